@@ -1,8 +1,13 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/ayrtonsato/video-catalog-golang/internal/metrics"
 	"github.com/ayrtonsato/video-catalog-golang/internal/setup"
 )
 
@@ -17,16 +22,124 @@ func main() {
 	loggerSetup.Start()
 	logger := loggerSetup.Log
 
+	tracing := setup.NewTracing(&c, logger)
+	err = tracing.Start()
+	if err != nil {
+		logger.Fatalf("tracing: failed to start: %v", err.Error())
+	}
+
 	db := setup.NewDB(&c)
 	err = db.StartConn()
 	if err != nil {
 		logger.Fatalf("db: failed to start connection: %v", err.Error())
 	}
 
-	server := setup.NewServer(db.DB, &c, logger)
+	migrator, err := setup.NewMigrator(db.DB, logger)
+	if err != nil {
+		logger.Fatalf("migrate: failed to load migrations: %v", err.Error())
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err = runMigrate(context.Background(), migrator, os.Args[2:])
+		_ = db.Close()
+		if err != nil {
+			logger.Fatalf("migrate: %v", err.Error())
+		}
+		return
+	}
+	if c.MigrateOnStart {
+		err = migrator.Up(context.Background())
+		if err != nil {
+			logger.Fatalf("migrate: failed to migrate on start: %v", err.Error())
+		}
+	}
+
+	err = metrics.RegisterDB(db.DB)
+	if err != nil {
+		logger.Fatalf("metrics: failed to register db stats: %v", err.Error())
+	}
+
+	media := setup.NewMedia(&c)
+	err = media.Start()
+	if err != nil {
+		logger.Fatalf("media: failed to start storage: %v", err.Error())
+	}
+
+	auth := setup.NewAuth(&c)
+	err = auth.Start()
+	if err != nil {
+		logger.Fatalf("auth: failed to load JWKS: %v", err.Error())
+	}
+
+	limits := setup.NewRateLimit(&c, logger)
+	err = limits.Start()
+	if err != nil {
+		logger.Fatalf("ratelimit: failed to start: %v", err.Error())
+	}
+
+	cache := setup.NewCache(&c, logger)
+	err = cache.Start()
+	if err != nil {
+		logger.Fatalf("cache: failed to start: %v", err.Error())
+	}
+
+	consumers := setup.NewConsumers(db.DB, &c, logger)
+	err = consumers.Start()
+	if err != nil {
+		logger.Fatalf("consumers: failed to start: %v", err.Error())
+	}
+
+	jobs := setup.NewJobs(db.DB, &media, &c, logger)
+	jobs.Start()
+
+	health := setup.NewHealth(db.DB, &limits, &cache, &consumers, &c, logger)
+	err = health.Start()
+	if err != nil {
+		logger.Fatalf("health: failed to start: %v", err.Error())
+	}
+
+	server := setup.NewServer(db.DB, &media, &auth, &limits, &cache, &health, &c, logger)
 
-	err = server.Start()
+	exitCode := 0
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.Start()
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case sig := <-signals:
+		logger.Infof("gin-server: received %v, shutting down", sig)
+	case err = <-serverErr:
+		if err != nil {
+			logger.Errorf("gin-server: failed to start gin: %v", err.Error())
+			exitCode = 1
+		}
+	}
+	signal.Stop(signals)
+
+	ctx, cancel := context.WithTimeout(context.Background(), server.ShutdownTimeout())
+	err = server.Shutdown(ctx)
+	cancel()
 	if err != nil {
-		logger.Fatalf("gin-server: failed to start gin: %v", err.Error())
+		logger.Errorf("gin-server: failed to drain requests: %v", err.Error())
+		exitCode = 1
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), server.ShutdownTimeout())
+	tracing.Close(ctx)
+	cancel()
+
+	// background workers still write to the database, they are stopped
+	// before the pool is closed
+	consumers.Close()
+	jobs.Close()
+	cache.Close()
+	limits.Close()
+	if err = db.Close(); err != nil {
+		logger.Errorf("db: failed to close connection: %v", err.Error())
+		exitCode = 1
 	}
+	logger.Info("gin-server: stopped")
+	os.Exit(exitCode)
 }
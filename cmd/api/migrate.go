@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ayrtonsato/video-catalog-golang/pkg/migrate"
+)
+
+const migrateUsage = "usage: api migrate up | down [N] | goto VERSION | force VERSION | status"
+
+// runMigrate runs the migrate subcommand, down reverts a single migration
+// unless N is given
+func runMigrate(ctx context.Context, migrator *migrate.Migrator, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	switch args[0] {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+			steps = n
+		}
+		return migrator.Down(ctx, steps)
+	case "goto", "force":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+		version, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		if args[0] == "goto" {
+			return migrator.Goto(ctx, uint(version))
+		}
+		return migrator.Force(ctx, uint(version))
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stdout, "version %d, latest %d, dirty %v\n", status.Version, status.Latest, status.Dirty)
+		for _, migration := range status.Pending {
+			fmt.Fprintf(os.Stdout, "pending %06d_%s\n", migration.Version, migration.Name)
+		}
+		return nil
+	}
+	return errors.New(migrateUsage)
+}
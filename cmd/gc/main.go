@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/setup"
+)
+
+// gc deletes the media files no video references anymore and prints the
+// report as JSON, run it with -dry-run to only list them
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report orphaned files without deleting them")
+	grace := flag.Duration("grace", 0, "keep files younger than this, overrides MEDIA_GC_GRACE_PERIOD")
+	flag.Parse()
+
+	c := setup.Config{}
+	err := c.Load(".")
+	if err != nil {
+		log.Fatalf("config: failed to load config: %v", err.Error())
+	}
+	if *grace > 0 {
+		c.MediaGCGracePeriod = *grace
+	}
+
+	loggerSetup := setup.NewLogger(&c)
+	loggerSetup.Start()
+	logger := loggerSetup.Log
+
+	db := setup.NewDB(&c)
+	err = db.StartConn()
+	if err != nil {
+		logger.Fatalf("db: failed to start connection: %v", err.Error())
+	}
+
+	media := setup.NewMedia(&c)
+	err = media.Start()
+	if err != nil {
+		logger.Fatalf("media: failed to start storage: %v", err.Error())
+	}
+
+	collector := setup.NewMediaGC(db.DB, &media, &c, logger)
+	report, err := collector.Collect(context.Background(), *dryRun)
+	if err != nil {
+		logger.Fatalf("media gc: failed to collect: %v", err.Error())
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(report); err != nil {
+		logger.Fatalf("media gc: failed to print report: %v", err.Error())
+	}
+	if len(report.Failed) > 0 {
+		os.Exit(1)
+	}
+}
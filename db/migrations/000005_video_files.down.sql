@@ -0,0 +1,5 @@
+ALTER TABLE "videos"
+    DROP COLUMN IF EXISTS video_file,
+    DROP COLUMN IF EXISTS trailer_file,
+    DROP COLUMN IF EXISTS thumb_file,
+    DROP COLUMN IF EXISTS banner_file;
\ No newline at end of file
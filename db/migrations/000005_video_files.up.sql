@@ -0,0 +1,5 @@
+ALTER TABLE "videos"
+    ADD COLUMN IF NOT EXISTS video_file VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS trailer_file VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS thumb_file VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS banner_file VARCHAR(255);
\ No newline at end of file
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_videos_status;
+
+ALTER TABLE "videos"
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS status_error,
+    DROP COLUMN IF EXISTS processing_at,
+    DROP COLUMN IF EXISTS completed_at,
+    DROP COLUMN IF EXISTS failed_at;
+
+DROP TYPE IF EXISTS video_status;
\ No newline at end of file
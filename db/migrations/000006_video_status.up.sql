@@ -0,0 +1,10 @@
+CREATE TYPE video_status AS ENUM ('pending', 'processing', 'completed', 'failed');
+
+ALTER TABLE "videos"
+    ADD COLUMN IF NOT EXISTS status video_status NOT NULL DEFAULT 'pending',
+    ADD COLUMN IF NOT EXISTS status_error TEXT,
+    ADD COLUMN IF NOT EXISTS processing_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS failed_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_videos_status ON videos(status);
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS dead_letters;
+DROP TABLE IF EXISTS processed_messages;
+
+ALTER TABLE "videos"
+    DROP COLUMN IF EXISTS encoded_files;
\ No newline at end of file
@@ -0,0 +1,18 @@
+ALTER TABLE "videos"
+    ADD COLUMN IF NOT EXISTS encoded_files JSONB NOT NULL DEFAULT '[]';
+
+CREATE TABLE IF NOT EXISTS "processed_messages" (
+    source VARCHAR(50) NOT NULL,
+    message_id VARCHAR(255) NOT NULL,
+    processed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (source, message_id)
+);
+
+CREATE TABLE IF NOT EXISTS "dead_letters" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    source VARCHAR(50) NOT NULL,
+    payload BYTEA NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    replayed_at TIMESTAMP
+);
\ No newline at end of file
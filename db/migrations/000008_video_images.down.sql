@@ -0,0 +1 @@
+DROP TABLE IF EXISTS video_images;
\ No newline at end of file
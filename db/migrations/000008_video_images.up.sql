@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS "video_images" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    video_id UUID NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    path VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (video_id, kind, width),
+    CONSTRAINT fk_video_image
+        FOREIGN KEY (video_id)
+            REFERENCES videos(id)
+);
\ No newline at end of file
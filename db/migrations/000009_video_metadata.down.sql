@@ -0,0 +1,3 @@
+ALTER TABLE "videos"
+    DROP COLUMN IF EXISTS duration_mismatch,
+    DROP COLUMN IF EXISTS metadata;
\ No newline at end of file
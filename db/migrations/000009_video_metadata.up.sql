@@ -0,0 +1,3 @@
+ALTER TABLE "videos"
+    ADD COLUMN IF NOT EXISTS metadata JSONB,
+    ADD COLUMN IF NOT EXISTS duration_mismatch BOOLEAN NOT NULL DEFAULT FALSE;
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS "video_subtitles" (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    video_id UUID NOT NULL,
+    language VARCHAR(35) NOT NULL,
+    label VARCHAR(255) NOT NULL,
+    path VARCHAR(255) NOT NULL,
+    cue_count INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (video_id, language),
+    CONSTRAINT fk_video_subtitle
+        FOREIGN KEY (video_id)
+            REFERENCES videos(id)
+);
\ No newline at end of file
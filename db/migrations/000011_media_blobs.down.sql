@@ -0,0 +1 @@
+DROP TABLE IF EXISTS media_blobs;
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS "media_blobs" (
+    hash CHAR(64) PRIMARY KEY,
+    path VARCHAR(255) NOT NULL UNIQUE,
+    size BIGINT NOT NULL,
+    ref_count INT NOT NULL DEFAULT 0 CHECK (ref_count >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
\ No newline at end of file
@@ -0,0 +1,34 @@
+// Package migrations embeds the SQL migrations so the binary knows which
+// schema version it was built against
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+// FS holds the golang-migrate files, named as 000001_name.up.sql
+//
+//go:embed *.sql
+var FS embed.FS
+
+// ExpectedVersion is the version of the newest embedded up migration
+func ExpectedVersion() (uint, error) {
+	names, err := fs.Glob(FS, "*.up.sql")
+	if err != nil {
+		return 0, err
+	}
+	var expected uint
+	for _, name := range names {
+		version, err := strconv.ParseUint(strings.SplitN(name, "_", 2)[0], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		if uint(version) > expected {
+			expected = uint(version)
+		}
+	}
+	return expected, nil
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpectedVersion(t *testing.T) {
+	version, err := ExpectedVersion()
+	require.NoError(t, err)
+	require.NotZero(t, version)
+
+	ups, err := fs.Glob(FS, "*.up.sql")
+	require.NoError(t, err)
+	downs, err := fs.Glob(FS, "*.down.sql")
+	require.NoError(t, err)
+	require.Len(t, ups, int(version))
+	require.Len(t, downs, int(version))
+}
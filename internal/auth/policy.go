@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidPolicy = errors.New("auth: invalid policy")
+
+// Permission is an action on an entity, written as entity:action
+type Permission string
+
+const (
+	CategoryRead   Permission = "category:read"
+	CategoryCreate Permission = "category:create"
+	CategoryUpdate Permission = "category:update"
+	CategoryDelete Permission = "category:delete"
+	GenreRead      Permission = "genre:read"
+	GenreCreate    Permission = "genre:create"
+	GenreUpdate    Permission = "genre:update"
+	GenreDelete    Permission = "genre:delete"
+	VideoRead      Permission = "video:read"
+	VideoUpdate    Permission = "video:update"
+	VideoDelete    Permission = "video:delete"
+	MediaUpload    Permission = "media:upload"
+	AdminManage    Permission = "admin:manage"
+	MetricsRead    Permission = "metrics:read"
+)
+
+// DefaultPolicy lets editors create and update the catalog while deleting
+// is left to admins. Anonymous callers may read the catalog but never change
+// it
+const DefaultPolicy = "admin=*;" +
+	"editor=category:read,category:create,category:update,genre:read,genre:create,genre:update,video:read,video:update,media:upload;" +
+	"viewer=category:read,genre:read,video:read;" +
+	"anonymous=category:read,genre:read,video:read"
+
+// ScopePermissions are the permissions of every API key scope. They are not
+// part of the Policy so a role named like a scope grants nothing to keys and
+// editing the policy can not widen what issued keys may do
+var ScopePermissions = map[string][]Permission{
+	"catalog:read": {CategoryRead, GenreRead, VideoRead},
+	"catalog:write": {CategoryRead, CategoryCreate, CategoryUpdate,
+		GenreRead, GenreCreate, GenreUpdate, VideoRead, VideoUpdate},
+	"media:upload": {VideoRead, MediaUpload},
+	"metrics:read": {MetricsRead},
+}
+
+// Policy grants permissions to roles, a grant is a permission, entity:* for
+// every action on an entity or * for everything
+type Policy struct {
+	grants map[string][]string
+}
+
+// ParsePolicy reads a policy written as role=grant,grant;role=grant
+func ParsePolicy(spec string) (Policy, error) {
+	policy := Policy{grants: make(map[string][]string)}
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		role := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || role == "" {
+			return Policy{}, fmt.Errorf("%w: %q", ErrInvalidPolicy, entry)
+		}
+		for _, grant := range strings.Split(parts[1], ",") {
+			grant = strings.TrimSpace(grant)
+			if grant == "" {
+				continue
+			}
+			if grant != "*" && !strings.Contains(grant, ":") {
+				return Policy{}, fmt.Errorf("%w: grant %q of %s", ErrInvalidPolicy, grant, role)
+			}
+			policy.grants[role] = append(policy.grants[role], grant)
+		}
+	}
+	if len(policy.grants) == 0 {
+		return Policy{}, fmt.Errorf("%w: no roles", ErrInvalidPolicy)
+	}
+	return policy, nil
+}
+
+// Allows tells whether any role of principal is granted permission by the
+// policy or any of its scopes carries it
+func (p Policy) Allows(principal Principal, permission Permission) bool {
+	for _, scope := range principal.Scopes {
+		for _, granted := range ScopePermissions[scope] {
+			if granted == permission {
+				return true
+			}
+		}
+	}
+	entity := strings.SplitN(string(permission), ":", 2)[0]
+	for _, role := range principal.Roles {
+		for _, grant := range p.grants[role] {
+			if grant == "*" || grant == string(permission) || grant == entity+":*" {
+				return true
+			}
+		}
+	}
+	return false
+}
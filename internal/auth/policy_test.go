@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_Allows(t *testing.T) {
+	policy, err := ParsePolicy(DefaultPolicy + "; auditor = video:* ")
+	require.NoError(t, err)
+
+	admin := Principal{Subject: "a", Roles: []string{"admin"}}
+	editor := Principal{Subject: "e", Roles: []string{"viewer", "editor"}}
+	auditor := Principal{Subject: "u", Roles: []string{"auditor"}}
+	unknown := Principal{Subject: "n"}
+	anonymous := Principal{Roles: []string{RoleAnonymous}}
+
+	require.True(t, policy.Allows(admin, CategoryDelete))
+	require.True(t, policy.Allows(editor, CategoryCreate))
+	require.True(t, policy.Allows(editor, GenreUpdate))
+	require.False(t, policy.Allows(editor, CategoryDelete))
+	require.False(t, policy.Allows(editor, GenreDelete))
+	require.True(t, policy.Allows(auditor, VideoDelete))
+	require.False(t, policy.Allows(auditor, CategoryRead))
+	require.False(t, policy.Allows(unknown, CategoryRead))
+	require.True(t, policy.Allows(anonymous, CategoryRead))
+	require.False(t, policy.Allows(anonymous, AdminManage))
+}
+
+func TestPolicy_AnonymousOnlyReads(t *testing.T) {
+	policy, err := ParsePolicy(DefaultPolicy)
+	require.NoError(t, err)
+	anonymous := Principal{Roles: []string{RoleAnonymous}}
+
+	for _, permission := range []Permission{CategoryRead, GenreRead, VideoRead} {
+		require.True(t, policy.Allows(anonymous, permission), permission)
+	}
+	for _, permission := range []Permission{
+		CategoryCreate, CategoryUpdate, CategoryDelete,
+		GenreCreate, GenreUpdate, GenreDelete,
+		VideoUpdate, VideoDelete, MediaUpload, AdminManage,
+	} {
+		require.False(t, policy.Allows(anonymous, permission), permission)
+	}
+}
+
+func TestPolicy_AllowsScopes(t *testing.T) {
+	policy, err := ParsePolicy(DefaultPolicy + ";catalog:read=*")
+	require.NoError(t, err)
+
+	reader := Principal{Subject: "apikey:1", Scopes: []string{"catalog:read"}}
+	uploader := Principal{Subject: "apikey:2", Scopes: []string{"media:upload"}}
+	writer := Principal{Subject: "apikey:3", Scopes: []string{"catalog:write"}}
+	// a role named like a scope is not a scope
+	role := Principal{Subject: "u", Roles: []string{"media:upload"}}
+
+	require.True(t, policy.Allows(reader, VideoRead))
+	require.False(t, policy.Allows(reader, CategoryDelete))
+	require.False(t, policy.Allows(reader, AdminManage))
+	require.True(t, policy.Allows(uploader, MediaUpload))
+	require.False(t, policy.Allows(uploader, CategoryRead))
+	require.True(t, policy.Allows(writer, GenreUpdate))
+	require.False(t, policy.Allows(writer, MediaUpload))
+	require.False(t, policy.Allows(role, MediaUpload))
+}
+
+func TestParsePolicy_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "admin", "=*", "editor=category"} {
+		_, err := ParsePolicy(spec)
+		require.ErrorIs(t, err, ErrInvalidPolicy, spec)
+	}
+}
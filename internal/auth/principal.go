@@ -0,0 +1,41 @@
+// Package auth carries the authenticated caller of a request through its
+// context, for services and audit logging
+package auth
+
+import "context"
+
+type contextKey struct{}
+
+// PrincipalKey is the gin context key the principal is also stored under
+const PrincipalKey = "principal"
+
+// RoleAnonymous is held by requests without credentials when anonymous
+// access is enabled
+const RoleAnonymous = "anonymous"
+
+// Principal is a caller, users hold roles granted through the Policy and API
+// keys hold scopes with fixed permissions
+type Principal struct {
+	Subject string
+	Roles   []string
+	Scopes  []string
+}
+
+func (p Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+// FromContext returns the principal of an authenticated request
+func FromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(contextKey{}).(Principal)
+	return principal, ok
+}
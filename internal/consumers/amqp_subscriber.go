@@ -0,0 +1,265 @@
+package consumers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/tracing"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/streadway/amqp"
+)
+
+const (
+	reconnectDelay = 5 * time.Second
+
+	DefaultRetryDelay  = 30 * time.Second
+	DefaultMaxAttempts = 5
+
+	// retryCountHeader counts the failed attempts of a message
+	retryCountHeader = "x-retry-count"
+)
+
+type Handler interface {
+	Handle(ctx context.Context, payload []byte) error
+	// DeadLetter stores a message that kept failing, cause is the error of
+	// the last attempt
+	DeadLetter(ctx context.Context, payload []byte, cause error) error
+}
+
+type publisher interface {
+	Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+}
+
+// AMQPSubscriber consumes a durable queue and hands every delivery to a
+// Handler, acknowledging it on success. A failed message is parked in the
+// "<queue>.retry" queue for retryDelay and comes back to the queue once it
+// expires, after maxAttempts failures it is handed to Handler.DeadLetter
+// instead. The connection is re-established until Close is called.
+type AMQPSubscriber struct {
+	url         string
+	queue       string
+	prefetch    int
+	retryDelay  time.Duration
+	maxAttempts int
+	handler     Handler
+	log         logger.Logger
+
+	mu      sync.Mutex
+	conn    *amqp.Connection
+	pub     publisher
+	closing chan struct{}
+	done    chan struct{}
+}
+
+func NewAMQPSubscriber(url string, queue string, prefetch int,
+	retryDelay time.Duration, maxAttempts int,
+	handler Handler, log logger.Logger) *AMQPSubscriber {
+	if retryDelay <= 0 {
+		retryDelay = DefaultRetryDelay
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	return &AMQPSubscriber{
+		url:         url,
+		queue:       queue,
+		prefetch:    prefetch,
+		retryDelay:  retryDelay,
+		maxAttempts: maxAttempts,
+		handler:     handler,
+		log:         log,
+		closing:     make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+func (s *AMQPSubscriber) retryQueue() string {
+	return s.queue + ".retry"
+}
+
+func (s *AMQPSubscriber) connect() (<-chan amqp.Delivery, error) {
+	conn, err := amqp.Dial(s.url)
+	if err != nil {
+		return nil, err
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if _, err = ch.QueueDeclare(s.queue, true, false, false, false, nil); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	// expired messages of the retry queue go back to the queue through the
+	// default exchange
+	retryArgs := amqp.Table{
+		"x-message-ttl":             s.retryDelay.Milliseconds(),
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": s.queue,
+	}
+	if _, err = ch.QueueDeclare(s.retryQueue(), true, false, false, false, retryArgs); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err = ch.Qos(s.prefetch, 0, false); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	deliveries, err := ch.Consume(s.queue, "", false, false, false, false, nil)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	s.mu.Lock()
+	s.conn = conn
+	s.pub = ch
+	s.mu.Unlock()
+	return deliveries, nil
+}
+
+// Start connects to the broker and consumes in background, the first
+// connection error is returned so misconfiguration is noticed on startup
+func (s *AMQPSubscriber) Start() error {
+	deliveries, err := s.connect()
+	if err != nil {
+		return err
+	}
+	go s.run(deliveries)
+	return nil
+}
+
+func (s *AMQPSubscriber) run(deliveries <-chan amqp.Delivery) {
+	defer close(s.done)
+	for {
+		for delivery := range deliveries {
+			s.handle(delivery)
+		}
+		select {
+		case <-s.closing:
+			return
+		default:
+		}
+		s.log.Warnf("amqp: connection to queue %v lost, reconnecting", s.queue)
+		for {
+			select {
+			case <-s.closing:
+				return
+			case <-time.After(reconnectDelay):
+			}
+			var err error
+			deliveries, err = s.connect()
+			if err == nil {
+				break
+			}
+			s.log.Errorf("amqp: failed to reconnect: %v", err)
+		}
+		select {
+		case <-s.closing:
+			s.closeConn()
+			return
+		default:
+		}
+	}
+}
+
+func (s *AMQPSubscriber) handle(delivery amqp.Delivery) {
+	ctx, span := tracing.Start(context.Background(), "amqp consume "+s.queue)
+	defer span.End()
+	log := logger.With(s.log, "queue", s.queue, "message_id", delivery.MessageId)
+	ctx = logger.WithContext(ctx, log)
+	err := s.handler.Handle(ctx, delivery.Body)
+	if err == nil {
+		s.ack(delivery, log)
+		return
+	}
+	attempts := retryCount(delivery.Headers) + 1
+	if attempts >= s.maxAttempts {
+		log.Warnf("amqp: giving up on message after %d attempts: %v", attempts, err)
+		dlErr := s.handler.DeadLetter(ctx, delivery.Body, err)
+		if dlErr == nil {
+			s.ack(delivery, log)
+			return
+		}
+		log.Errorf("amqp: failed to dead letter message: %v", dlErr)
+	}
+	if pubErr := s.retry(delivery, attempts); pubErr != nil {
+		log.Errorf("amqp: failed to schedule retry: %v", pubErr)
+		if nackErr := delivery.Nack(false, true); nackErr != nil {
+			log.Errorf("amqp: failed to nack message: %v", nackErr)
+		}
+		return
+	}
+	s.ack(delivery, log)
+}
+
+// retry publishes a copy of delivery to the retry queue with its attempt
+// count, the original must be acknowledged afterwards
+func (s *AMQPSubscriber) retry(delivery amqp.Delivery, attempts int) error {
+	headers := amqp.Table{}
+	for k, v := range delivery.Headers {
+		headers[k] = v
+	}
+	headers[retryCountHeader] = int32(attempts)
+	s.mu.Lock()
+	pub := s.pub
+	s.mu.Unlock()
+	return pub.Publish("", s.retryQueue(), false, false, amqp.Publishing{
+		Headers:       headers,
+		ContentType:   delivery.ContentType,
+		CorrelationId: delivery.CorrelationId,
+		MessageId:     delivery.MessageId,
+		Timestamp:     delivery.Timestamp,
+		Type:          delivery.Type,
+		DeliveryMode:  amqp.Persistent,
+		Body:          delivery.Body,
+	})
+}
+
+func (s *AMQPSubscriber) ack(delivery amqp.Delivery, log logger.Logger) {
+	if ackErr := delivery.Ack(false); ackErr != nil {
+		log.Errorf("amqp: failed to ack message: %v", ackErr)
+	}
+}
+
+// retryCount reads the attempt count set by retry, the broker may hand the
+// integer back with any width
+func retryCount(headers amqp.Table) int {
+	switch v := headers[retryCountHeader].(type) {
+	case int:
+		return v
+	case int16:
+		return int(v)
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	}
+	return 0
+}
+
+func (s *AMQPSubscriber) closeConn() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil || s.conn.IsClosed() {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+// Connected reports whether the broker connection is currently open, it is
+// false while reconnecting
+func (s *AMQPSubscriber) Connected() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conn != nil && !s.conn.IsClosed()
+}
+
+// Close stops consuming and waits for the message being handled
+func (s *AMQPSubscriber) Close() error {
+	close(s.closing)
+	err := s.closeConn()
+	<-s.done
+	return err
+}
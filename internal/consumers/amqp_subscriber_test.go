@@ -0,0 +1,170 @@
+package consumers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mock_logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAcknowledger struct {
+	acked   int
+	nacked  int
+	requeue bool
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acked++
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	a.nacked++
+	a.requeue = requeue
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+type fakePublisher struct {
+	key       string
+	published []amqp.Publishing
+	err       error
+}
+
+func (p *fakePublisher) Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.key = key
+	p.published = append(p.published, msg)
+	return nil
+}
+
+type fakeHandler struct {
+	err           error
+	deadLetters   [][]byte
+	deadLetterErr error
+}
+
+func (h *fakeHandler) Handle(_ context.Context, payload []byte) error {
+	return h.err
+}
+
+func (h *fakeHandler) DeadLetter(_ context.Context, payload []byte, cause error) error {
+	if h.deadLetterErr != nil {
+		return h.deadLetterErr
+	}
+	h.deadLetters = append(h.deadLetters, payload)
+	return nil
+}
+
+func TestAMQPSubscriber_Handle(t *testing.T) {
+	payload := []byte(`{"message_id": "message-1"}`)
+	newSubscriber := func(ctrl *gomock.Controller, handler Handler, pub publisher) *AMQPSubscriber {
+		log := mock_logger.NewMockLogger(ctrl)
+		log.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+		log.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
+		s := NewAMQPSubscriber("", "videos.encoded", 1, time.Second, 3, handler, log)
+		s.pub = pub
+		return s
+	}
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Should acknowledge handled messages",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				ack := &fakeAcknowledger{}
+				pub := &fakePublisher{}
+				SUT := newSubscriber(ctrl, &fakeHandler{}, pub)
+				SUT.handle(amqp.Delivery{Acknowledger: ack, Body: payload})
+				require.Equal(t, 1, ack.acked)
+				require.Empty(t, pub.published)
+			},
+		},
+		{
+			name: "Should park failed messages in the retry queue with their attempt count",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				ack := &fakeAcknowledger{}
+				pub := &fakePublisher{}
+				SUT := newSubscriber(ctrl, &fakeHandler{err: errors.New("connection refused")}, pub)
+				SUT.handle(amqp.Delivery{
+					Acknowledger: ack,
+					Headers:      amqp.Table{retryCountHeader: int64(1)},
+					Body:         payload,
+				})
+				require.Equal(t, 1, ack.acked)
+				require.Equal(t, 0, ack.nacked)
+				require.Equal(t, "videos.encoded.retry", pub.key)
+				require.Len(t, pub.published, 1)
+				require.Equal(t, int32(2), pub.published[0].Headers[retryCountHeader])
+				require.Equal(t, payload, pub.published[0].Body)
+			},
+		},
+		{
+			name: "Should dead letter messages that exhausted their attempts",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				ack := &fakeAcknowledger{}
+				pub := &fakePublisher{}
+				handler := &fakeHandler{err: errors.New("connection refused")}
+				SUT := newSubscriber(ctrl, handler, pub)
+				SUT.handle(amqp.Delivery{
+					Acknowledger: ack,
+					Headers:      amqp.Table{retryCountHeader: int32(2)},
+					Body:         payload,
+				})
+				require.Equal(t, 1, ack.acked)
+				require.Empty(t, pub.published)
+				require.Equal(t, [][]byte{payload}, handler.deadLetters)
+			},
+		},
+		{
+			name: "Should retry later when the dead letter cannot be stored",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				ack := &fakeAcknowledger{}
+				pub := &fakePublisher{}
+				handler := &fakeHandler{
+					err:           errors.New("connection refused"),
+					deadLetterErr: errors.New("fake_error"),
+				}
+				SUT := newSubscriber(ctrl, handler, pub)
+				SUT.handle(amqp.Delivery{
+					Acknowledger: ack,
+					Headers:      amqp.Table{retryCountHeader: int32(2)},
+					Body:         payload,
+				})
+				require.Equal(t, 1, ack.acked)
+				require.Len(t, pub.published, 1)
+			},
+		},
+		{
+			name: "Should requeue when the retry cannot be published",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				ack := &fakeAcknowledger{}
+				pub := &fakePublisher{err: errors.New("channel closed")}
+				SUT := newSubscriber(ctrl, &fakeHandler{err: errors.New("connection refused")}, pub)
+				SUT.handle(amqp.Delivery{Acknowledger: ack, Body: payload})
+				require.Equal(t, 0, ack.acked)
+				require.Equal(t, 1, ack.nacked)
+				require.True(t, ack.requeue)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tc.testCase(t, ctrl)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package consumers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+)
+
+const EncoderSource = "encoder"
+
+type EncoderConsumer struct {
+	processor   services.MessageProcessor
+	deadLetters repositories.DeadLetterDB
+	log         logger.Logger
+}
+
+func NewEncoderConsumer(processor services.MessageProcessor,
+	deadLetters repositories.DeadLetterDB,
+	log logger.Logger) EncoderConsumer {
+	return EncoderConsumer{
+		processor:   processor,
+		deadLetters: deadLetters,
+		log:         log,
+	}
+}
+
+// Handle processes an encoder message. Messages that can never succeed are
+// moved to the dead letter store and acknowledged, a returned error means
+// the message must be delivered again.
+func (c *EncoderConsumer) Handle(ctx context.Context, payload []byte) error {
+	log := logger.FromContext(ctx, c.log)
+	err := c.processor.Process(ctx, payload)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, services.ErrInvalidMessage) &&
+		!errors.Is(err, services.ErrNotFound) &&
+		!errors.Is(err, services.ErrConflict) {
+		log.Errorf("encoder consumer: failed to process message: %v", err)
+		return err
+	}
+	log.Warnf("encoder consumer: dead lettering message: %v", err)
+	return c.DeadLetter(ctx, payload, err)
+}
+
+// DeadLetter stores a message that can not be processed with the reason
+func (c *EncoderConsumer) DeadLetter(ctx context.Context, payload []byte, cause error) error {
+	_, err := c.deadLetters.Save(ctx, EncoderSource, payload, cause.Error())
+	return err
+}
@@ -0,0 +1,119 @@
+package consumers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	mock_repositories "github.com/ayrtonsato/video-catalog-golang/internal/repositories/mocks"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	mock_services "github.com/ayrtonsato/video-catalog-golang/internal/services/mocks"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	mock_logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoderConsumer_Handle(t *testing.T) {
+	payload := []byte(`{"message_id": "message-1"}`)
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Should acknowledge processed messages",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				processor := mock_services.NewMockMessageProcessor(ctrl)
+				deadLetters := mock_repositories.NewMockDeadLetterDB(ctrl)
+				log := mock_logger.NewMockLogger(ctrl)
+				processor.EXPECT().Process(gomock.Any(), payload).Times(1).Return(nil)
+				SUT := NewEncoderConsumer(processor, deadLetters, log)
+				require.NoError(t, SUT.Handle(context.Background(), payload))
+			},
+		},
+		{
+			name: "Should dead letter malformed messages",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				processor := mock_services.NewMockMessageProcessor(ctrl)
+				deadLetters := mock_repositories.NewMockDeadLetterDB(ctrl)
+				log := mock_logger.NewMockLogger(ctrl)
+				processErr := fmt.Errorf("%w: video_id: must be a valid UUID.", services.ErrInvalidMessage)
+				processor.EXPECT().Process(gomock.Any(), payload).Times(1).Return(processErr)
+				log.EXPECT().Warnf(gomock.Any(), gomock.Any()).Times(1)
+				deadLetters.EXPECT().
+					Save(gomock.Any(), EncoderSource, payload, processErr.Error()).
+					Times(1).
+					Return(models.DeadLetter{}, nil)
+				SUT := NewEncoderConsumer(processor, deadLetters, log)
+				require.NoError(t, SUT.Handle(context.Background(), payload))
+			},
+		},
+		{
+			name: "Should ask for redelivery on transient errors",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				processor := mock_services.NewMockMessageProcessor(ctrl)
+				deadLetters := mock_repositories.NewMockDeadLetterDB(ctrl)
+				log := mock_logger.NewMockLogger(ctrl)
+				processor.EXPECT().Process(gomock.Any(), payload).Times(1).Return(errors.New("connection refused"))
+				log.EXPECT().Errorf(gomock.Any(), gomock.Any()).Times(1)
+				SUT := NewEncoderConsumer(processor, deadLetters, log)
+				require.Error(t, SUT.Handle(context.Background(), payload))
+			},
+		},
+		{
+			name: "Should log with the logger of the message context",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				processor := mock_services.NewMockMessageProcessor(ctrl)
+				deadLetters := mock_repositories.NewMockDeadLetterDB(ctrl)
+				log := mock_logger.NewMockLogger(ctrl)
+				messageLog := mock_logger.NewMockLogger(ctrl)
+				processor.EXPECT().Process(gomock.Any(), payload).Times(1).Return(errors.New("connection refused"))
+				messageLog.EXPECT().Errorf(gomock.Any(), gomock.Any()).Times(1)
+				SUT := NewEncoderConsumer(processor, deadLetters, log)
+				ctx := logger.WithContext(context.Background(), messageLog)
+				require.Error(t, SUT.Handle(ctx, payload))
+			},
+		},
+		{
+			name: "Should ask for redelivery when dead letter cannot be saved",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				processor := mock_services.NewMockMessageProcessor(ctrl)
+				deadLetters := mock_repositories.NewMockDeadLetterDB(ctrl)
+				log := mock_logger.NewMockLogger(ctrl)
+				processor.EXPECT().Process(gomock.Any(), payload).Times(1).Return(services.ErrNotFound)
+				log.EXPECT().Warnf(gomock.Any(), gomock.Any()).Times(1)
+				deadLetters.EXPECT().
+					Save(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(models.DeadLetter{}, errors.New("fake_error"))
+				SUT := NewEncoderConsumer(processor, deadLetters, log)
+				require.Error(t, SUT.Handle(context.Background(), payload))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tc.testCase(t, ctrl)
+		})
+	}
+}
+
+func TestEncoderConsumer_DeadLetter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	payload := []byte(`{"message_id": "message-1"}`)
+	processor := mock_services.NewMockMessageProcessor(ctrl)
+	deadLetters := mock_repositories.NewMockDeadLetterDB(ctrl)
+	log := mock_logger.NewMockLogger(ctrl)
+	deadLetters.EXPECT().
+		Save(gomock.Any(), EncoderSource, payload, "connection refused").
+		Times(1).
+		Return(models.DeadLetter{}, nil)
+	SUT := NewEncoderConsumer(processor, deadLetters, log)
+	require.NoError(t, SUT.DeadLetter(context.Background(), payload, errors.New("connection refused")))
+}
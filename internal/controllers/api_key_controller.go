@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/helpers"
+	"github.com/ayrtonsato/video-catalog-golang/internal/protocols"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	"github.com/gofrs/uuid"
+)
+
+type GetAPIKeysController struct {
+	apiKeys services.APIKeys
+}
+
+func NewGetAPIKeysController(apiKeys services.APIKeys) GetAPIKeysController {
+	return GetAPIKeysController{
+		apiKeys: apiKeys,
+	}
+}
+
+func (g GetAPIKeysController) Handle(ctx context.Context) protocols.HttpResponse {
+	keys, err := g.apiKeys.GetAPIKeys(ctx)
+	if err != nil {
+		return helpers.HTTPServiceError(err)
+	}
+	return helpers.HTTPOk(keys)
+}
+
+type IssueAPIKeyController struct {
+	apiKeys    services.APIKeys
+	dto        IssueAPIKeyDTO
+	validation protocols.Validation
+}
+
+func NewIssueAPIKeyController(apiKeys services.APIKeys,
+	dto IssueAPIKeyDTO,
+	validation protocols.Validation) IssueAPIKeyController {
+	return IssueAPIKeyController{
+		apiKeys:    apiKeys,
+		dto:        dto,
+		validation: validation,
+	}
+}
+
+func (i IssueAPIKeyController) Handle(ctx context.Context) protocols.HttpResponse {
+	err := i.validation.Validate()
+	if err != nil {
+		return helpers.HTTPBadRequestError(err)
+	}
+	issued, err := i.apiKeys.Issue(ctx, i.dto.Name, i.dto.Scopes, i.dto.ExpiresAt, i.dto.CreatedBy)
+	if err != nil {
+		return helpers.HTTPServiceError(err)
+	}
+	return helpers.HTTPCreated(issued)
+}
+
+type RevokeAPIKeyController struct {
+	params  map[string]interface{}
+	apiKeys services.APIKeys
+}
+
+func NewRevokeAPIKeyController(apiKeys services.APIKeys,
+	params map[string]interface{}) RevokeAPIKeyController {
+	return RevokeAPIKeyController{
+		params:  params,
+		apiKeys: apiKeys,
+	}
+}
+
+func (r RevokeAPIKeyController) Handle(ctx context.Context) protocols.HttpResponse {
+	newUUID := r.params["id"].(uuid.UUID)
+	if newUUID == uuid.Nil {
+		return helpers.HTTPNotFound()
+	}
+	err := r.apiKeys.Revoke(ctx, newUUID)
+	if err != nil {
+		return helpers.HTTPServiceError(err)
+	}
+	return helpers.HTTPOkNoContent()
+}
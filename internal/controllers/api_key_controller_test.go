@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	mock_protocols "github.com/ayrtonsato/video-catalog-golang/internal/protocols/mocks"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	mock_services "github.com/ayrtonsato/video-catalog-golang/internal/services/mocks"
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueAPIKeyController_Handle(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "Should return 201 with the key", err: nil, expected: 201},
+		{name: "Should return 500 when the key is not saved", err: services.ErrSaveFailed, expected: 500},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			apiKeys := mock_services.NewMockAPIKeys(ctrl)
+			validationMock := mock_protocols.NewMockValidation(ctrl)
+			validationMock.EXPECT().Validate().Times(1).Return(nil)
+			apiKeys.EXPECT().
+				Issue(gomock.Any(), "ingest", []string{models.ScopeMediaUpload}, nil, "admin-1").
+				Times(1).
+				Return(models.IssuedAPIKey{Key: "vck_secret"}, tc.err)
+			dto := IssueAPIKeyDTO{Name: "ingest", Scopes: []string{models.ScopeMediaUpload}, CreatedBy: "admin-1"}
+			SUT := NewIssueAPIKeyController(apiKeys, dto, validationMock)
+			resp := SUT.Handle(context.Background())
+			require.Equal(t, tc.expected, resp.Code)
+		})
+	}
+}
+
+func TestIssueAPIKeyValidation_Validate(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	testCases := []struct {
+		name  string
+		dto   IssueAPIKeyDTO
+		valid bool
+	}{
+		{name: "valid", dto: IssueAPIKeyDTO{Name: "ingest", Scopes: []string{"catalog:read", "media:upload"}, ExpiresAt: &future}, valid: true},
+		{name: "without expiry", dto: IssueAPIKeyDTO{Name: "ingest", Scopes: []string{"catalog:write"}}, valid: true},
+		{name: "without scopes", dto: IssueAPIKeyDTO{Name: "ingest"}},
+		{name: "unknown scope", dto: IssueAPIKeyDTO{Name: "ingest", Scopes: []string{"admin"}}},
+		{name: "expired", dto: IssueAPIKeyDTO{Name: "ingest", Scopes: []string{"catalog:read"}, ExpiresAt: &past}},
+		{name: "without name", dto: IssueAPIKeyDTO{Scopes: []string{"catalog:read"}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewIssueAPIKeyValidation(&tc.dto).Validate()
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestRevokeAPIKeyController_Handle(t *testing.T) {
+	newUUID := uuid.Must(uuid.NewV4())
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "Should return 204 when revoked", err: nil, expected: 204},
+		{name: "Should return 404 when key not found or already revoked", err: services.ErrNotFound, expected: 404},
+		{name: "Should return 500 on unexpected errors", err: services.ErrUpdateFailed, expected: 500},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			apiKeys := mock_services.NewMockAPIKeys(ctrl)
+			apiKeys.EXPECT().Revoke(gomock.Any(), newUUID).Times(1).Return(tc.err)
+			SUT := NewRevokeAPIKeyController(apiKeys, map[string]interface{}{"id": newUUID})
+			resp := SUT.Handle(context.Background())
+			require.Equal(t, tc.expected, resp.Code)
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package controllers
+
+import "time"
+
+type IssueAPIKeyDTO struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+	CreatedBy string     `json:"-"`
+}
@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/helpers"
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+func apiKeyScopeValues() []interface{} {
+	values := make([]interface{}, len(models.APIKeyScopes))
+	for i, scope := range models.APIKeyScopes {
+		values[i] = scope
+	}
+	return values
+}
+
+type IssueAPIKeyValidation struct {
+	dto *IssueAPIKeyDTO
+}
+
+func NewIssueAPIKeyValidation(dto *IssueAPIKeyDTO) IssueAPIKeyValidation {
+	return IssueAPIKeyValidation{
+		dto: dto,
+	}
+}
+
+func (i IssueAPIKeyValidation) Validate() error {
+	return validation.ValidateStruct(i.dto,
+		validation.Field(&i.dto.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&i.dto.Scopes, validation.Required,
+			validation.Each(validation.Required, validation.In(apiKeyScopeValues()...))),
+		validation.Field(&i.dto.ExpiresAt, validation.Min(time.Now()).ErrorObject(helpers.ErrFutureRequired)),
+	)
+}
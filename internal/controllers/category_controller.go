@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"context"
+
 	"github.com/ayrtonsato/video-catalog-golang/internal/helpers"
 	"github.com/ayrtonsato/video-catalog-golang/internal/protocols"
 	"github.com/ayrtonsato/video-catalog-golang/internal/services"
@@ -17,10 +19,10 @@ func NewGetCategoriesController(category services.ReaderCategory) GetCategoriesC
 	}
 }
 
-func (c *GetCategoriesController) Handle() protocols.HttpResponse {
-	listCategories, err := c.category.GetCategories()
+func (c *GetCategoriesController) Handle(ctx context.Context) protocols.HttpResponse {
+	listCategories, err := c.category.GetCategories(ctx)
 	if err != nil {
-		return helpers.HTTPInternalError()
+		return helpers.HTTPServiceError(err)
 	}
 	return helpers.HTTPOk(listCategories)
 }
@@ -41,14 +43,14 @@ func NewSaveCategoryController(category services.WriterCategory,
 	}
 }
 
-func (c *SaveCategoryController) Handle() protocols.HttpResponse {
+func (c *SaveCategoryController) Handle(ctx context.Context) protocols.HttpResponse {
 	err := c.validation.Validate()
 	if err != nil {
 		return helpers.HTTPBadRequestError(err)
 	}
-	category, err := c.category.Save(c.dto.Name, c.dto.Description)
+	category, err := c.category.Save(ctx, c.dto.Name, c.dto.Description)
 	if err != nil {
-		return helpers.HTTPInternalError()
+		return helpers.HTTPServiceError(err)
 	}
 	return helpers.HTTPCreated(category)
 }
@@ -72,7 +74,7 @@ func NewUpdateCategoryController(category services.UpdateCategory,
 	}
 }
 
-func (u UpdateCategoryController) Handle() protocols.HttpResponse {
+func (u UpdateCategoryController) Handle(ctx context.Context) protocols.HttpResponse {
 	newUUID := u.params["id"].(uuid.UUID)
 	if newUUID == uuid.Nil {
 		return helpers.HTTPNotFound()
@@ -81,12 +83,9 @@ func (u UpdateCategoryController) Handle() protocols.HttpResponse {
 	if err != nil {
 		return helpers.HTTPBadRequestError(err)
 	}
-	err = u.category.Update(newUUID, u.dto.Name, u.dto.Description)
+	err = u.category.Update(ctx, newUUID, u.dto.Name, u.dto.Description)
 	if err != nil {
-		if err == services.ErrNotFound {
-			return helpers.HTTPNotFound()
-		}
-		return helpers.HTTPInternalError()
+		return helpers.HTTPServiceError(err)
 	}
 	return helpers.HTTPOkNoContent()
 }
@@ -104,17 +103,14 @@ func NewDeleteCategoryController(category services.DeleteCategory,
 	}
 }
 
-func (u DeleteCategoryController) Handle() protocols.HttpResponse {
+func (u DeleteCategoryController) Handle(ctx context.Context) protocols.HttpResponse {
 	newUUID := u.params["id"].(uuid.UUID)
 	if newUUID == uuid.Nil {
 		return helpers.HTTPNotFound()
 	}
-	err := u.category.Delete(newUUID)
+	err := u.category.Delete(ctx, newUUID)
 	if err != nil {
-		if err == services.ErrNotFound {
-			return helpers.HTTPNotFound()
-		}
-		return helpers.HTTPInternalError()
+		return helpers.HTTPServiceError(err)
 	}
 	return helpers.HTTPOkNoContent()
 }
@@ -132,17 +128,14 @@ func NewGetSingleCategoryController(category services.ReaderCategory,
 	}
 }
 
-func (g GetSingleCategoryController) Handle() protocols.HttpResponse {
+func (g GetSingleCategoryController) Handle(ctx context.Context) protocols.HttpResponse {
 	newUUID := g.params["id"].(uuid.UUID)
 	if newUUID == uuid.Nil {
 		return helpers.HTTPNotFound()
 	}
-	category, err := g.category.GetCategory(newUUID)
+	category, err := g.category.GetCategory(ctx, newUUID)
 	if err != nil {
-		if err == services.ErrNotFound {
-			return helpers.HTTPNotFound()
-		}
-		return helpers.HTTPInternalError()
+		return helpers.HTTPServiceError(err)
 	}
 	return helpers.HTTPOk(category)
 }
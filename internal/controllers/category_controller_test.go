@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"context"
 	"errors"
 	"github.com/ayrtonsato/video-catalog-golang/internal/services"
 	"testing"
@@ -42,22 +43,22 @@ func TestGetCategoriesController_Handle(t *testing.T) {
 			name: "Should call Get repository",
 			testCase: func(t *testing.T, ctrl *gomock.Controller) {
 				getCategories := mock_services.NewMockReaderCategory(ctrl)
-				getCategories.EXPECT().GetCategories().Times(1)
+				getCategories.EXPECT().GetCategories(gomock.Any()).Times(1)
 				SUT := &GetCategoriesController{
 					category: getCategories,
 				}
-				SUT.Handle()
+				SUT.Handle(context.Background())
 			},
 		},
 		{
 			name: "Should return 200 with list of categories",
 			testCase: func(t *testing.T, ctrl *gomock.Controller) {
 				getCategories := mock_services.NewMockReaderCategory(ctrl)
-				getCategories.EXPECT().GetCategories().Return(testCategory, nil)
+				getCategories.EXPECT().GetCategories(gomock.Any()).Return(testCategory, nil)
 				SUT := &GetCategoriesController{
 					category: getCategories,
 				}
-				result := SUT.Handle()
+				result := SUT.Handle(context.Background())
 				isEqual := cmp.Equal(testCategory, result.Body.([]models.Category))
 				require.Equal(t, result.Code, 200)
 				require.True(t, isEqual)
@@ -67,11 +68,11 @@ func TestGetCategoriesController_Handle(t *testing.T) {
 			name: "Should return 500 when SUT throws an error",
 			testCase: func(t *testing.T, ctrl *gomock.Controller) {
 				getCategories := mock_services.NewMockReaderCategory(ctrl)
-				getCategories.EXPECT().GetCategories().Return([]models.Category{}, errors.New("new error"))
+				getCategories.EXPECT().GetCategories(gomock.Any()).Return([]models.Category{}, errors.New("new error"))
 				SUT := &GetCategoriesController{
 					category: getCategories,
 				}
-				result := SUT.Handle()
+				result := SUT.Handle(context.Background())
 				require.Equal(t, result, helpers.HTTPInternalError())
 			},
 		},
@@ -98,13 +99,13 @@ func TestSaveCategoryController_Handle(t *testing.T) {
 				saveCategories := mock_services.NewMockWriterCategory(ctrl)
 				nameValidationMock := mock_protocols.NewMockValidation(ctrl)
 				nameValidationMock.EXPECT().Validate().Times(1).Return(nil)
-				saveCategories.EXPECT().Save(gomock.Any(), gomock.Any()).Times(1)
+				saveCategories.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
 				SUT := &SaveCategoryController{
 					category:   saveCategories,
 					validation: nameValidationMock,
 					dto:        validDTO,
 				}
-				SUT.Handle()
+				SUT.Handle(context.Background())
 			},
 		},
 		{
@@ -118,8 +119,8 @@ func TestSaveCategoryController_Handle(t *testing.T) {
 					validation: nameValidationMock,
 					dto:        validDTO,
 				}
-				response := SUT.Handle()
-				require.Equal(t, response.Body.(error).Error(), "invalid field")
+				response := SUT.Handle(context.Background())
+				require.Equal(t, response.Body.(helpers.Problem).Detail, "the request is invalid")
 				require.Equal(t, response.Code, 400)
 			},
 		},
@@ -131,14 +132,14 @@ func TestSaveCategoryController_Handle(t *testing.T) {
 				nameValidationMock.EXPECT().Validate().Return(nil)
 				saveCategories.
 					EXPECT().
-					Save(gomock.Eq("valid_name"), gomock.Eq("valid_description")).
+					Save(gomock.Any(), gomock.Eq("valid_name"), gomock.Eq("valid_description")).
 					Times(1)
 				SUT := &SaveCategoryController{
 					category:   saveCategories,
 					validation: nameValidationMock,
 					dto:        validDTO,
 				}
-				SUT.Handle()
+				SUT.Handle(context.Background())
 			},
 		},
 		{
@@ -149,7 +150,7 @@ func TestSaveCategoryController_Handle(t *testing.T) {
 				nameValidationMock.EXPECT().Validate().Return(nil)
 				saveCategories.
 					EXPECT().
-					Save(gomock.Any(), gomock.Any()).
+					Save(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(models.Category{}, errors.New("any error"))
 				SUT := &SaveCategoryController{
@@ -157,7 +158,7 @@ func TestSaveCategoryController_Handle(t *testing.T) {
 					validation: nameValidationMock,
 					dto:        SaveCategoryDTO{Name: "valid_name", Description: "valid_description"},
 				}
-				response := SUT.Handle()
+				response := SUT.Handle(context.Background())
 				require.Equal(t, response.Code, 500)
 			},
 		},
@@ -182,7 +183,7 @@ func TestSaveCategoryController_Handle(t *testing.T) {
 				nameValidationMock.EXPECT().Validate().Return(nil)
 				saveCategories.
 					EXPECT().
-					Save(gomock.Eq("valid_name"), gomock.Eq("valid_description")).
+					Save(gomock.Any(), gomock.Eq("valid_name"), gomock.Eq("valid_description")).
 					Times(1).
 					Return(newCategoryFake, nil)
 				SUT := &SaveCategoryController{
@@ -190,7 +191,7 @@ func TestSaveCategoryController_Handle(t *testing.T) {
 					validation: nameValidationMock,
 					dto:        validDTO,
 				}
-				response := SUT.Handle()
+				response := SUT.Handle(context.Background())
 				require.Equal(t, response.Code, 201)
 				require.Equal(t, response.Body, newCategoryFake)
 
@@ -227,7 +228,7 @@ func TestUpdateCategoryController_Handle(t *testing.T) {
 				nameValidationMock.EXPECT().Validate().Times(1).Return(nil)
 				updateServicesCategory.
 					EXPECT().
-					Update(
+					Update(gomock.Any(),
 						gomock.Eq(newUUID),
 						gomock.Eq(validDTO.Name),
 						gomock.Eq(validDTO.Description)).
@@ -238,7 +239,7 @@ func TestUpdateCategoryController_Handle(t *testing.T) {
 					dto:        validDTO,
 					params:     fakeParams,
 				}
-				resp := SUT.Handle()
+				resp := SUT.Handle(context.Background())
 				require.Equal(t, resp, helpers.HTTPOkNoContent())
 			},
 		},
@@ -254,8 +255,8 @@ func TestUpdateCategoryController_Handle(t *testing.T) {
 					dto:        validDTO,
 					params:     fakeParams,
 				}
-				response := SUT.Handle()
-				require.Equal(t, response.Body.(error).Error(), "invalid field")
+				response := SUT.Handle(context.Background())
+				require.Equal(t, response.Body.(helpers.Problem).Detail, "the request is invalid")
 				require.Equal(t, response.Code, 400)
 			},
 		},
@@ -267,7 +268,7 @@ func TestUpdateCategoryController_Handle(t *testing.T) {
 				nameValidationMock.EXPECT().Validate().Times(1).Return(nil)
 				updateServicesCategory.
 					EXPECT().
-					Update(
+					Update(gomock.Any(),
 						gomock.Eq(newUUID),
 						gomock.Eq(validDTO.Name),
 						gomock.Eq(validDTO.Description)).
@@ -279,7 +280,7 @@ func TestUpdateCategoryController_Handle(t *testing.T) {
 					dto:        validDTO,
 					params:     fakeParams,
 				}
-				response := SUT.Handle()
+				response := SUT.Handle(context.Background())
 				require.Equal(t, response.Code, 500)
 			},
 		},
@@ -308,11 +309,11 @@ func TestDeleteCategoryController_Handle(t *testing.T) {
 				deleteServicesCategory := mock_services.NewMockDeleteCategory(ctrl)
 				deleteServicesCategory.
 					EXPECT().
-					Delete(
+					Delete(gomock.Any(),
 						gomock.Eq(newUUID)).
 					Times(1)
 				SUT := NewDeleteCategoryController(deleteServicesCategory, fakeParams)
-				resp := SUT.Handle()
+				resp := SUT.Handle(context.Background())
 				require.Equal(t, resp, helpers.HTTPOkNoContent())
 			},
 		},
@@ -324,7 +325,7 @@ func TestDeleteCategoryController_Handle(t *testing.T) {
 					"id": uuid.Nil,
 				}
 				SUT := NewDeleteCategoryController(deleteServicesCategory, wrongFakeParam)
-				response := SUT.Handle()
+				response := SUT.Handle(context.Background())
 				require.Equal(t, response.Code, 404)
 			},
 		},
@@ -334,11 +335,11 @@ func TestDeleteCategoryController_Handle(t *testing.T) {
 				deleteServicesCategory := mock_services.NewMockDeleteCategory(ctrl)
 				deleteServicesCategory.
 					EXPECT().
-					Delete(gomock.Eq(fakeParams["id"])).
+					Delete(gomock.Any(), gomock.Eq(fakeParams["id"])).
 					Times(1).
 					Return(services.ErrNotFound)
 				SUT := NewDeleteCategoryController(deleteServicesCategory, fakeParams)
-				response := SUT.Handle()
+				response := SUT.Handle(context.Background())
 				require.Equal(t, response.Code, 404)
 			},
 		},
@@ -348,11 +349,11 @@ func TestDeleteCategoryController_Handle(t *testing.T) {
 				deleteServicesCategory := mock_services.NewMockDeleteCategory(ctrl)
 				deleteServicesCategory.
 					EXPECT().
-					Delete(gomock.Eq(fakeParams["id"])).
+					Delete(gomock.Any(), gomock.Eq(fakeParams["id"])).
 					Times(1).
 					Return(services.ErrUpdateFailed)
 				SUT := NewDeleteCategoryController(deleteServicesCategory, fakeParams)
-				response := SUT.Handle()
+				response := SUT.Handle(context.Background())
 				require.Equal(t, response.Code, 500)
 			},
 		},
@@ -390,12 +391,12 @@ func TestGetSingleCategoryController_Handle(t *testing.T) {
 				getServicesCategory := mock_services.NewMockReaderCategory(ctrl)
 				getServicesCategory.
 					EXPECT().
-					GetCategory(
+					GetCategory(gomock.Any(),
 						gomock.Eq(newUUID)).
 					Times(1).
 					Return(fakeCategory, nil)
 				SUT := NewGetSingleCategoryController(getServicesCategory, fakeParams)
-				resp := SUT.Handle()
+				resp := SUT.Handle(context.Background())
 				require.Equal(t, resp.Code, 200)
 				require.Equal(t, resp.Body.(models.Category), fakeCategory)
 			},
@@ -408,7 +409,7 @@ func TestGetSingleCategoryController_Handle(t *testing.T) {
 					"id": uuid.Nil,
 				}
 				SUT := NewGetSingleCategoryController(getServicesCategory, wrongFakeParam)
-				response := SUT.Handle()
+				response := SUT.Handle(context.Background())
 				require.Equal(t, response.Code, 404)
 			},
 		},
@@ -418,11 +419,11 @@ func TestGetSingleCategoryController_Handle(t *testing.T) {
 				getServicesCategory := mock_services.NewMockReaderCategory(ctrl)
 				getServicesCategory.
 					EXPECT().
-					GetCategory(gomock.Any()).
+					GetCategory(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(models.Category{}, services.ErrNotFound)
 				SUT := NewGetSingleCategoryController(getServicesCategory, fakeParams)
-				response := SUT.Handle()
+				response := SUT.Handle(context.Background())
 				require.Equal(t, response.Code, 404)
 			},
 		},
@@ -432,11 +433,11 @@ func TestGetSingleCategoryController_Handle(t *testing.T) {
 				getServicesCategory := mock_services.NewMockReaderCategory(ctrl)
 				getServicesCategory.
 					EXPECT().
-					GetCategory(gomock.Eq(fakeParams["id"])).
+					GetCategory(gomock.Any(), gomock.Eq(fakeParams["id"])).
 					Times(1).
 					Return(models.Category{}, errors.New("test: failed"))
 				SUT := NewGetSingleCategoryController(getServicesCategory, fakeParams)
-				response := SUT.Handle()
+				response := SUT.Handle(context.Background())
 				require.Equal(t, response.Code, 500)
 			},
 		},
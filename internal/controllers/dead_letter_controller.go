@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/helpers"
+	"github.com/ayrtonsato/video-catalog-golang/internal/protocols"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	"github.com/gofrs/uuid"
+)
+
+type GetDeadLettersController struct {
+	deadLetters services.DeadLetters
+	dto         GetDeadLettersDTO
+}
+
+func NewGetDeadLettersController(deadLetters services.DeadLetters,
+	dto GetDeadLettersDTO) GetDeadLettersController {
+	return GetDeadLettersController{
+		deadLetters: deadLetters,
+		dto:         dto,
+	}
+}
+
+func (g GetDeadLettersController) Handle(ctx context.Context) protocols.HttpResponse {
+	deadLetters, err := g.deadLetters.GetDeadLetters(ctx, g.dto.Source)
+	if err != nil {
+		return helpers.HTTPServiceError(err)
+	}
+	return helpers.HTTPOk(deadLetters)
+}
+
+type ReplayDeadLetterController struct {
+	params      map[string]interface{}
+	deadLetters services.DeadLetters
+}
+
+func NewReplayDeadLetterController(deadLetters services.DeadLetters,
+	params map[string]interface{}) ReplayDeadLetterController {
+	return ReplayDeadLetterController{
+		params:      params,
+		deadLetters: deadLetters,
+	}
+}
+
+func (r ReplayDeadLetterController) Handle(ctx context.Context) protocols.HttpResponse {
+	newUUID := r.params["id"].(uuid.UUID)
+	if newUUID == uuid.Nil {
+		return helpers.HTTPNotFound()
+	}
+	err := r.deadLetters.Replay(ctx, newUUID)
+	if err != nil {
+		return helpers.HTTPServiceError(err)
+	}
+	return helpers.HTTPOkNoContent()
+}
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	mock_services "github.com/ayrtonsato/video-catalog-golang/internal/services/mocks"
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayDeadLetterController_Handle(t *testing.T) {
+	newUUID := uuid.Must(uuid.NewV4())
+	fakeParams := map[string]interface{}{
+		"id": newUUID,
+	}
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "Should return 204 when replayed", err: nil, expected: 204},
+		{name: "Should return 404 when not found", err: services.ErrNotFound, expected: 404},
+		{name: "Should return 409 when already replayed", err: services.ErrConflict, expected: 409},
+		{
+			name:     "Should return 422 when message fails again",
+			err:      fmt.Errorf("%w: %v", services.ErrReplayFailed, services.ErrInvalidMessage),
+			expected: 422,
+		},
+		{name: "Should return 500 on unexpected errors", err: services.ErrUpdateFailed, expected: 500},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			deadLetters := mock_services.NewMockDeadLetters(ctrl)
+			deadLetters.EXPECT().Replay(gomock.Any(), newUUID).Times(1).Return(tc.err)
+			SUT := NewReplayDeadLetterController(deadLetters, fakeParams)
+			resp := SUT.Handle(context.Background())
+			require.Equal(t, tc.expected, resp.Code)
+		})
+	}
+}
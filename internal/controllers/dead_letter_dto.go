@@ -0,0 +1,5 @@
+package controllers
+
+type GetDeadLettersDTO struct {
+	Source string `form:"source" json:"source"`
+}
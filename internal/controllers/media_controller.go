@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/helpers"
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/internal/protocols"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	"github.com/gofrs/uuid"
+)
+
+type SignMediaController struct {
+	params     map[string]interface{}
+	media      services.SignMedia
+	dto        SignMediaDTO
+	validation protocols.Validation
+}
+
+func NewSignMediaController(media services.SignMedia,
+	dto SignMediaDTO,
+	validation protocols.Validation,
+	params map[string]interface{}) SignMediaController {
+	return SignMediaController{
+		params:     params,
+		media:      media,
+		dto:        dto,
+		validation: validation,
+	}
+}
+
+func (s SignMediaController) Handle(ctx context.Context) protocols.HttpResponse {
+	newUUID := s.params["id"].(uuid.UUID)
+	if newUUID == uuid.Nil {
+		return helpers.HTTPNotFound()
+	}
+	err := s.validation.Validate()
+	if err != nil {
+		return helpers.HTTPBadRequestError(err)
+	}
+	clientIP := ""
+	if s.dto.BindIP {
+		clientIP = s.dto.ClientIP
+	}
+	signed, err := s.media.SignVideoFile(ctx, newUUID, models.VideoFileKind(s.dto.Kind), clientIP, s.dto.Disposition)
+	if err != nil {
+		return helpers.HTTPServiceError(err)
+	}
+	return helpers.HTTPOk(signed)
+}
+
+type UploadVideoImageController struct {
+	params     map[string]interface{}
+	images     services.UploadVideoImage
+	dto        UploadVideoImageDTO
+	validation protocols.Validation
+}
+
+func NewUploadVideoImageController(images services.UploadVideoImage,
+	dto UploadVideoImageDTO,
+	validation protocols.Validation,
+	params map[string]interface{}) UploadVideoImageController {
+	return UploadVideoImageController{
+		params:     params,
+		images:     images,
+		dto:        dto,
+		validation: validation,
+	}
+}
+
+func (u UploadVideoImageController) Handle(ctx context.Context) protocols.HttpResponse {
+	newUUID := u.params["id"].(uuid.UUID)
+	if newUUID == uuid.Nil {
+		return helpers.HTTPNotFound()
+	}
+	err := u.validation.Validate()
+	if err != nil {
+		return helpers.HTTPBadRequestError(err)
+	}
+	video, err := u.images.Upload(ctx, newUUID, models.VideoFileKind(u.dto.Kind), u.dto.File)
+	if err != nil {
+		return helpers.HTTPServiceError(err)
+	}
+	return helpers.HTTPOk(video)
+}
+
+type UploadVideoFileController struct {
+	params     map[string]interface{}
+	files      services.UploadVideoFile
+	dto        UploadVideoFileDTO
+	validation protocols.Validation
+}
+
+func NewUploadVideoFileController(files services.UploadVideoFile,
+	dto UploadVideoFileDTO,
+	validation protocols.Validation,
+	params map[string]interface{}) UploadVideoFileController {
+	return UploadVideoFileController{
+		params:     params,
+		files:      files,
+		dto:        dto,
+		validation: validation,
+	}
+}
+
+func (u UploadVideoFileController) Handle(ctx context.Context) protocols.HttpResponse {
+	newUUID := u.params["id"].(uuid.UUID)
+	if newUUID == uuid.Nil {
+		return helpers.HTTPNotFound()
+	}
+	err := u.validation.Validate()
+	if err != nil {
+		return helpers.HTTPBadRequestError(err)
+	}
+	video, err := u.files.Upload(ctx, newUUID, models.VideoFileKind(u.dto.Kind), u.dto.File, u.dto.Size)
+	if err != nil {
+		return helpers.HTTPServiceError(err)
+	}
+	return helpers.HTTPOk(video)
+}
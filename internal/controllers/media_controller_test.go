@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	mock_protocols "github.com/ayrtonsato/video-catalog-golang/internal/protocols/mocks"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	mock_services "github.com/ayrtonsato/video-catalog-golang/internal/services/mocks"
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignMediaController_Handle(t *testing.T) {
+	newUUID := uuid.Must(uuid.NewV4())
+	fakeParams := map[string]interface{}{
+		"id": newUUID,
+	}
+	signed := services.SignedURL{
+		URL:       "http://localhost/media/videos/video.mp4?signature=abc",
+		ExpiresAt: time.Now(),
+	}
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Should return 200 with the signed url bound to the client ip",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				media := mock_services.NewMockSignMedia(ctrl)
+				validationMock := mock_protocols.NewMockValidation(ctrl)
+				validationMock.EXPECT().Validate().Times(1).Return(nil)
+				media.EXPECT().
+					SignVideoFile(gomock.Any(), newUUID, models.VideoFileKindVideo, "10.0.0.1", "inline").
+					Times(1).
+					Return(signed, nil)
+				dto := SignMediaDTO{Kind: "video", Disposition: "inline", BindIP: true, ClientIP: "10.0.0.1"}
+				SUT := NewSignMediaController(media, dto, validationMock, fakeParams)
+				resp := SUT.Handle(context.Background())
+				require.Equal(t, 200, resp.Code)
+				require.Equal(t, signed, resp.Body)
+			},
+		},
+		{
+			name: "Should not bind the client ip unless requested",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				media := mock_services.NewMockSignMedia(ctrl)
+				validationMock := mock_protocols.NewMockValidation(ctrl)
+				validationMock.EXPECT().Validate().Times(1).Return(nil)
+				media.EXPECT().
+					SignVideoFile(gomock.Any(), newUUID, models.VideoFileKindThumb, "", "").
+					Times(1).
+					Return(signed, nil)
+				dto := SignMediaDTO{Kind: "thumb", ClientIP: "10.0.0.1"}
+				SUT := NewSignMediaController(media, dto, validationMock, fakeParams)
+				resp := SUT.Handle(context.Background())
+				require.Equal(t, 200, resp.Code)
+			},
+		},
+		{
+			name: "Should return 400 when validation fails",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				media := mock_services.NewMockSignMedia(ctrl)
+				validationMock := mock_protocols.NewMockValidation(ctrl)
+				validationMock.EXPECT().Validate().Times(1).Return(errors.New("invalid kind"))
+				SUT := NewSignMediaController(media, SignMediaDTO{Kind: "poster"}, validationMock, fakeParams)
+				resp := SUT.Handle(context.Background())
+				require.Equal(t, 400, resp.Code)
+			},
+		},
+		{
+			name: "Should return 404 when file not found",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				media := mock_services.NewMockSignMedia(ctrl)
+				validationMock := mock_protocols.NewMockValidation(ctrl)
+				validationMock.EXPECT().Validate().Times(1).Return(nil)
+				media.EXPECT().
+					SignVideoFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(services.SignedURL{}, services.ErrNotFound)
+				SUT := NewSignMediaController(media, SignMediaDTO{Kind: "video"}, validationMock, fakeParams)
+				resp := SUT.Handle(context.Background())
+				require.Equal(t, 404, resp.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tc.testCase(t, ctrl)
+		})
+	}
+}
+
+func TestSignMediaValidation_Validate(t *testing.T) {
+	dto := SignMediaDTO{Kind: "video", Disposition: "attachment"}
+	require.NoError(t, NewSignMediaValidation(&dto).Validate())
+
+	dto = SignMediaDTO{Kind: "poster"}
+	require.EqualError(t, NewSignMediaValidation(&dto).Validate(), "kind: must be a valid value.")
+
+	dto = SignMediaDTO{Kind: "video", Disposition: "download"}
+	require.EqualError(t, NewSignMediaValidation(&dto).Validate(), "disposition: must be a valid value.")
+}
+
+func TestUploadVideoImageController_Handle(t *testing.T) {
+	newUUID := uuid.Must(uuid.NewV4())
+	fakeParams := map[string]interface{}{
+		"id": newUUID,
+	}
+	file := strings.NewReader("fake_image")
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "Should return 200 with the video", err: nil, expected: 200},
+		{name: "Should return 404 when video not found", err: services.ErrNotFound, expected: 404},
+		{name: "Should return 422 when image is invalid", err: services.ErrInvalidImage, expected: 422},
+		{name: "Should return 500 on unexpected errors", err: services.ErrSaveFailed, expected: 500},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			images := mock_services.NewMockUploadVideoImage(ctrl)
+			validationMock := mock_protocols.NewMockValidation(ctrl)
+			validationMock.EXPECT().Validate().Times(1).Return(nil)
+			images.EXPECT().
+				Upload(gomock.Any(), newUUID, models.VideoFileKindBanner, file).
+				Times(1).
+				Return(models.Video{Id: newUUID}, tc.err)
+			dto := UploadVideoImageDTO{Kind: "banner", File: file}
+			SUT := NewUploadVideoImageController(images, dto, validationMock, fakeParams)
+			resp := SUT.Handle(context.Background())
+			require.Equal(t, tc.expected, resp.Code)
+		})
+	}
+}
+
+func TestUploadVideoImageValidation_Validate(t *testing.T) {
+	dto := UploadVideoImageDTO{Kind: "thumb", File: strings.NewReader("fake_image")}
+	require.NoError(t, NewUploadVideoImageValidation(&dto).Validate())
+
+	dto = UploadVideoImageDTO{Kind: "thumb"}
+	require.EqualError(t, NewUploadVideoImageValidation(&dto).Validate(), "File: is required.")
+
+	dto = UploadVideoImageDTO{Kind: "video", File: strings.NewReader("fake_image")}
+	require.EqualError(t, NewUploadVideoImageValidation(&dto).Validate(), "kind: must be a valid value.")
+}
+
+func TestUploadVideoFileController_Handle(t *testing.T) {
+	newUUID := uuid.Must(uuid.NewV4())
+	fakeParams := map[string]interface{}{
+		"id": newUUID,
+	}
+	file := strings.NewReader("fake_video")
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "Should return 200 with the video", err: nil, expected: 200},
+		{name: "Should return 404 when video not found", err: services.ErrNotFound, expected: 404},
+		{name: "Should return 422 when file is not an mp4", err: services.ErrInvalidVideo, expected: 422},
+		{name: "Should return 500 on unexpected errors", err: services.ErrUpdateFailed, expected: 500},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			files := mock_services.NewMockUploadVideoFile(ctrl)
+			validationMock := mock_protocols.NewMockValidation(ctrl)
+			validationMock.EXPECT().Validate().Times(1).Return(nil)
+			files.EXPECT().
+				Upload(gomock.Any(), newUUID, models.VideoFileKindVideo, file, int64(10)).
+				Times(1).
+				Return(models.Video{Id: newUUID}, tc.err)
+			dto := UploadVideoFileDTO{Kind: "video", File: file, Size: 10}
+			SUT := NewUploadVideoFileController(files, dto, validationMock, fakeParams)
+			resp := SUT.Handle(context.Background())
+			require.Equal(t, tc.expected, resp.Code)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package controllers
+
+import "io"
+
+type SignMediaDTO struct {
+	Kind        string `json:"kind"`
+	Disposition string `form:"disposition" json:"disposition"`
+	BindIP      bool   `form:"bind_ip" json:"bindIp"`
+	ClientIP    string `json:"-"`
+}
+
+type UploadVideoImageDTO struct {
+	Kind string    `json:"kind"`
+	File io.Reader `json:"-"`
+}
+
+type UploadVideoFileDTO struct {
+	Kind string      `json:"kind"`
+	File io.ReaderAt `json:"-"`
+	Size int64       `json:"-"`
+}
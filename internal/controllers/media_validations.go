@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type SignMediaValidation struct {
+	dto *SignMediaDTO
+}
+
+func NewSignMediaValidation(dto *SignMediaDTO) SignMediaValidation {
+	return SignMediaValidation{
+		dto: dto,
+	}
+}
+
+func (s SignMediaValidation) Validate() error {
+	return validation.ValidateStruct(s.dto,
+		validation.Field(&s.dto.Kind, validation.Required, validation.In(
+			string(models.VideoFileKindVideo),
+			string(models.VideoFileKindTrailer),
+			string(models.VideoFileKindThumb),
+			string(models.VideoFileKindBanner),
+		)),
+		validation.Field(&s.dto.Disposition, validation.In("inline", "attachment")),
+	)
+}
+
+type UploadVideoImageValidation struct {
+	dto *UploadVideoImageDTO
+}
+
+func NewUploadVideoImageValidation(dto *UploadVideoImageDTO) UploadVideoImageValidation {
+	return UploadVideoImageValidation{
+		dto: dto,
+	}
+}
+
+func (u UploadVideoImageValidation) Validate() error {
+	return validation.ValidateStruct(u.dto,
+		validation.Field(&u.dto.Kind, validation.Required, validation.In(
+			string(models.VideoFileKindThumb),
+			string(models.VideoFileKindBanner),
+		)),
+		validation.Field(&u.dto.File, validation.NotNil),
+	)
+}
+
+type UploadVideoFileValidation struct {
+	dto *UploadVideoFileDTO
+}
+
+func NewUploadVideoFileValidation(dto *UploadVideoFileDTO) UploadVideoFileValidation {
+	return UploadVideoFileValidation{
+		dto: dto,
+	}
+}
+
+func (u UploadVideoFileValidation) Validate() error {
+	return validation.ValidateStruct(u.dto,
+		validation.Field(&u.dto.Kind, validation.Required, validation.In(
+			string(models.VideoFileKindVideo),
+			string(models.VideoFileKindTrailer),
+		)),
+		validation.Field(&u.dto.File, validation.NotNil),
+	)
+}
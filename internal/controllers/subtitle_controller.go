@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/helpers"
+	"github.com/ayrtonsato/video-catalog-golang/internal/protocols"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	"github.com/gofrs/uuid"
+)
+
+type GetSubtitlesController struct {
+	params    map[string]interface{}
+	subtitles services.Subtitles
+}
+
+func NewGetSubtitlesController(subtitles services.Subtitles,
+	params map[string]interface{}) GetSubtitlesController {
+	return GetSubtitlesController{
+		params:    params,
+		subtitles: subtitles,
+	}
+}
+
+func (g GetSubtitlesController) Handle(ctx context.Context) protocols.HttpResponse {
+	newUUID := g.params["id"].(uuid.UUID)
+	if newUUID == uuid.Nil {
+		return helpers.HTTPNotFound()
+	}
+	subtitles, err := g.subtitles.GetSubtitles(ctx, newUUID)
+	if err != nil {
+		return helpers.HTTPServiceError(err)
+	}
+	return helpers.HTTPOk(subtitles)
+}
+
+type UploadSubtitleController struct {
+	params     map[string]interface{}
+	subtitles  services.Subtitles
+	dto        UploadSubtitleDTO
+	validation protocols.Validation
+}
+
+func NewUploadSubtitleController(subtitles services.Subtitles,
+	dto UploadSubtitleDTO,
+	validation protocols.Validation,
+	params map[string]interface{}) UploadSubtitleController {
+	return UploadSubtitleController{
+		params:     params,
+		subtitles:  subtitles,
+		dto:        dto,
+		validation: validation,
+	}
+}
+
+func (u UploadSubtitleController) Handle(ctx context.Context) protocols.HttpResponse {
+	newUUID := u.params["id"].(uuid.UUID)
+	if newUUID == uuid.Nil {
+		return helpers.HTTPNotFound()
+	}
+	err := u.validation.Validate()
+	if err != nil {
+		return helpers.HTTPBadRequestError(err)
+	}
+	subtitle, err := u.subtitles.Upload(ctx, newUUID, u.dto.Language, u.dto.Label, u.dto.File)
+	if err != nil {
+		return helpers.HTTPServiceError(err)
+	}
+	return helpers.HTTPCreated(subtitle)
+}
+
+type DeleteSubtitleController struct {
+	params    map[string]interface{}
+	subtitles services.Subtitles
+}
+
+func NewDeleteSubtitleController(subtitles services.Subtitles,
+	params map[string]interface{}) DeleteSubtitleController {
+	return DeleteSubtitleController{
+		params:    params,
+		subtitles: subtitles,
+	}
+}
+
+func (d DeleteSubtitleController) Handle(ctx context.Context) protocols.HttpResponse {
+	newUUID := d.params["id"].(uuid.UUID)
+	if newUUID == uuid.Nil {
+		return helpers.HTTPNotFound()
+	}
+	err := d.subtitles.Delete(ctx, newUUID, d.params["language"].(string))
+	if err != nil {
+		return helpers.HTTPServiceError(err)
+	}
+	return helpers.HTTPOkNoContent()
+}
@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	mock_protocols "github.com/ayrtonsato/video-catalog-golang/internal/protocols/mocks"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	mock_services "github.com/ayrtonsato/video-catalog-golang/internal/services/mocks"
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadSubtitleController_Handle(t *testing.T) {
+	newUUID := uuid.Must(uuid.NewV4())
+	fakeParams := map[string]interface{}{
+		"id": newUUID,
+	}
+	file := strings.NewReader("WEBVTT")
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "Should return 201 with the track", err: nil, expected: 201},
+		{name: "Should return 404 when video not found", err: services.ErrNotFound, expected: 404},
+		{
+			name:     "Should return 422 when cues are invalid",
+			err:      fmt.Errorf("%w: cue 2: overlaps", services.ErrInvalidSubtitle),
+			expected: 422,
+		},
+		{name: "Should return 500 on unexpected errors", err: services.ErrSaveFailed, expected: 500},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			subtitles := mock_services.NewMockSubtitles(ctrl)
+			validationMock := mock_protocols.NewMockValidation(ctrl)
+			validationMock.EXPECT().Validate().Times(1).Return(nil)
+			subtitles.EXPECT().
+				Upload(gomock.Any(), newUUID, "en", "English", file).
+				Times(1).
+				Return(models.Subtitle{VideoID: newUUID}, tc.err)
+			dto := UploadSubtitleDTO{Language: "en", Label: "English", File: file}
+			SUT := NewUploadSubtitleController(subtitles, dto, validationMock, fakeParams)
+			resp := SUT.Handle(context.Background())
+			require.Equal(t, tc.expected, resp.Code)
+		})
+	}
+}
+
+func TestDeleteSubtitleController_Handle(t *testing.T) {
+	newUUID := uuid.Must(uuid.NewV4())
+	fakeParams := map[string]interface{}{
+		"id":       newUUID,
+		"language": "en",
+	}
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "Should return 204 when deleted", err: nil, expected: 204},
+		{name: "Should return 404 when track not found", err: services.ErrNotFound, expected: 404},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			subtitles := mock_services.NewMockSubtitles(ctrl)
+			subtitles.EXPECT().Delete(gomock.Any(), newUUID, "en").Times(1).Return(tc.err)
+			SUT := NewDeleteSubtitleController(subtitles, fakeParams)
+			resp := SUT.Handle(context.Background())
+			require.Equal(t, tc.expected, resp.Code)
+		})
+	}
+}
+
+func TestUploadSubtitleValidation_Validate(t *testing.T) {
+	dto := UploadSubtitleDTO{Language: "pt-BR", Label: "Português", File: strings.NewReader("WEBVTT")}
+	require.NoError(t, NewUploadSubtitleValidation(&dto).Validate())
+
+	dto = UploadSubtitleDTO{Language: "Portuguese", Label: "Português", File: strings.NewReader("WEBVTT")}
+	require.EqualError(t, NewUploadSubtitleValidation(&dto).Validate(), "language: must be in a valid format.")
+}
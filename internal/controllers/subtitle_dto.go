@@ -0,0 +1,9 @@
+package controllers
+
+import "io"
+
+type UploadSubtitleDTO struct {
+	Language string    `form:"language" json:"language"`
+	Label    string    `form:"label" json:"label"`
+	File     io.Reader `json:"-"`
+}
@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// languageTag accepts BCP 47 tags as en, pt-BR or zh-Hant
+var languageTag = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+type UploadSubtitleValidation struct {
+	dto *UploadSubtitleDTO
+}
+
+func NewUploadSubtitleValidation(dto *UploadSubtitleDTO) UploadSubtitleValidation {
+	return UploadSubtitleValidation{
+		dto: dto,
+	}
+}
+
+func (u UploadSubtitleValidation) Validate() error {
+	return validation.ValidateStruct(u.dto,
+		validation.Field(&u.dto.Language, validation.Required, validation.Length(2, 35),
+			validation.Match(languageTag)),
+		validation.Field(&u.dto.Label, validation.Required, validation.Length(1, 255)),
+		validation.Field(&u.dto.File, validation.NotNil),
+	)
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/helpers"
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/internal/protocols"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	"github.com/gofrs/uuid"
+)
+
+type GetVideosController struct {
+	video      services.ReaderVideo
+	dto        GetVideosDTO
+	validation protocols.Validation
+}
+
+func NewGetVideosController(video services.ReaderVideo,
+	dto GetVideosDTO,
+	validation protocols.Validation) GetVideosController {
+	return GetVideosController{
+		video:      video,
+		dto:        dto,
+		validation: validation,
+	}
+}
+
+func (g GetVideosController) Handle(ctx context.Context) protocols.HttpResponse {
+	err := g.validation.Validate()
+	if err != nil {
+		return helpers.HTTPBadRequestError(err)
+	}
+	videos, err := g.video.GetVideos(ctx, models.VideoStatus(g.dto.Status))
+	if err != nil {
+		return helpers.HTTPServiceError(err)
+	}
+	return helpers.HTTPOk(videos)
+}
+
+type GetSingleVideoController struct {
+	params map[string]interface{}
+	video  services.ReaderVideo
+}
+
+func NewGetSingleVideoController(video services.ReaderVideo,
+	params map[string]interface{}) GetSingleVideoController {
+	return GetSingleVideoController{
+		params: params,
+		video:  video,
+	}
+}
+
+func (g GetSingleVideoController) Handle(ctx context.Context) protocols.HttpResponse {
+	newUUID := g.params["id"].(uuid.UUID)
+	if newUUID == uuid.Nil {
+		return helpers.HTTPNotFound()
+	}
+	video, err := g.video.GetVideo(ctx, newUUID)
+	if err != nil {
+		return helpers.HTTPServiceError(err)
+	}
+	return helpers.HTTPOk(video)
+}
+
+type UpdateVideoStatusController struct {
+	params     map[string]interface{}
+	video      services.UpdateVideoStatus
+	dto        UpdateVideoStatusDTO
+	validation protocols.Validation
+}
+
+func NewUpdateVideoStatusController(video services.UpdateVideoStatus,
+	dto UpdateVideoStatusDTO,
+	validation protocols.Validation,
+	params map[string]interface{}) UpdateVideoStatusController {
+	return UpdateVideoStatusController{
+		params:     params,
+		video:      video,
+		dto:        dto,
+		validation: validation,
+	}
+}
+
+func (u UpdateVideoStatusController) Handle(ctx context.Context) protocols.HttpResponse {
+	newUUID := u.params["id"].(uuid.UUID)
+	if newUUID == uuid.Nil {
+		return helpers.HTTPNotFound()
+	}
+	err := u.validation.Validate()
+	if err != nil {
+		return helpers.HTTPBadRequestError(err)
+	}
+	video, err := u.video.UpdateStatus(ctx, newUUID, models.VideoStatus(u.dto.Status), u.dto.Reason)
+	if err != nil {
+		return helpers.HTTPServiceError(err)
+	}
+	return helpers.HTTPOk(video)
+}
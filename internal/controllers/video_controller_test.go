@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	mock_protocols "github.com/ayrtonsato/video-catalog-golang/internal/protocols/mocks"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	mock_services "github.com/ayrtonsato/video-catalog-golang/internal/services/mocks"
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVideosController_Handle(t *testing.T) {
+	fakeVideos := []models.Video{
+		{Id: uuid.Must(uuid.NewV4()), Status: models.VideoStatusCompleted},
+	}
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Should return 200 with videos of the status",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				video := mock_services.NewMockReaderVideo(ctrl)
+				video.EXPECT().GetVideos(gomock.Any(), models.VideoStatusCompleted).Times(1).Return(fakeVideos, nil)
+				dto := GetVideosDTO{Status: "completed"}
+				SUT := NewGetVideosController(video, dto, NewGetVideosValidation(&dto))
+				resp := SUT.Handle(context.Background())
+				require.Equal(t, 200, resp.Code)
+				require.Equal(t, fakeVideos, resp.Body)
+			},
+		},
+		{
+			name: "Should return 400 with an unknown status",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				video := mock_services.NewMockReaderVideo(ctrl)
+				dto := GetVideosDTO{Status: "encoding"}
+				SUT := NewGetVideosController(video, dto, NewGetVideosValidation(&dto))
+				resp := SUT.Handle(context.Background())
+				require.Equal(t, 400, resp.Code)
+			},
+		},
+		{
+			name: "Should return 500 when service throws",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				video := mock_services.NewMockReaderVideo(ctrl)
+				video.EXPECT().GetVideos(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("fake_error"))
+				dto := GetVideosDTO{}
+				SUT := NewGetVideosController(video, dto, NewGetVideosValidation(&dto))
+				resp := SUT.Handle(context.Background())
+				require.Equal(t, 500, resp.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tc.testCase(t, ctrl)
+		})
+	}
+}
+
+func TestUpdateVideoStatusController_Handle(t *testing.T) {
+	newUUID := uuid.Must(uuid.NewV4())
+	fakeParams := map[string]interface{}{
+		"id": newUUID,
+	}
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Should return 200 with the updated video",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				video := mock_services.NewMockUpdateVideoStatus(ctrl)
+				validationMock := mock_protocols.NewMockValidation(ctrl)
+				validationMock.EXPECT().Validate().Times(1).Return(nil)
+				updated := models.Video{Id: newUUID, Status: models.VideoStatusProcessing}
+				video.EXPECT().
+					UpdateStatus(gomock.Any(), newUUID, models.VideoStatusProcessing, "").
+					Times(1).
+					Return(updated, nil)
+				dto := UpdateVideoStatusDTO{Status: "processing"}
+				SUT := NewUpdateVideoStatusController(video, dto, validationMock, fakeParams)
+				resp := SUT.Handle(context.Background())
+				require.Equal(t, 200, resp.Code)
+				require.Equal(t, updated, resp.Body)
+			},
+		},
+		{
+			name: "Should return 409 on illegal transition",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				video := mock_services.NewMockUpdateVideoStatus(ctrl)
+				validationMock := mock_protocols.NewMockValidation(ctrl)
+				validationMock.EXPECT().Validate().Times(1).Return(nil)
+				video.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(models.Video{}, services.ErrConflict)
+				dto := UpdateVideoStatusDTO{Status: "completed"}
+				SUT := NewUpdateVideoStatusController(video, dto, validationMock, fakeParams)
+				resp := SUT.Handle(context.Background())
+				require.Equal(t, 409, resp.Code)
+			},
+		},
+		{
+			name: "Should return 404 when video not found",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				video := mock_services.NewMockUpdateVideoStatus(ctrl)
+				validationMock := mock_protocols.NewMockValidation(ctrl)
+				validationMock.EXPECT().Validate().Times(1).Return(nil)
+				video.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(models.Video{}, services.ErrNotFound)
+				dto := UpdateVideoStatusDTO{Status: "processing"}
+				SUT := NewUpdateVideoStatusController(video, dto, validationMock, fakeParams)
+				resp := SUT.Handle(context.Background())
+				require.Equal(t, 404, resp.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tc.testCase(t, ctrl)
+		})
+	}
+}
+
+func TestUpdateVideoStatusValidation_Validate(t *testing.T) {
+	dto := UpdateVideoStatusDTO{Status: "processing"}
+	require.NoError(t, NewUpdateVideoStatusValidation(&dto).Validate())
+
+	dto = UpdateVideoStatusDTO{Status: "failed"}
+	require.EqualError(t, NewUpdateVideoStatusValidation(&dto).Validate(), "reason: cannot be blank.")
+
+	dto = UpdateVideoStatusDTO{}
+	require.EqualError(t, NewUpdateVideoStatusValidation(&dto).Validate(), "status: cannot be blank.")
+}
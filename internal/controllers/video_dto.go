@@ -0,0 +1,10 @@
+package controllers
+
+type GetVideosDTO struct {
+	Status string `form:"status" json:"status"`
+}
+
+type UpdateVideoStatusDTO struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
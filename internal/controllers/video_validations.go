@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+func videoStatusValues() []interface{} {
+	statuses := models.VideoStatuses()
+	values := make([]interface{}, len(statuses))
+	for i, status := range statuses {
+		values[i] = string(status)
+	}
+	return values
+}
+
+type GetVideosValidation struct {
+	dto *GetVideosDTO
+}
+
+func NewGetVideosValidation(dto *GetVideosDTO) GetVideosValidation {
+	return GetVideosValidation{
+		dto: dto,
+	}
+}
+
+func (g GetVideosValidation) Validate() error {
+	return validation.ValidateStruct(g.dto,
+		validation.Field(&g.dto.Status, validation.In(videoStatusValues()...)),
+	)
+}
+
+type UpdateVideoStatusValidation struct {
+	dto *UpdateVideoStatusDTO
+}
+
+func NewUpdateVideoStatusValidation(dto *UpdateVideoStatusDTO) UpdateVideoStatusValidation {
+	return UpdateVideoStatusValidation{
+		dto: dto,
+	}
+}
+
+func (u UpdateVideoStatusValidation) Validate() error {
+	return validation.ValidateStruct(u.dto,
+		validation.Field(&u.dto.Status, validation.Required, validation.In(videoStatusValues()...)),
+		validation.Field(&u.dto.Reason,
+			validation.When(u.dto.Status == string(models.VideoStatusFailed), validation.Required)),
+	)
+}
@@ -0,0 +1,78 @@
+package helpers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/i18n"
+	"github.com/ayrtonsato/video-catalog-golang/internal/protocols"
+)
+
+// HTTPInvalidJSON tells clients where their JSON body is malformed, values
+// of the wrong type are listed as field errors
+func HTTPInvalidJSON(err error) protocols.HttpResponse {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var problem Problem
+	switch {
+	case errors.As(err, &syntaxErr):
+		problem = NewLocalizedProblem(400, "error.malformed_json", map[string]interface{}{"offset": syntaxErr.Offset})
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		problem = NewLocalizedProblem(400, "error.truncated_json", nil)
+	case errors.As(err, &typeErr):
+		params := map[string]interface{}{"type": jsonType(typeErr.Type)}
+		problem = NewLocalizedProblem(400, "error.validation", nil)
+		problem.Errors = []FieldError{
+			FieldError{Field: typeErr.Field, Code: "type", Params: params, key: "invalid_type"}.localize(i18n.English),
+		}
+	default:
+		problem = NewLocalizedProblem(400, "error.malformed_json_body", nil)
+	}
+	return protocols.HttpResponse{
+		Code: 400,
+		Body: problem,
+	}
+}
+
+func HTTPInvalidQuery() protocols.HttpResponse {
+	return protocols.HttpResponse{
+		Code: 400,
+		Body: NewLocalizedProblem(400, "error.invalid_query", nil),
+	}
+}
+
+func HTTPInvalidForm() protocols.HttpResponse {
+	return protocols.HttpResponse{
+		Code: 400,
+		Body: NewLocalizedProblem(400, "error.invalid_form", nil),
+	}
+}
+
+func HTTPRequestTooLarge() protocols.HttpResponse {
+	return protocols.HttpResponse{
+		Code: 413,
+		Body: NewLocalizedProblem(413, "error.body_too_large", nil),
+	}
+}
+
+// jsonType names the JSON type clients should have sent for t
+func jsonType(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	}
+	return "object"
+}
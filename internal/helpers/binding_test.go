@@ -0,0 +1,33 @@
+package helpers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPInvalidJSON(t *testing.T) {
+	var dto struct {
+		Name   string   `json:"name"`
+		Scopes []string `json:"scopes"`
+	}
+	decode := func(body string) error {
+		return json.NewDecoder(strings.NewReader(body)).Decode(&dto)
+	}
+
+	problem := HTTPInvalidJSON(decode(`{"name": "a",}`)).Body.(Problem)
+	require.Equal(t, 400, problem.Status)
+	require.Equal(t, "malformed JSON at offset 14", problem.Detail)
+
+	problem = HTTPInvalidJSON(decode(`{"name": "a"`)).Body.(Problem)
+	require.Equal(t, "the JSON body ends unexpectedly", problem.Detail)
+
+	problem = HTTPInvalidJSON(decode(`{"name": 1, "scopes": "read"}`)).Body.(Problem)
+	require.Equal(t, "one or more fields are invalid", problem.Detail)
+	require.Len(t, problem.Errors, 1)
+	require.Equal(t, "name", problem.Errors[0].Field)
+	require.Equal(t, "type", problem.Errors[0].Code)
+	require.Equal(t, "must be of type string", problem.Errors[0].Message)
+}
@@ -1,7 +1,6 @@
 package helpers
 
 import (
-	"errors"
 	"github.com/ayrtonsato/video-catalog-golang/internal/protocols"
 )
 
@@ -26,23 +25,40 @@ func HTTPOkNoContent() protocols.HttpResponse {
 	}
 }
 
+// HTTPBadRequestError details each invalid field when err comes from a
+// validation, other errors are not detailed past a localized message
 func HTTPBadRequestError(err error) protocols.HttpResponse {
+	problem := NewLocalizedProblem(400, "error.bad_request", nil)
+	if fields := FieldErrors(err); fields != nil {
+		problem = NewLocalizedProblem(400, "error.validation", nil)
+		problem.Errors = fields
+	}
 	return protocols.HttpResponse{
 		Code: 400,
-		Body: err,
+		Body: problem,
 	}
 }
 
 func HTTPNotFound() protocols.HttpResponse {
-	return protocols.HttpResponse{
-		Code: 404,
-		Body: errors.New("Not Found"),
-	}
+	return problemResponse(404, "")
 }
 
 func HTTPInternalError() protocols.HttpResponse {
-	return protocols.HttpResponse{
-		Code: 500,
-		Body: errors.New("Internal Server Error"),
-	}
+	return problemResponse(500, "")
+}
+
+func HTTPForbidden() protocols.HttpResponse {
+	return problemResponse(403, "")
+}
+
+func HTTPConflict(err error) protocols.HttpResponse {
+	return localizedResponse(409, err, "error.conflict")
+}
+
+func HTTPUnprocessableEntity(err error) protocols.HttpResponse {
+	return localizedResponse(422, err, "error.unprocessable")
+}
+
+func HTTPUnauthorized() protocols.HttpResponse {
+	return problemResponse(401, "")
 }
@@ -0,0 +1,131 @@
+package helpers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/i18n"
+	"github.com/ayrtonsato/video-catalog-golang/internal/protocols"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// Problem is the RFC 7807 body of every error response. Instance and
+// RequestID are filled when the response is written, Errors lists the
+// invalid fields of bad requests
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+
+	detailKey    string
+	detailParams map[string]interface{}
+}
+
+func NewProblem(status int, detail string) Problem {
+	return Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// NewLocalizedProblem details the problem with the i18n message key so
+// Localize can translate it, Detail holds the English message
+func NewLocalizedProblem(status int, key string, params map[string]interface{}) Problem {
+	detail, _ := i18n.Translate(i18n.English, key, params)
+	problem := NewProblem(status, detail)
+	problem.detailKey = key
+	problem.detailParams = params
+	return problem
+}
+
+// Localize translates the title, the detail and the field errors of the
+// problem to lang, messages missing from the catalogs are kept
+func (p Problem) Localize(lang string) Problem {
+	if title, ok := i18n.Translate(lang, "status."+strconv.Itoa(p.Status), nil); ok {
+		p.Title = title
+	}
+	if p.detailKey != "" {
+		if detail, ok := i18n.Translate(lang, p.detailKey, p.detailParams); ok {
+			p.Detail = detail
+		}
+	}
+	if len(p.Errors) > 0 {
+		fields := make([]FieldError, len(p.Errors))
+		for i, field := range p.Errors {
+			fields[i] = field.localize(lang)
+		}
+		p.Errors = fields
+	}
+	return p
+}
+
+func problemResponse(status int, detail string) protocols.HttpResponse {
+	return protocols.HttpResponse{
+		Code: status,
+		Body: NewProblem(status, detail),
+	}
+}
+
+// serviceErrors maps the errors of the services package to their status and
+// the message key of their detail, errors without key are not detailed
+var serviceErrors = []struct {
+	err    error
+	status int
+	key    string
+}{
+	{err: services.ErrNotFound, status: 404},
+	{err: services.ErrConflict, status: 409, key: "error.conflict"},
+	{err: services.ErrForbidden, status: 403},
+	{err: services.ErrInvalidAPIKey, status: 401},
+	{err: services.ErrInvalidImage, status: 422, key: "error.invalid_image"},
+	{err: services.ErrInvalidVideo, status: 422, key: "error.invalid_video"},
+	{err: services.ErrInvalidSubtitle, status: 422, key: "error.invalid_subtitle"},
+	{err: services.ErrReplayFailed, status: 422, key: "error.replay_failed"},
+}
+
+// HTTPServiceError maps the errors of the services package to their
+// response, errors it does not know are internal errors and not detailed.
+// What errors wrap after the service error is kept as reason of the detail
+func HTTPServiceError(err error) protocols.HttpResponse {
+	for _, known := range serviceErrors {
+		if !errors.Is(err, known.err) {
+			continue
+		}
+		if known.key == "" {
+			return problemResponse(known.status, "")
+		}
+		return localizedResponse(known.status, err, known.key)
+	}
+	return HTTPInternalError()
+}
+
+// localizedResponse details status with the message key of the service
+// error err wraps, or with key when it wraps none, so messages of other
+// packages never reach clients
+func localizedResponse(status int, err error, key string) protocols.HttpResponse {
+	var params map[string]interface{}
+	for _, known := range serviceErrors {
+		if known.key == "" || !errors.Is(err, known.err) {
+			continue
+		}
+		key = known.key
+		if reason := strings.TrimPrefix(err.Error(), known.err.Error()+": "); reason != err.Error() {
+			params = map[string]interface{}{"reason": reason}
+		}
+		break
+	}
+	return protocols.HttpResponse{
+		Code: status,
+		Body: NewLocalizedProblem(status, key, params),
+	}
+}
@@ -0,0 +1,102 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/i18n"
+	"github.com/ayrtonsato/video-catalog-golang/internal/protocols"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPServiceError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		status int
+		detail string
+	}{
+		{name: "not found", err: services.ErrNotFound, status: 404},
+		{name: "conflict", err: services.ErrConflict, status: 409, detail: "object state conflict"},
+		{name: "forbidden", err: services.ErrForbidden, status: 403},
+		{name: "invalid api key", err: services.ErrInvalidAPIKey, status: 401},
+		{
+			name:   "wrapped invalid subtitle",
+			err:    fmt.Errorf("%w: line 3", services.ErrInvalidSubtitle),
+			status: 422,
+			detail: "invalid subtitle: line 3",
+		},
+		{name: "unknown errors are not detailed", err: errors.New("pq: connection refused"), status: 500},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := HTTPServiceError(tc.err)
+			require.Equal(t, tc.status, resp.Code)
+			problem, ok := resp.Body.(Problem)
+			require.True(t, ok)
+			require.Equal(t, tc.status, problem.Status)
+			require.Equal(t, "about:blank", problem.Type)
+			require.Equal(t, tc.detail, problem.Detail)
+		})
+	}
+}
+
+func TestHTTPErrorsAreLocalized(t *testing.T) {
+	raw := errors.New("pq: duplicate key value violates unique constraint")
+	testCases := []struct {
+		name   string
+		resp   func(error) protocols.HttpResponse
+		err    error
+		status int
+		detail string
+	}{
+		{name: "bad request", resp: HTTPBadRequestError, err: raw, status: 400, detail: "a requisição é inválida"},
+		{name: "conflict", resp: HTTPConflict, err: raw, status: 409, detail: "o estado do objeto está em conflito"},
+		{name: "unprocessable", resp: HTTPUnprocessableEntity, err: raw, status: 422,
+			detail: "a requisição não pode ser processada"},
+		{
+			name:   "unprocessable service error",
+			resp:   HTTPUnprocessableEntity,
+			err:    fmt.Errorf("%w: line 3", services.ErrInvalidSubtitle),
+			status: 422,
+			detail: "legenda inválida: line 3",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := tc.resp(tc.err)
+			require.Equal(t, tc.status, resp.Code)
+			problem := resp.Body.(Problem).Localize(i18n.Portuguese)
+			require.Equal(t, tc.status, problem.Status)
+			require.Equal(t, tc.detail, problem.Detail)
+		})
+	}
+}
+
+func TestProblem_Localize(t *testing.T) {
+	dto := struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}{Name: "abc"}
+	err := validation.ValidateStruct(&dto,
+		validation.Field(&dto.Name, validation.Required, validation.Length(5, 254)),
+		validation.Field(&dto.Description, validation.Required),
+	)
+	problem := HTTPBadRequestError(err).Body.(Problem)
+	require.Equal(t, "one or more fields are invalid", problem.Detail)
+
+	localized := problem.Localize(i18n.Portuguese)
+	require.Equal(t, "Requisição inválida", localized.Title)
+	require.Equal(t, "um ou mais campos são inválidos", localized.Detail)
+	require.Equal(t, "não pode ficar em branco", localized.Errors[0].Message)
+	require.Equal(t, "o tamanho deve estar entre 5 e 254", localized.Errors[1].Message)
+	require.Equal(t, "the length must be between 5 and 254", problem.Errors[1].Message)
+
+	unknown := NewProblem(400, "free text").Localize(i18n.Portuguese)
+	require.Equal(t, "free text", unknown.Detail)
+}
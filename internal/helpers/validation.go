@@ -2,14 +2,109 @@ package helpers
 
 import (
 	"errors"
+	"sort"
+	"strings"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/i18n"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/gofrs/uuid"
 )
 
+var (
+	ErrInvalidUUID    = validation.NewError("invalid_uuid", "must be a valid UUID")
+	ErrFutureRequired = validation.NewError("future_required", "must be in the future")
+)
+
+// FieldError is an invalid field of a request, Code and Params are stable so
+// clients can highlight the field with their own message
+type FieldError struct {
+	Field   string                 `json:"field"`
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Params  map[string]interface{} `json:"params,omitempty"`
+
+	// key is the code of the validation error, the i18n message key
+	key string
+}
+
+// fieldCodes shortens the codes of ozzo rules, rules with the same meaning
+// like Required and NotNil share a code
+var fieldCodes = map[string]string{
+	"validation_required":                        "required",
+	"validation_not_nil_required":                "required",
+	"validation_nil_or_not_empty_required":       "required",
+	"validation_length_too_long":                 "length",
+	"validation_length_too_short":                "length",
+	"validation_length_invalid":                  "length",
+	"validation_length_out_of_range":             "length",
+	"validation_in_invalid":                      "one_of",
+	"validation_match_invalid":                   "format",
+	"validation_min_greater_equal_than_required": "min",
+	"validation_min_greater_than_required":       "min",
+	"validation_max_less_equal_than_required":    "max",
+	"validation_max_less_than_required":          "max",
+}
+
 func UUIDIsRequired(value interface{}) error {
-	err := errors.New("cannot be blank")
 	newUUID := value.(uuid.UUID)
 	if newUUID == uuid.Nil || newUUID.String() == "" {
-		return err
+		return ErrInvalidUUID
 	}
 	return nil
 }
+
+// FieldErrors flattens the errors of validation.ValidateStruct sorted by
+// field, elements of slices are named like scopes.0. It returns nil when err
+// does not come from a validation
+func FieldErrors(err error) []FieldError {
+	var errs validation.Errors
+	if !errors.As(err, &errs) {
+		return nil
+	}
+	fields := appendFieldErrors(nil, "", errs)
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].Field < fields[j].Field
+	})
+	return fields
+}
+
+func appendFieldErrors(fields []FieldError, prefix string, errs validation.Errors) []FieldError {
+	for name, err := range errs {
+		field := strings.TrimPrefix(prefix+"."+name, ".")
+		var nested validation.Errors
+		if errors.As(err, &nested) {
+			fields = appendFieldErrors(fields, field, nested)
+			continue
+		}
+		fields = append(fields, fieldError(field, err))
+	}
+	return fields
+}
+
+func fieldError(field string, err error) FieldError {
+	var coded validation.Error
+	if !errors.As(err, &coded) {
+		return FieldError{Field: field, Code: "invalid", Message: err.Error()}
+	}
+	code, ok := fieldCodes[coded.Code()]
+	if !ok {
+		code = strings.TrimPrefix(coded.Code(), "validation_")
+	}
+	return FieldError{
+		Field:   field,
+		Code:    code,
+		Message: err.Error(),
+		Params:  coded.Params(),
+		key:     coded.Code(),
+	}
+}
+
+func (f FieldError) localize(lang string) FieldError {
+	if f.key == "" {
+		return f
+	}
+	if message, ok := i18n.Translate(lang, f.key, f.Params); ok {
+		f.Message = message
+	}
+	return f
+}
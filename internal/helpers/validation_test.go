@@ -0,0 +1,46 @@
+package helpers
+
+import (
+	"errors"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type fieldsDTO struct {
+	Name   string   `json:"name"`
+	Label  string   `json:"label"`
+	Scopes []string `json:"scopes"`
+}
+
+func TestFieldErrors(t *testing.T) {
+	dto := fieldsDTO{Name: "abc", Scopes: []string{"read", ""}}
+	err := validation.ValidateStruct(&dto,
+		validation.Field(&dto.Name, validation.Required, validation.Length(5, 254)),
+		validation.Field(&dto.Label, validation.Required),
+		validation.Field(&dto.Scopes, validation.Each(validation.Required)),
+	)
+	require.Equal(t, []FieldError{
+		{Field: "label", Code: "required", Message: "cannot be blank", key: "validation_required"},
+		{
+			Field:   "name",
+			Code:    "length",
+			Message: "the length must be between 5 and 254",
+			Params:  map[string]interface{}{"min": 5, "max": 254},
+			key:     "validation_length_out_of_range",
+		},
+		{Field: "scopes.1", Code: "required", Message: "cannot be blank", key: "validation_required"},
+	}, FieldErrors(err))
+
+	require.Nil(t, FieldErrors(errors.New("invalid field")))
+}
+
+func TestUUIDIsRequired(t *testing.T) {
+	require.NoError(t, UUIDIsRequired(uuid.Must(uuid.NewV4())))
+	err := validation.Errors{"id": UUIDIsRequired(uuid.Nil)}
+	require.Equal(t, []FieldError{
+		{Field: "id", Code: "invalid_uuid", Message: "must be a valid UUID", key: "invalid_uuid"},
+	}, FieldErrors(err))
+}
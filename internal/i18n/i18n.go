@@ -0,0 +1,70 @@
+// Package i18n holds the English and Portuguese messages of validation and
+// error responses, the language of a request is picked from its
+// Accept-Language header
+package i18n
+
+import (
+	"strings"
+	"text/template"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	English    = "en"
+	Portuguese = "pt"
+)
+
+var matcher = language.NewMatcher([]language.Tag{
+	language.English,
+	language.BrazilianPortuguese,
+})
+
+// templates are parsed once from catalogs, messages with params use
+// text/template like {{.min}}
+var templates = parseCatalogs()
+
+func parseCatalogs() map[string]map[string]*template.Template {
+	parsed := make(map[string]map[string]*template.Template, len(catalogs))
+	for lang, messages := range catalogs {
+		parsed[lang] = make(map[string]*template.Template, len(messages))
+		for key, message := range messages {
+			parsed[lang][key] = template.Must(template.New(key).Parse(message))
+		}
+	}
+	return parsed
+}
+
+// Match picks the language of an Accept-Language header, English when none
+// of its languages has a catalog
+func Match(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return English
+	}
+	tag, _, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return English
+	}
+	if base, _ := tag.Base(); base.String() == Portuguese {
+		return Portuguese
+	}
+	return English
+}
+
+// Translate renders the message key of lang with params, falling back to
+// English when lang has no such message. ok is false when no catalog has it
+func Translate(lang, key string, params map[string]interface{}) (string, bool) {
+	tmpl, ok := templates[lang][key]
+	if !ok {
+		tmpl, ok = templates[English][key]
+	}
+	if !ok {
+		return "", false
+	}
+	var message strings.Builder
+	if err := tmpl.Execute(&message, params); err != nil {
+		return "", false
+	}
+	return message.String(), true
+}
@@ -0,0 +1,44 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	testCases := map[string]string{
+		"":                           English,
+		"pt-BR":                      Portuguese,
+		"pt-PT,pt;q=0.9":             Portuguese,
+		"en-US,en;q=0.9,pt;q=0.8":    English,
+		"fr-FR,pt-BR;q=0.7,en;q=0.5": Portuguese,
+		"de":                         English,
+		"not a language;;":           English,
+	}
+	for header, lang := range testCases {
+		require.Equal(t, lang, Match(header), header)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	message, ok := Translate(Portuguese, "validation_length_out_of_range", map[string]interface{}{"min": 5, "max": 254})
+	require.True(t, ok)
+	require.Equal(t, "o tamanho deve estar entre 5 e 254", message)
+
+	message, ok = Translate("es", "validation_required", nil)
+	require.True(t, ok)
+	require.Equal(t, "cannot be blank", message)
+
+	_, ok = Translate(English, "unknown", nil)
+	require.False(t, ok)
+}
+
+func TestCatalogsHaveTheSameKeys(t *testing.T) {
+	for key := range catalogs[English] {
+		require.Contains(t, catalogs[Portuguese], key)
+	}
+	for key := range catalogs[Portuguese] {
+		require.Contains(t, catalogs[English], key)
+	}
+}
@@ -0,0 +1,115 @@
+package i18n
+
+// catalogs are keyed by language then message key. Validation messages use
+// the codes of ozzo-validation errors, problem titles status.<code> and
+// problem details error.<name>
+var catalogs = map[string]map[string]string{
+	English: {
+		"validation_required":                        "cannot be blank",
+		"validation_nil_or_not_empty_required":       "cannot be blank",
+		"validation_not_nil_required":                "is required",
+		"validation_length_too_long":                 "the length must be no more than {{.max}}",
+		"validation_length_too_short":                "the length must be no less than {{.min}}",
+		"validation_length_invalid":                  "the length must be exactly {{.min}}",
+		"validation_length_out_of_range":             "the length must be between {{.min}} and {{.max}}",
+		"validation_in_invalid":                      "must be a valid value",
+		"validation_match_invalid":                   "must be in a valid format",
+		"validation_min_greater_equal_than_required": "must be no less than {{.threshold}}",
+		"validation_min_greater_than_required":       "must be greater than {{.threshold}}",
+		"validation_max_less_equal_than_required":    "must be no greater than {{.threshold}}",
+		"validation_max_less_than_required":          "must be less than {{.threshold}}",
+		"invalid_uuid":                               "must be a valid UUID",
+		"future_required":                            "must be in the future",
+		"invalid_type":                               "must be of type {{.type}}",
+
+		"status.400": "Bad Request",
+		"status.401": "Unauthorized",
+		"status.403": "Forbidden",
+		"status.404": "Not Found",
+		"status.409": "Conflict",
+		"status.413": "Request Entity Too Large",
+		"status.415": "Unsupported Media Type",
+		"status.422": "Unprocessable Entity",
+		"status.429": "Too Many Requests",
+		"status.500": "Internal Server Error",
+		"status.503": "Service Unavailable",
+
+		"error.validation":             "one or more fields are invalid",
+		"error.conflict":               "object state conflict",
+		"error.bad_request":            "the request is invalid",
+		"error.unprocessable":          "the request cannot be processed",
+		"error.invalid_image":          "invalid image{{if .reason}}: {{.reason}}{{end}}",
+		"error.invalid_video":          "invalid video file{{if .reason}}: {{.reason}}{{end}}",
+		"error.invalid_subtitle":       "invalid subtitle{{if .reason}}: {{.reason}}{{end}}",
+		"error.replay_failed":          "failed to replay message{{if .reason}}: {{.reason}}{{end}}",
+		"error.credentials_required":   "a bearer token or api key is required",
+		"error.credentials_invalid":    "the credentials are invalid or expired",
+		"error.missing_permission":     "missing permission {{.permission}}",
+		"error.route_forbidden":        "no permission grants access to this route",
+		"error.route_not_found":        "no route matches this path",
+		"error.method_not_allowed":     "method {{.method}} is not allowed on this path",
+		"error.rate_limited":           "rate limit exceeded",
+		"error.malformed_json":         "malformed JSON at offset {{.offset}}",
+		"error.truncated_json":         "the JSON body ends unexpectedly",
+		"error.malformed_json_body":    "the body is not valid JSON",
+		"error.invalid_query":          "the query string is invalid",
+		"error.invalid_form":           "the multipart form is invalid",
+		"error.body_too_large":         "the request body is too large",
+		"error.unsupported_media_type": "content type {{.type}} is not supported, use {{.supported}}",
+		"error.missing_content_type":   "the body has no content type, use {{.supported}}",
+	},
+	Portuguese: {
+		"validation_required":                        "não pode ficar em branco",
+		"validation_nil_or_not_empty_required":       "não pode ficar em branco",
+		"validation_not_nil_required":                "é obrigatório",
+		"validation_length_too_long":                 "o tamanho deve ser no máximo {{.max}}",
+		"validation_length_too_short":                "o tamanho deve ser no mínimo {{.min}}",
+		"validation_length_invalid":                  "o tamanho deve ser exatamente {{.min}}",
+		"validation_length_out_of_range":             "o tamanho deve estar entre {{.min}} e {{.max}}",
+		"validation_in_invalid":                      "deve ser um valor válido",
+		"validation_match_invalid":                   "deve estar em um formato válido",
+		"validation_min_greater_equal_than_required": "deve ser no mínimo {{.threshold}}",
+		"validation_min_greater_than_required":       "deve ser maior que {{.threshold}}",
+		"validation_max_less_equal_than_required":    "deve ser no máximo {{.threshold}}",
+		"validation_max_less_than_required":          "deve ser menor que {{.threshold}}",
+		"invalid_uuid":                               "deve ser um UUID válido",
+		"future_required":                            "deve estar no futuro",
+		"invalid_type":                               "deve ser do tipo {{.type}}",
+
+		"status.400": "Requisição inválida",
+		"status.401": "Não autorizado",
+		"status.403": "Acesso negado",
+		"status.404": "Não encontrado",
+		"status.409": "Conflito",
+		"status.413": "Requisição muito grande",
+		"status.415": "Tipo de mídia não suportado",
+		"status.422": "Entidade não processável",
+		"status.429": "Muitas requisições",
+		"status.500": "Erro interno do servidor",
+		"status.503": "Serviço indisponível",
+
+		"error.validation":             "um ou mais campos são inválidos",
+		"error.conflict":               "o estado do objeto está em conflito",
+		"error.bad_request":            "a requisição é inválida",
+		"error.unprocessable":          "a requisição não pode ser processada",
+		"error.invalid_image":          "imagem inválida{{if .reason}}: {{.reason}}{{end}}",
+		"error.invalid_video":          "arquivo de vídeo inválido{{if .reason}}: {{.reason}}{{end}}",
+		"error.invalid_subtitle":       "legenda inválida{{if .reason}}: {{.reason}}{{end}}",
+		"error.replay_failed":          "falha ao reenviar a mensagem{{if .reason}}: {{.reason}}{{end}}",
+		"error.credentials_required":   "é necessário um token bearer ou uma chave de api",
+		"error.credentials_invalid":    "as credenciais são inválidas ou expiraram",
+		"error.missing_permission":     "permissão {{.permission}} ausente",
+		"error.route_forbidden":        "nenhuma permissão concede acesso a esta rota",
+		"error.route_not_found":        "nenhuma rota corresponde a este caminho",
+		"error.method_not_allowed":     "o método {{.method}} não é permitido neste caminho",
+		"error.rate_limited":           "limite de requisições excedido",
+		"error.malformed_json":         "JSON malformado na posição {{.offset}}",
+		"error.truncated_json":         "o corpo JSON termina inesperadamente",
+		"error.malformed_json_body":    "o corpo não é um JSON válido",
+		"error.invalid_query":          "a query string é inválida",
+		"error.invalid_form":           "o formulário multipart é inválido",
+		"error.body_too_large":         "o corpo da requisição é muito grande",
+		"error.unsupported_media_type": "o tipo de conteúdo {{.type}} não é suportado, use {{.supported}}",
+		"error.missing_content_type":   "o corpo não tem tipo de conteúdo, use {{.supported}}",
+	},
+}
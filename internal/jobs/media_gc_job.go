@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+)
+
+// MediaGCJob runs the media garbage collector every interval until closed
+type MediaGCJob struct {
+	collector services.MediaGarbageCollector
+	interval  time.Duration
+	dryRun    bool
+	log       logger.Logger
+	stop      chan struct{}
+	wg        sync.WaitGroup
+}
+
+func NewMediaGCJob(collector services.MediaGarbageCollector,
+	interval time.Duration,
+	dryRun bool,
+	log logger.Logger) *MediaGCJob {
+	return &MediaGCJob{
+		collector: collector,
+		interval:  interval,
+		dryRun:    dryRun,
+		log:       log,
+		stop:      make(chan struct{}),
+	}
+}
+
+func (j *MediaGCJob) Start() {
+	j.wg.Add(1)
+	go func() {
+		defer j.wg.Done()
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-j.stop:
+				return
+			case <-ticker.C:
+				j.Run()
+			}
+		}
+	}()
+}
+
+// Run collects once and logs the outcome
+func (j *MediaGCJob) Run() {
+	report, err := j.collector.Collect(context.Background(), j.dryRun)
+	if err != nil {
+		j.log.Errorf("media gc: failed to collect: %v", err)
+		return
+	}
+	if len(report.Failed) > 0 {
+		j.log.Warnf("media gc: failed to delete %d files: %v", len(report.Failed), report.Failed)
+	}
+	j.log.Infof("media gc: scanned %d files, %d orphans, %d deleted, %d bytes freed, dry run %v",
+		report.Scanned, len(report.Orphans), report.Deleted, report.FreedBytes, report.DryRun)
+}
+
+func (j *MediaGCJob) Close() {
+	close(j.stop)
+	j.wg.Wait()
+}
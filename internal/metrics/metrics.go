@@ -0,0 +1,71 @@
+// Package metrics holds the Prometheus collectors of the catalog, they are
+// registered on Registry which the /metrics route exposes
+package metrics
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "catalog"
+
+const (
+	EventCreated = "created"
+	EventDeleted = "deleted"
+)
+
+var (
+	Registry = prometheus.NewRegistry()
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Duration of HTTP requests by method, matched route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	QueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "repository_query_duration_seconds",
+		Help:      "Duration of repository methods by repository and method.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"repository", "method"})
+
+	CatalogEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "entity_events_total",
+		Help:      "Catalog entities created and deleted by entity and event.",
+	}, []string{"entity", "event"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		QueryDuration,
+		CatalogEvents,
+	)
+}
+
+// RegisterDB exposes the connection pool statistics of db
+func RegisterDB(db *sql.DB) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, namespace))
+}
+
+// ObserveQuery starts timing a repository method, call the returned function
+// when it returns
+func ObserveQuery(repository, method string) func() {
+	start := time.Now()
+	return func() {
+		QueryDuration.WithLabelValues(repository, method).Observe(time.Since(start).Seconds())
+	}
+}
+
+// CountEvent records that an entity like category was created or deleted
+func CountEvent(entity, event string) {
+	CatalogEvents.WithLabelValues(entity, event).Inc()
+}
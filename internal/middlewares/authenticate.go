@@ -0,0 +1,105 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/auth"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/jwtauth"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type TokenVerifier interface {
+	Verify(token string) (jwtauth.Claims, error)
+}
+
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (auth.Principal, error)
+}
+
+// IsPublic tells whether path is one of public. Entries ending with a slash
+// match every path below them, others only match the exact path
+func IsPublic(path string, public []string) bool {
+	for _, entry := range public {
+		if path == entry || (strings.HasSuffix(entry, "/") && strings.HasPrefix(path, entry)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticate requires a valid bearer token or X-API-Key header on every
+// request whose path is not public as matched by IsPublic, the caller is
+// placed in the request context as an auth.Principal. verifier may be nil
+// to accept API keys only and keys nil to accept bearer tokens only. With
+// anonymous set, requests without credentials go on with the
+// auth.RoleAnonymous role
+func Authenticate(verifier TokenVerifier,
+	keys APIKeyAuthenticator,
+	anonymous bool,
+	log logger.Logger,
+	public ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if IsPublic(ctx.Request.URL.Path, public) {
+			ctx.Next()
+			return
+		}
+		var principal auth.Principal
+		header := ctx.GetHeader("Authorization")
+		switch key := ctx.GetHeader("X-API-Key"); {
+		case key != "" && keys != nil:
+			var err error
+			principal, err = keys.Authenticate(ctx.Request.Context(), key)
+			if err != nil {
+				log.Warnf("auth: rejected api key: %v", err)
+				unauthorized(ctx, "invalid_token")
+				return
+			}
+		case header == "" && anonymous:
+			principal = auth.Principal{Roles: []string{auth.RoleAnonymous}}
+		default:
+			token, ok := bearerToken(header)
+			if !ok {
+				unauthorized(ctx, "")
+				return
+			}
+			if verifier == nil {
+				log.Warnf("auth: rejected token: no JWKS configured")
+				unauthorized(ctx, "invalid_token")
+				return
+			}
+			claims, err := verifier.Verify(token)
+			if err != nil {
+				log.Warnf("auth: rejected token: %v", err)
+				unauthorized(ctx, "invalid_token")
+				return
+			}
+			principal = auth.Principal{Subject: claims.Subject, Roles: claims.Roles}
+		}
+		ctx.Set(auth.PrincipalKey, principal)
+		ctx.Request = ctx.Request.WithContext(auth.WithPrincipal(ctx.Request.Context(), principal))
+		ctx.Next()
+	}
+}
+
+func bearerToken(header string) (string, bool) {
+	const prefix = "bearer "
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+	token := strings.TrimSpace(header[len(prefix):])
+	return token, token != ""
+}
+
+func unauthorized(ctx *gin.Context, reason string) {
+	challenge := "Bearer"
+	key := "error.credentials_required"
+	if reason != "" {
+		challenge += ` error="` + reason + `"`
+		key = "error.credentials_invalid"
+	}
+	ctx.Header("WWW-Authenticate", challenge)
+	abortWithProblem(ctx, http.StatusUnauthorized, key, nil)
+}
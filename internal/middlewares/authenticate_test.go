@@ -0,0 +1,154 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/auth"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/jwtauth"
+	mock_logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+type verifierFunc func(token string) (jwtauth.Claims, error)
+
+func (f verifierFunc) Verify(token string) (jwtauth.Claims, error) {
+	return f(token)
+}
+
+type apiKeysFunc func(key string) (auth.Principal, error)
+
+func (f apiKeysFunc) Authenticate(_ context.Context, key string) (auth.Principal, error) {
+	return f(key)
+}
+
+func TestAuthenticate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	verifier := verifierFunc(func(token string) (jwtauth.Claims, error) {
+		if token != "good" {
+			return jwtauth.Claims{}, jwtauth.ErrInvalidSignature
+		}
+		return jwtauth.Claims{Subject: "user-1", Roles: []string{"admin"}}, nil
+	})
+	keys := apiKeysFunc(func(key string) (auth.Principal, error) {
+		if key != "vck_good" {
+			return auth.Principal{}, errors.New("invalid api key")
+		}
+		return auth.Principal{Subject: "user-1", Roles: []string{"admin"}}, nil
+	})
+
+	testCases := []struct {
+		name          string
+		path          string
+		authorization string
+		apiKey        string
+		code          int
+		challenge     string
+	}{
+		{name: "valid token", path: "/category", authorization: "Bearer good", code: http.StatusOK},
+		{name: "lowercase scheme", path: "/category", authorization: "bearer good", code: http.StatusOK},
+		{name: "missing header", path: "/category", code: http.StatusUnauthorized, challenge: "Bearer"},
+		{name: "basic scheme", path: "/category", authorization: "Basic Zm9vOmJhcg==", code: http.StatusUnauthorized, challenge: "Bearer"},
+		{name: "invalid token", path: "/category", authorization: "Bearer bad", code: http.StatusUnauthorized, challenge: `Bearer error="invalid_token"`},
+		{name: "valid api key", path: "/category", apiKey: "vck_good", code: http.StatusOK},
+		{name: "invalid api key", path: "/category", apiKey: "vck_bad", authorization: "Bearer good", code: http.StatusUnauthorized, challenge: `Bearer error="invalid_token"`},
+		{name: "public path", path: "/media/videos/a.mp4", code: http.StatusOK},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			log := mock_logger.NewMockLogger(ctrl)
+			log.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+
+			router := gin.New()
+			router.Use(Authenticate(verifier, keys, false, log, "/media/"))
+			router.GET("/*path", func(ctx *gin.Context) {
+				principal, ok := auth.FromContext(ctx.Request.Context())
+				if ctx.Param("path") != "/media/videos/a.mp4" {
+					require.True(t, ok)
+					require.Equal(t, auth.Principal{Subject: "user-1", Roles: []string{"admin"}}, principal)
+					require.True(t, principal.HasRole("admin"))
+				}
+				ctx.Status(http.StatusOK)
+			})
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.authorization != "" {
+				request.Header.Set("Authorization", tc.authorization)
+			}
+			if tc.apiKey != "" {
+				request.Header.Set("X-API-Key", tc.apiKey)
+			}
+			router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.code, recorder.Code)
+			require.Equal(t, tc.challenge, recorder.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
+
+func TestAuthenticate_WithoutVerifier(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	keys := apiKeysFunc(func(key string) (auth.Principal, error) {
+		return auth.Principal{Subject: "key-1", Roles: []string{"catalog:read"}}, nil
+	})
+
+	testCases := []struct {
+		name          string
+		anonymous     bool
+		authorization string
+		apiKey        string
+		code          int
+		roles         []string
+	}{
+		{name: "api key", apiKey: "vck_good", code: http.StatusOK, roles: []string{"catalog:read"}},
+		{name: "bearer token is rejected", authorization: "Bearer good", code: http.StatusUnauthorized},
+		{name: "missing credentials", code: http.StatusUnauthorized},
+		{name: "anonymous", anonymous: true, code: http.StatusOK, roles: []string{auth.RoleAnonymous}},
+		{name: "anonymous with token", anonymous: true, authorization: "Bearer good", code: http.StatusUnauthorized},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			log := mock_logger.NewMockLogger(ctrl)
+			log.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+
+			router := gin.New()
+			router.Use(Authenticate(nil, keys, tc.anonymous, log))
+			router.GET("/category", func(ctx *gin.Context) {
+				principal, ok := auth.FromContext(ctx.Request.Context())
+				require.True(t, ok)
+				require.Equal(t, tc.roles, principal.Roles)
+				ctx.Status(http.StatusOK)
+			})
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/category", nil)
+			if tc.authorization != "" {
+				request.Header.Set("Authorization", tc.authorization)
+			}
+			if tc.apiKey != "" {
+				request.Header.Set("X-API-Key", tc.apiKey)
+			}
+			router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.code, recorder.Code)
+		})
+	}
+}
+
+func TestIsPublic(t *testing.T) {
+	public := []string{"/media/", "/healthz"}
+	require.True(t, IsPublic("/media/videos/a.mp4", public))
+	require.True(t, IsPublic("/healthz", public))
+	require.False(t, IsPublic("/healthz/extra", public))
+	require.False(t, IsPublic("/healthzx", public))
+	require.False(t, IsPublic("/media", public))
+	require.False(t, IsPublic("/metrics", public))
+}
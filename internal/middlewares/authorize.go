@@ -0,0 +1,47 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/auth"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// Authorize checks the principal set by Authenticate holds the permission
+// the matched route requires. Public paths, as matched by IsPublic, are
+// skipped, any other route missing from permissions is denied
+func Authorize(policy auth.Policy,
+	permissions map[string]auth.Permission,
+	log logger.Logger,
+	public ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if IsPublic(ctx.Request.URL.Path, public) {
+			ctx.Next()
+			return
+		}
+		// unmatched requests are answered by the router with 404 or 405
+		if ctx.FullPath() == "" {
+			ctx.Next()
+			return
+		}
+		permission, ok := permissions[ctx.Request.Method+" "+ctx.FullPath()]
+		if !ok {
+			log.Errorf("auth: no permission mapped for %s %s", ctx.Request.Method, ctx.FullPath())
+			abortWithProblem(ctx, http.StatusForbidden, "error.route_forbidden", nil)
+			return
+		}
+		principal, ok := auth.FromContext(ctx.Request.Context())
+		if !ok {
+			abortWithProblem(ctx, http.StatusUnauthorized, "error.credentials_required", nil)
+			return
+		}
+		if !policy.Allows(principal, permission) {
+			log.Warnf("auth: %s denied %s on %s %s", principal.Subject, permission, ctx.Request.Method, ctx.FullPath())
+			abortWithProblem(ctx, http.StatusForbidden, "error.missing_permission",
+				map[string]interface{}{"permission": string(permission)})
+			return
+		}
+		ctx.Next()
+	}
+}
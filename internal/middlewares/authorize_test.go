@@ -0,0 +1,78 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/auth"
+	"github.com/ayrtonsato/video-catalog-golang/internal/helpers"
+	mock_logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	policy, err := auth.ParsePolicy(auth.DefaultPolicy)
+	require.NoError(t, err)
+	permissions := map[string]auth.Permission{
+		"POST /category":       auth.CategoryCreate,
+		"DELETE /category/:id": auth.CategoryDelete,
+	}
+
+	testCases := []struct {
+		name      string
+		method    string
+		path      string
+		principal *auth.Principal
+		code      int
+	}{
+		{name: "editor creates", method: http.MethodPost, path: "/category", principal: &auth.Principal{Subject: "e", Roles: []string{"editor"}}, code: http.StatusOK},
+		{name: "editor deletes", method: http.MethodDelete, path: "/category/1", principal: &auth.Principal{Subject: "e", Roles: []string{"editor"}}, code: http.StatusForbidden},
+		{name: "admin deletes", method: http.MethodDelete, path: "/category/1", principal: &auth.Principal{Subject: "a", Roles: []string{"admin"}}, code: http.StatusOK},
+		{name: "no principal", method: http.MethodPost, path: "/category", code: http.StatusUnauthorized},
+		{name: "api key scope", method: http.MethodPost, path: "/category", principal: &auth.Principal{Subject: "k", Scopes: []string{"catalog:write"}}, code: http.StatusOK},
+		{name: "api key scope without permission", method: http.MethodDelete, path: "/category/1", principal: &auth.Principal{Subject: "k", Scopes: []string{"catalog:write"}}, code: http.StatusForbidden},
+		{name: "route without permission", method: http.MethodGet, path: "/other", principal: &auth.Principal{Subject: "a", Roles: []string{"admin"}}, code: http.StatusForbidden},
+		{name: "public route", method: http.MethodGet, path: "/healthz", code: http.StatusOK},
+		{name: "path extending a public route", method: http.MethodGet, path: "/healthzx", principal: &auth.Principal{Subject: "a", Roles: []string{"admin"}}, code: http.StatusForbidden},
+		{name: "unknown route", method: http.MethodGet, path: "/missing", code: http.StatusNotFound},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			log := mock_logger.NewMockLogger(ctrl)
+			log.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+			log.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
+
+			router := gin.New()
+			router.Use(func(ctx *gin.Context) {
+				if tc.principal != nil {
+					ctx.Request = ctx.Request.WithContext(auth.WithPrincipal(ctx.Request.Context(), *tc.principal))
+				}
+			})
+			router.Use(Authorize(policy, permissions, log, "/healthz"))
+			ok := func(ctx *gin.Context) { ctx.Status(http.StatusOK) }
+			router.POST("/category", ok)
+			router.DELETE("/category/:id", ok)
+			router.GET("/other", ok)
+			router.GET("/healthz", ok)
+			router.GET("/healthzx", ok)
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(tc.method, tc.path, nil))
+			require.Equal(t, tc.code, recorder.Code)
+			if tc.code == http.StatusForbidden && tc.method == http.MethodDelete {
+				require.Equal(t, "application/problem+json", recorder.Header().Get("Content-Type"))
+				var body helpers.Problem
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				require.Equal(t, helpers.Problem{Type: "about:blank", Title: "Forbidden", Status: 403,
+					Detail: "missing permission category:delete", Instance: tc.path}, body)
+			}
+		})
+	}
+}
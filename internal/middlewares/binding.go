@@ -0,0 +1,84 @@
+package middlewares
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/helpers"
+	"github.com/gin-gonic/gin"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+)
+
+// uuidParamKey prefixes the gin keys holding the parsed path parameters
+const uuidParamKey = "uuid_param:"
+
+// UUIDParams rejects requests whose path parameters in names are not UUIDs
+// with a 400, parsed values are read back with UUIDParam
+func UUIDParams(names ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		invalid := validation.Errors{}
+		for _, name := range names {
+			value, ok := ctx.Params.Get(name)
+			if !ok {
+				continue
+			}
+			id, err := uuid.FromString(value)
+			if err != nil {
+				invalid[name] = helpers.ErrInvalidUUID
+				continue
+			}
+			ctx.Set(uuidParamKey+name, id)
+		}
+		if len(invalid) > 0 {
+			ctx.Abort()
+			WriteProblem(ctx, helpers.HTTPBadRequestError(invalid).Body.(helpers.Problem))
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// UUIDParam is the path parameter name parsed by UUIDParams, it is parsed
+// again when the middleware did not run and is uuid.Nil when malformed
+func UUIDParam(ctx *gin.Context, name string) uuid.UUID {
+	if value, ok := ctx.Get(uuidParamKey + name); ok {
+		return value.(uuid.UUID)
+	}
+	return uuid.FromStringOrNil(ctx.Param(name))
+}
+
+// Consumes answers 415 to requests with a body which is not of one of the
+// media types in types, a body without Content-Type included. Requests
+// without body are let through so the handler reports the missing fields
+func Consumes(types ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !hasBody(ctx.Request) {
+			ctx.Next()
+			return
+		}
+		header := ctx.GetHeader("Content-Type")
+		if header == "" {
+			abortWithProblem(ctx, http.StatusUnsupportedMediaType, "error.missing_content_type",
+				map[string]interface{}{"supported": strings.Join(types, ", ")})
+			return
+		}
+		mediaType, _, err := mime.ParseMediaType(header)
+		if err == nil {
+			for _, allowed := range types {
+				if strings.EqualFold(mediaType, allowed) {
+					ctx.Next()
+					return
+				}
+			}
+		}
+		abortWithProblem(ctx, http.StatusUnsupportedMediaType, "error.unsupported_media_type",
+			map[string]interface{}{"type": header, "supported": strings.Join(types, ", ")})
+	}
+}
+
+func hasBody(request *http.Request) bool {
+	return request.Body != nil && request.Body != http.NoBody &&
+		(request.ContentLength != 0 || len(request.TransferEncoding) > 0)
+}
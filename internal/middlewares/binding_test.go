@@ -0,0 +1,85 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/helpers"
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUUIDParams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(UUIDParams("id"))
+	router.GET("/category/:id", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, UUIDParam(ctx, "id").String())
+	})
+	router.GET("/category", func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	id := uuid.Must(uuid.NewV4())
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/category/"+id.String(), nil))
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Equal(t, id.String(), response.Body.String())
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/category", nil))
+	require.Equal(t, http.StatusOK, response.Code)
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/category/teste", nil))
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Equal(t, helpers.ProblemContentType, response.Header().Get("Content-Type"))
+	var body helpers.Problem
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+	require.Len(t, body.Errors, 1)
+	require.Equal(t, "id", body.Errors[0].Field)
+	require.Equal(t, "invalid_uuid", body.Errors[0].Code)
+}
+
+func TestConsumes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/category", Consumes(gin.MIMEJSON), func(ctx *gin.Context) {
+		ctx.Status(http.StatusCreated)
+	})
+
+	testCases := []struct {
+		name        string
+		contentType string
+		body        string
+		code        int
+	}{
+		{name: "json", contentType: "application/json", body: "{}", code: http.StatusCreated},
+		{name: "json with charset", contentType: "application/json; charset=utf-8", body: "{}", code: http.StatusCreated},
+		{name: "no content type", body: "{}", code: http.StatusUnsupportedMediaType},
+		{name: "no content type nor body", code: http.StatusCreated},
+		{name: "no body", contentType: "text/plain", code: http.StatusCreated},
+		{name: "form", contentType: "application/x-www-form-urlencoded", body: "name=a", code: http.StatusUnsupportedMediaType},
+		{name: "malformed content type", contentType: "application/", body: "{}", code: http.StatusUnsupportedMediaType},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/category", strings.NewReader(tc.body))
+			if tc.contentType != "" {
+				request.Header.Set("Content-Type", tc.contentType)
+			}
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, request)
+			require.Equal(t, tc.code, response.Code)
+			if tc.code == http.StatusUnsupportedMediaType {
+				var body helpers.Problem
+				require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+				require.Contains(t, body.Detail, "use application/json")
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package middlewares
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientIPKey holds the resolved address of the caller in the gin context
+const ClientIPKey = "client_ip"
+
+// ClientIP resolves the address of the caller. X-Forwarded-For is only
+// followed while the hop it came from is one of the trusted proxies, read
+// from the right so entries added by the client are never reached
+func ClientIP(trusted []*net.IPNet) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ip := peerIP(ctx)
+		if isTrusted(ip, trusted) {
+			hops := strings.Split(ctx.GetHeader("X-Forwarded-For"), ",")
+			for i := len(hops) - 1; i >= 0; i-- {
+				hop := net.ParseIP(strings.TrimSpace(hops[i]))
+				if hop == nil {
+					break
+				}
+				ip = hop
+				if !isTrusted(ip, trusted) {
+					break
+				}
+			}
+		}
+		if ip != nil {
+			ctx.Set(ClientIPKey, ip.String())
+		}
+		ctx.Next()
+	}
+}
+
+// ClientIPOf returns the address resolved by ClientIP, or the peer address
+// when it did not run. Unlike gin's ClientIP it never trusts a header the
+// caller can set
+func ClientIPOf(ctx *gin.Context) string {
+	if ip := ctx.GetString(ClientIPKey); ip != "" {
+		return ip
+	}
+	if ip := peerIP(ctx); ip != nil {
+		return ip.String()
+	}
+	return ""
+}
+
+func peerIP(ctx *gin.Context) net.IP {
+	host, _, err := net.SplitHostPort(strings.TrimSpace(ctx.Request.RemoteAddr))
+	if err != nil {
+		return nil
+	}
+	return net.ParseIP(host)
+}
+
+func isTrusted(ip net.IP, trusted []*net.IPNet) bool {
+	if ip == nil {
+		return false
+	}
+	for _, network := range trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
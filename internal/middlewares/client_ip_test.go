@@ -0,0 +1,49 @@
+package middlewares
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	_, proxies, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		remote    string
+		forwarded string
+		ip        string
+	}{
+		{name: "direct caller", remote: "203.0.113.7:1234", ip: "203.0.113.7"},
+		{name: "forged header from an untrusted caller", remote: "203.0.113.7:1234", forwarded: "198.51.100.1", ip: "203.0.113.7"},
+		{name: "trusted proxy", remote: "10.0.0.1:1234", forwarded: "198.51.100.1", ip: "198.51.100.1"},
+		{name: "forged entry before the proxy hop", remote: "10.0.0.1:1234", forwarded: "198.51.100.9, 203.0.113.7", ip: "203.0.113.7"},
+		{name: "chain of trusted proxies", remote: "10.0.0.1:1234", forwarded: "203.0.113.7, 10.0.0.2", ip: "203.0.113.7"},
+		{name: "trusted proxy without header", remote: "10.0.0.1:1234", ip: "10.0.0.1"},
+		{name: "malformed hop", remote: "10.0.0.1:1234", forwarded: "not-an-ip", ip: "10.0.0.1"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got string
+			router := gin.New()
+			router.ForwardedByClientIP = false
+			router.Use(ClientIP([]*net.IPNet{proxies}))
+			router.GET("/", func(ctx *gin.Context) { got = ClientIPOf(ctx) })
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			request.RemoteAddr = tc.remote
+			if tc.forwarded != "" {
+				request.Header.Set("X-Forwarded-For", tc.forwarded)
+			}
+			router.ServeHTTP(httptest.NewRecorder(), request)
+			require.Equal(t, tc.ip, got)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	durations := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "test_duration_seconds"},
+		[]string{"method", "route", "status"})
+	router := gin.New()
+	router.Use(Metrics(durations))
+	router.GET("/category/:id", func(ctx *gin.Context) {
+		ctx.Status(http.StatusNoContent)
+	})
+
+	for _, path := range []string{"/category/1", "/category/2", "/wp-login.php"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	require.Equal(t, 2, testutil.CollectAndCount(durations))
+	require.Equal(t, uint64(2), sampleCount(t, durations, "GET", "/category/:id", "204"))
+	require.Equal(t, uint64(1), sampleCount(t, durations, "GET", "unmatched", "404"))
+}
+
+func sampleCount(t *testing.T, durations *prometheus.HistogramVec, labels ...string) uint64 {
+	observer, err := durations.GetMetricWithLabelValues(labels...)
+	require.NoError(t, err)
+	metric := &dto.Metric{}
+	require.NoError(t, observer.(prometheus.Histogram).Write(metric))
+	return metric.GetHistogram().GetSampleCount()
+}
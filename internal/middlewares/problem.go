@@ -0,0 +1,49 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/helpers"
+	"github.com/ayrtonsato/video-catalog-golang/internal/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// WriteProblem writes problem as application/problem+json in the language
+// of the Accept-Language header, the request path and ID are added so
+// clients can report which request failed
+func WriteProblem(ctx *gin.Context, problem helpers.Problem) {
+	lang := i18n.Match(ctx.GetHeader("Accept-Language"))
+	problem = problem.Localize(lang)
+	if problem.Instance == "" {
+		problem.Instance = ctx.Request.URL.Path
+	}
+	problem.RequestID = ctx.GetString(RequestIDKey)
+	ctx.Header("Content-Type", helpers.ProblemContentType)
+	ctx.Header("Content-Language", lang)
+	ctx.Header("Vary", "Accept-Language")
+	ctx.JSON(problem.Status, problem)
+}
+
+// abortWithProblem ends the request with an application/problem+json body
+// detailed by the i18n message key
+func abortWithProblem(ctx *gin.Context, status int, key string, params map[string]interface{}) {
+	ctx.Abort()
+	WriteProblem(ctx, helpers.NewLocalizedProblem(status, key, params))
+}
+
+// NotFound answers requests matching no route with a problem, it is
+// registered with gin's NoRoute
+func NotFound() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		abortWithProblem(ctx, http.StatusNotFound, "error.route_not_found", nil)
+	}
+}
+
+// MethodNotAllowed answers requests to a known path with another method
+// with a problem, it is registered with gin's NoMethod
+func MethodNotAllowed() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		abortWithProblem(ctx, http.StatusMethodNotAllowed, "error.method_not_allowed",
+			map[string]interface{}{"method": ctx.Request.Method})
+	}
+}
@@ -0,0 +1,45 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/helpers"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotFoundAndMethodNotAllowed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.HandleMethodNotAllowed = true
+	router.NoRoute(NotFound())
+	router.NoMethod(MethodNotAllowed())
+	router.GET("/category", func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	testCases := []struct {
+		method string
+		path   string
+		code   int
+		detail string
+	}{
+		{method: http.MethodGet, path: "/unknown", code: http.StatusNotFound, detail: "no route matches this path"},
+		{method: http.MethodDelete, path: "/category", code: http.StatusMethodNotAllowed,
+			detail: "method DELETE is not allowed on this path"},
+	}
+	for _, tc := range testCases {
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, httptest.NewRequest(tc.method, tc.path, nil))
+		require.Equal(t, tc.code, response.Code)
+		require.Equal(t, helpers.ProblemContentType, response.Header().Get("Content-Type"))
+		var body helpers.Problem
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+		require.Equal(t, tc.code, body.Status)
+		require.Equal(t, tc.detail, body.Detail)
+		require.Equal(t, tc.path, body.Instance)
+	}
+}
@@ -0,0 +1,75 @@
+package middlewares
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/auth"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit limits requests per client, identified by its api key, token
+// subject or ip address, answering 429 once its bucket is empty. A failing
+// limiter lets requests through
+func RateLimit(limiter ratelimit.Limiter, rules ratelimit.Rules, log logger.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		limit, bucket, ok := rules.For(ctx.Request.Method + " " + ctx.FullPath())
+		if !ok {
+			ctx.Next()
+			return
+		}
+		if allow(ctx, limiter, clientKey(ctx)+":"+bucket, limit, log) {
+			ctx.Next()
+		}
+	}
+}
+
+// RateLimitIP limits every request of an ip address in a single bucket. It
+// runs before authentication so requests with bad credentials, which never
+// reach RateLimit, are limited as well
+func RateLimitIP(limiter ratelimit.Limiter, limit ratelimit.Limit, log logger.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if allow(ctx, limiter, "ip:"+ClientIPOf(ctx)+":preauth", limit, log) {
+			ctx.Next()
+		}
+	}
+}
+
+// allow takes a token from the bucket under key and sets the RateLimit
+// headers, the request is aborted with 429 when it returns false
+func allow(ctx *gin.Context, limiter ratelimit.Limiter, key string, limit ratelimit.Limit, log logger.Logger) bool {
+	result, err := limiter.Allow(key, limit)
+	if err != nil {
+		log.Warnf("ratelimit: limiter failed, allowing request: %v", err)
+		return true
+	}
+	ctx.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+	ctx.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	ctx.Header("RateLimit-Reset", ceilSeconds(result.Reset))
+	if !result.Allowed {
+		ctx.Header("Retry-After", ceilSeconds(result.RetryAfter))
+		abortWithProblem(ctx, http.StatusTooManyRequests, "error.rate_limited", nil)
+		return false
+	}
+	return true
+}
+
+func clientKey(ctx *gin.Context) string {
+	// anonymous callers have no subject and are told apart by address
+	if principal, ok := auth.FromContext(ctx.Request.Context()); ok && principal.Subject != "" {
+		if strings.HasPrefix(principal.Subject, "apikey:") {
+			return principal.Subject
+		}
+		return "user:" + principal.Subject
+	}
+	return "ip:" + ClientIPOf(ctx)
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,153 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/auth"
+	mock_logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger/mocks"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/ratelimit"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+type limiterFunc func(key string, limit ratelimit.Limit) (ratelimit.Result, error)
+
+func (f limiterFunc) Allow(key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	return f(key, limit)
+}
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rules, err := ratelimit.ParseRules("", "POST /category=1/m")
+	require.NoError(t, err)
+
+	newRouter := func(limiter ratelimit.Limiter, log *mock_logger.MockLogger, principal *auth.Principal) *gin.Engine {
+		router := gin.New()
+		router.Use(func(ctx *gin.Context) {
+			if principal != nil {
+				ctx.Request = ctx.Request.WithContext(auth.WithPrincipal(ctx.Request.Context(), *principal))
+			}
+		})
+		router.Use(RateLimit(limiter, rules, log))
+		ok := func(ctx *gin.Context) { ctx.Status(http.StatusOK) }
+		router.POST("/category", ok)
+		router.GET("/category", ok)
+		return router
+	}
+	serve := func(router *gin.Engine, method string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(method, "/category", nil)
+		request.RemoteAddr = "10.0.0.1:1234"
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	t.Run("Should answer 429 with headers once the bucket is empty", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		router := newRouter(ratelimit.NewMemoryLimiter(), mock_logger.NewMockLogger(ctrl), nil)
+
+		first := serve(router, http.MethodPost)
+		require.Equal(t, http.StatusOK, first.Code)
+		require.Equal(t, "1", first.Header().Get("RateLimit-Limit"))
+		require.Equal(t, "0", first.Header().Get("RateLimit-Remaining"))
+		require.Equal(t, "60", first.Header().Get("RateLimit-Reset"))
+
+		second := serve(router, http.MethodPost)
+		require.Equal(t, http.StatusTooManyRequests, second.Code)
+		require.Equal(t, "60", second.Header().Get("Retry-After"))
+		require.Equal(t, "application/problem+json", second.Header().Get("Content-Type"))
+
+		unlimited := serve(router, http.MethodGet)
+		require.Equal(t, http.StatusOK, unlimited.Code)
+		require.Empty(t, unlimited.Header().Get("RateLimit-Limit"))
+	})
+
+	t.Run("Should key buckets by client", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		var keys []string
+		limiter := limiterFunc(func(key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+			keys = append(keys, key)
+			return ratelimit.Result{Allowed: true, Limit: 1}, nil
+		})
+		serve(newRouter(limiter, nil, nil), http.MethodPost)
+		serve(newRouter(limiter, nil, &auth.Principal{Subject: "sub-1"}), http.MethodPost)
+		serve(newRouter(limiter, nil, &auth.Principal{Subject: "apikey:k1"}), http.MethodPost)
+		serve(newRouter(limiter, nil, &auth.Principal{Roles: []string{auth.RoleAnonymous}}), http.MethodPost)
+		require.Equal(t, []string{
+			"ip:10.0.0.1:POST /category",
+			"user:sub-1:POST /category",
+			"apikey:k1:POST /category",
+			"ip:10.0.0.1:POST /category",
+		}, keys)
+	})
+
+	t.Run("Should let requests through when the limiter fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		log := mock_logger.NewMockLogger(ctrl)
+		log.EXPECT().Warnf(gomock.Any(), gomock.Any()).Times(1)
+		limiter := limiterFunc(func(key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+			return ratelimit.Result{}, errors.New("redis down")
+		})
+		require.Equal(t, http.StatusOK, serve(newRouter(limiter, log, nil), http.MethodPost).Code)
+	})
+}
+
+func TestRateLimitIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limit, err := ratelimit.ParseLimit("2/m")
+	require.NoError(t, err)
+
+	var handled int
+	router := gin.New()
+	router.Use(RateLimitIP(ratelimit.NewMemoryLimiter(), limit, nil))
+	// stands in for Authenticate rejecting bad credentials
+	router.Use(func(ctx *gin.Context) {
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+	})
+	router.GET("/category", func(ctx *gin.Context) { handled++ })
+	serve := func(addr string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/category", nil)
+		request.RemoteAddr = addr
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	require.Equal(t, http.StatusUnauthorized, serve("10.0.0.1:1234").Code)
+	require.Equal(t, http.StatusUnauthorized, serve("10.0.0.1:1235").Code)
+	limited := serve("10.0.0.1:1236")
+	require.Equal(t, http.StatusTooManyRequests, limited.Code)
+	require.Equal(t, "30", limited.Header().Get("Retry-After"))
+	require.Equal(t, http.StatusUnauthorized, serve("10.0.0.2:1234").Code)
+	require.Zero(t, handled)
+}
+
+func TestRateLimitIP_IgnoresForgedForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limit, err := ratelimit.ParseLimit("1/m")
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(ClientIP(nil))
+	router.Use(RateLimitIP(ratelimit.NewMemoryLimiter(), limit, nil))
+	router.GET("/category", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	serve := func(forwarded string) int {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/category", nil)
+		request.RemoteAddr = "203.0.113.7:1234"
+		request.Header.Set("X-Forwarded-For", forwarded)
+		router.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	require.Equal(t, http.StatusOK, serve("198.51.100.1"))
+	require.Equal(t, http.StatusTooManyRequests, serve("198.51.100.2"))
+	require.Equal(t, http.StatusTooManyRequests, serve("198.51.100.3"))
+}
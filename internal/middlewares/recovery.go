@@ -0,0 +1,84 @@
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/helpers"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/errreport"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// Recovery turns panics of the next handlers into a problem+json 500. The
+// panic and its stack are logged through the request scoped logger and sent
+// to reporter. Panics caused by clients which went away are only logged
+// since nothing can be written to them
+func Recovery(log logger.Logger, reporter errreport.Reporter) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+			err, ok := recovered.(error)
+			if !ok {
+				err = fmt.Errorf("%v", recovered)
+			}
+			requestLog := logger.FromContext(ctx.Request.Context(), log)
+			if brokenConnection(err) {
+				requestLog.Warnw("connection closed by client", "error", err, "path", ctx.Request.URL.Path)
+				ctx.Abort()
+				return
+			}
+
+			stack := debug.Stack()
+			requestLog.Errorw("panic recovered",
+				"error", err,
+				"method", ctx.Request.Method,
+				"route", ctx.FullPath(),
+				"stack", string(stack))
+			reporter.Report(ctx.Request.Context(), errreport.Event{
+				Err:       err,
+				Stack:     stack,
+				RequestID: ctx.GetString(RequestIDKey),
+				Method:    ctx.Request.Method,
+				Route:     ctx.FullPath(),
+				Path:      ctx.Request.URL.Path,
+				Time:      time.Now(),
+			})
+			_ = ctx.Error(err)
+
+			ctx.Abort()
+			if ctx.Writer.Written() {
+				return
+			}
+			WriteProblem(ctx, helpers.NewProblem(http.StatusInternalServerError, ""))
+		}()
+		ctx.Next()
+	}
+}
+
+// brokenConnection tells whether err comes from writing to a connection the
+// client closed
+func brokenConnection(err error) bool {
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+	var syscallErr *os.SyscallError
+	if !errors.As(opErr, &syscallErr) {
+		return false
+	}
+	message := strings.ToLower(syscallErr.Error())
+	return strings.Contains(message, "broken pipe") || strings.Contains(message, "connection reset by peer")
+}
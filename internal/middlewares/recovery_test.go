@@ -0,0 +1,66 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/helpers"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/errreport"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type recordingReporter struct {
+	events []errreport.Event
+}
+
+func (r *recordingReporter) Report(_ context.Context, event errreport.Event) {
+	r.events = append(r.events, event)
+}
+
+func TestRecovery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zap.DebugLevel)
+	log := zap.New(core).Sugar()
+	reporter := &recordingReporter{}
+	router := gin.New()
+	router.Use(RequestID(log), Recovery(log, reporter))
+	router.PUT("/category/:id", func(ctx *gin.Context) {
+		var params map[string]interface{}
+		_ = params["id"].(string)
+	})
+
+	request := httptest.NewRequest(http.MethodPut, "/category/1", nil)
+	request.Header.Set(RequestIDHeader, "abc-123")
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	require.Equal(t, http.StatusInternalServerError, response.Code)
+	require.Equal(t, helpers.ProblemContentType, response.Header().Get("Content-Type"))
+	var body helpers.Problem
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &body))
+	require.Equal(t, helpers.Problem{
+		Type:      "about:blank",
+		Title:     "Internal Server Error",
+		Status:    500,
+		Instance:  "/category/1",
+		RequestID: "abc-123",
+	}, body)
+
+	entries := logs.FilterMessage("panic recovered").All()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	require.Equal(t, "abc-123", fields["request_id"])
+	require.Equal(t, "/category/:id", fields["route"])
+	require.Contains(t, fields["stack"], "runtime/debug.Stack")
+
+	require.Len(t, reporter.events, 1)
+	require.Equal(t, "abc-123", reporter.events[0].RequestID)
+	require.Equal(t, "/category/1", reporter.events[0].Path)
+	require.Error(t, reporter.events[0].Err)
+}
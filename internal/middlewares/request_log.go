@@ -0,0 +1,90 @@
+package middlewares
+
+import (
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDKey holds the request id in the gin context
+	RequestIDKey = "request_id"
+
+	maxRequestIDLength = 128
+)
+
+// RequestID keeps the X-Request-ID of the caller or assigns a new one,
+// echoes it in the response and stores a logger tagged with it, and with the
+// trace id when the request is traced, in the request context
+func RequestID(log logger.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.Must(uuid.NewV4()).String()
+		}
+		ctx.Set(RequestIDKey, id)
+		ctx.Header(RequestIDHeader, id)
+
+		fields := []interface{}{"request_id", id}
+		if span := trace.SpanContextFromContext(ctx.Request.Context()); span.IsValid() {
+			fields = append(fields, "trace_id", span.TraceID().String())
+		}
+		requestLog := logger.With(log, fields...)
+		ctx.Request = ctx.Request.WithContext(logger.WithContext(ctx.Request.Context(), requestLog))
+		ctx.Next()
+	}
+}
+
+// validRequestID accepts ids of printable ASCII so callers cannot inject
+// line breaks or control characters into the logs
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// AccessLog logs every request once it is served through the request scoped
+// logger, server errors are logged as errors and client errors as warnings
+func AccessLog(log logger.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := ctx.Writer.Status()
+		fields := []interface{}{
+			"method", ctx.Request.Method,
+			"route", route,
+			"path", ctx.Request.URL.Path,
+			"status", status,
+			"latency", time.Since(start).String(),
+			"client", ClientIPOf(ctx),
+			"bytes", ctx.Writer.Size(),
+		}
+		if len(ctx.Errors) > 0 {
+			fields = append(fields, "errors", ctx.Errors.String())
+		}
+		requestLog := logger.FromContext(ctx.Request.Context(), log)
+		switch {
+		case status >= 500:
+			requestLog.Errorw("request", fields...)
+		case status >= 400:
+			requestLog.Warnw("request", fields...)
+		default:
+			requestLog.Infow("request", fields...)
+		}
+	}
+}
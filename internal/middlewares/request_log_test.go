@@ -0,0 +1,67 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestIDAndAccessLog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zap.DebugLevel)
+	log := zap.New(core).Sugar()
+	router := gin.New()
+	router.Use(RequestID(log), AccessLog(log))
+	router.GET("/category/:id", func(ctx *gin.Context) {
+		logger.FromContext(ctx.Request.Context(), nil).Info("loading category")
+		ctx.Status(http.StatusNotFound)
+	})
+
+	testCases := []struct {
+		name     string
+		incoming string
+		kept     bool
+	}{
+		{name: "propagate caller id", incoming: "abc-123", kept: true},
+		{name: "assign id when missing", incoming: "", kept: false},
+		{name: "replace id with control characters", incoming: "abc\nlevel=error", kept: false},
+		{name: "replace overlong id", incoming: strings.Repeat("a", 129), kept: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logs.TakeAll()
+			request := httptest.NewRequest(http.MethodGet, "/category/1", nil)
+			if tc.incoming != "" {
+				request.Header.Set(RequestIDHeader, tc.incoming)
+			}
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, request)
+
+			id := response.Header().Get(RequestIDHeader)
+			require.NotEmpty(t, id)
+			if tc.kept {
+				require.Equal(t, tc.incoming, id)
+			} else {
+				require.NotEqual(t, tc.incoming, id)
+			}
+
+			entries := logs.TakeAll()
+			require.Len(t, entries, 2)
+			require.Equal(t, "loading category", entries[0].Message)
+			require.Equal(t, id, entries[0].ContextMap()["request_id"])
+			access := entries[1].ContextMap()
+			require.Equal(t, zap.WarnLevel, entries[1].Level)
+			require.Equal(t, id, access["request_id"])
+			require.Equal(t, "GET", access["method"])
+			require.Equal(t, "/category/:id", access["route"])
+			require.Equal(t, int64(http.StatusNotFound), access["status"])
+		})
+	}
+}
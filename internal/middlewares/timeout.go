@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Timeout bounds the context of each request so queries made with it are
+// cancelled once the route's timeout or defaultTimeout elapses, a zero
+// timeout leaves the request unbounded. The context is also cancelled when
+// the client disconnects
+func Timeout(defaultTimeout time.Duration, routes map[string]time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		timeout, ok := routes[ctx.Request.Method+" "+ctx.FullPath()]
+		if !ok {
+			timeout = defaultTimeout
+		}
+		if timeout <= 0 {
+			ctx.Next()
+			return
+		}
+		bounded, cancel := context.WithTimeout(ctx.Request.Context(), timeout)
+		defer cancel()
+		ctx.Request = ctx.Request.WithContext(bounded)
+		ctx.Next()
+	}
+}
@@ -0,0 +1,31 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Timeout(time.Minute, map[string]time.Duration{"GET /slow": time.Hour}))
+	deadlines := make(map[string]time.Duration)
+	handler := func(ctx *gin.Context) {
+		deadline, ok := ctx.Request.Context().Deadline()
+		require.True(t, ok)
+		deadlines[ctx.FullPath()] = time.Until(deadline).Round(time.Minute)
+		ctx.Status(http.StatusOK)
+	}
+	router.GET("/fast", handler)
+	router.GET("/slow", handler)
+
+	for _, path := range []string{"/fast", "/slow"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	require.Equal(t, map[string]time.Duration{"/fast": time.Minute, "/slow": time.Hour}, deadlines)
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	ScopeCatalogRead  = "catalog:read"
+	ScopeCatalogWrite = "catalog:write"
+	ScopeMediaUpload  = "media:upload"
+)
+
+// APIKeyScopes lists the scopes a key can be issued with
+var APIKeyScopes = []string{ScopeCatalogRead, ScopeCatalogWrite, ScopeMediaUpload}
+
+// APIKey authenticates a machine client, only the hash of the key is stored
+// and Prefix is kept so the owner can tell keys apart
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	CreatedBy  string     `json:"createdBy"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// Active tells whether the key is neither revoked nor expired at now
+func (k APIKey) Active(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// IssuedAPIKey is returned once when a key is issued, Key can not be
+// recovered afterwards
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"github.com/gofrs/uuid"
+	"time"
+)
+
+type DeadLetter struct {
+	ID         uuid.UUID  `json:"id"`
+	Source     string     `json:"source"`
+	Payload    string     `json:"payload"`
+	Reason     string     `json:"reason"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ReplayedAt *time.Time `json:"replayedAt"`
+}
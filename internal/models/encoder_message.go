@@ -0,0 +1,92 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/gofrs/uuid"
+)
+
+// EncodedFiles is the list of output paths written by the encoder, it is
+// persisted as a JSON array. The encoder may list only its manifests, every
+// file below EncodedDir of an encoded video is kept by the media garbage
+// collector
+type EncodedFiles []string
+
+// EncodedDir is the directory the encoder writes the outputs of a video to
+func EncodedDir(videoID string) string {
+	return "videos/" + videoID + "/encoded/"
+}
+
+func (e EncodedFiles) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]string(e))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (e *EncodedFiles) Scan(src interface{}) error {
+	var data []byte
+	switch value := src.(type) {
+	case nil:
+		*e = EncodedFiles{}
+		return nil
+	case []byte:
+		data = value
+	case string:
+		data = []byte(value)
+	default:
+		return errors.New("models: unsupported encoded files type")
+	}
+	return json.Unmarshal(data, (*[]string)(e))
+}
+
+// EncoderMessage is the "video encoded" message published by the encoder
+type EncoderMessage struct {
+	MessageID string      `json:"message_id"`
+	VideoID   string      `json:"video_id"`
+	Status    VideoStatus `json:"status"`
+	Error     string      `json:"error"`
+	Outputs   []string    `json:"outputs"`
+	EncodedAt time.Time   `json:"encoded_at"`
+}
+
+func (m EncoderMessage) Validate() error {
+	return validation.ValidateStruct(&m,
+		validation.Field(&m.MessageID, validation.Required, validation.Length(1, 255)),
+		validation.Field(&m.VideoID, validation.Required, is.UUID),
+		validation.Field(&m.Status, validation.Required,
+			validation.In(VideoStatusProcessing, VideoStatusCompleted, VideoStatusFailed)),
+		validation.Field(&m.Error,
+			validation.When(m.Status == VideoStatusFailed, validation.Required)),
+		validation.Field(&m.Outputs,
+			validation.When(m.Status == VideoStatusCompleted, validation.Required),
+			validation.Each(validation.Required, validation.Length(1, 255),
+				validation.By(m.inEncodedDir))),
+	)
+}
+
+// inEncodedDir requires an output below the encoded directory of the video,
+// anywhere else it would not be kept by the media garbage collector
+func (m EncoderMessage) inEncodedDir(value interface{}) error {
+	output, _ := value.(string)
+	dir := EncodedDir(m.VideoID)
+	if !strings.HasPrefix(output, dir) || len(output) == len(dir) || path.Clean(output) != output {
+		return validation.NewError("validation_encoded_dir", "must be a file below "+dir)
+	}
+	return nil
+}
+
+func (m EncoderMessage) VideoUUID() uuid.UUID {
+	return uuid.FromStringOrNil(m.VideoID)
+}
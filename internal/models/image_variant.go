@@ -0,0 +1,10 @@
+package models
+
+type ImageVariant struct {
+	Kind   VideoFileKind `json:"kind"`
+	Width  int           `json:"width"`
+	Height int           `json:"height"`
+	Format string        `json:"format"`
+	Path   string        `json:"-"`
+	URL    string        `json:"url"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// MediaBlob is a content addressed file shared by every video uploading
+// the same bytes, it is deleted once RefCount drops to zero
+type MediaBlob struct {
+	Hash      string    `json:"hash"`
+	Path      string    `json:"path"`
+	Size      int64     `json:"size"`
+	RefCount  int       `json:"refCount"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// MediaReferences lists what the database points to in the storage, files
+// are exact paths and directories hold every file below them
+type MediaReferences struct {
+	Files       []string
+	Directories []string
+}
+
+type OrphanFile struct {
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+}
+
+// GCReport is the outcome of a media garbage collection run
+type GCReport struct {
+	DryRun     bool         `json:"dryRun"`
+	Scanned    int          `json:"scanned"`
+	Referenced int          `json:"referenced"`
+	Recent     int          `json:"recent"`
+	Orphans    []OrphanFile `json:"orphans"`
+	Deleted    int          `json:"deleted"`
+	FreedBytes int64        `json:"freedBytes"`
+	Failed     []string     `json:"failed"`
+}
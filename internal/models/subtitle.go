@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Subtitle is a WebVTT caption track of a video, one per language
+type Subtitle struct {
+	ID        uuid.UUID `json:"id"`
+	VideoID   uuid.UUID `json:"videoId"`
+	Language  string    `json:"language"`
+	Label     string    `json:"label"`
+	Path      string    `json:"-"`
+	URL       string    `json:"url"`
+	CueCount  int       `json:"cueCount"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
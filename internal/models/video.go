@@ -5,23 +5,80 @@ import (
 	"time"
 )
 
+type VideoFileKind string
+
+const (
+	VideoFileKindVideo   VideoFileKind = "video"
+	VideoFileKindTrailer VideoFileKind = "trailer"
+	VideoFileKindThumb   VideoFileKind = "thumb"
+	VideoFileKindBanner  VideoFileKind = "banner"
+)
+
 type Video struct {
-	Id           uuid.UUID    `json:"id"`
-	Title        string       `json:"title"`
-	Description  string       `json:"description"`
-	YearLaunched int          `json:"year_launched"`
-	Opened       bool         `json:"opened"`
-	Rating       string       `json:"rating"`
-	Duration     int          `json:"duration"`
-	Genres       []Genre      `json:"genres"`
-	Categories   []Category   `json:"categories"`
-	CastMembers  []CastMember `json:"castMembers"`
-	IsActive     bool         `json:"isActive"`
-	CreatedAt    time.Time    `json:"createdAt"`
-	UpdatedAt    time.Time    `json:"updatedAt"`
-	DeletedAt    *time.Time   `json:"deletedAt"`
+	Id               uuid.UUID      `json:"id"`
+	Title            string         `json:"title"`
+	Description      string         `json:"description"`
+	YearLaunched     int            `json:"year_launched"`
+	Opened           bool           `json:"opened"`
+	Rating           string         `json:"rating"`
+	Duration         int            `json:"duration"`
+	VideoFile        *string        `json:"videoFile"`
+	TrailerFile      *string        `json:"trailerFile"`
+	ThumbFile        *string        `json:"thumbFile"`
+	BannerFile       *string        `json:"bannerFile"`
+	Status           VideoStatus    `json:"status"`
+	StatusError      *string        `json:"statusError"`
+	ProcessingAt     *time.Time     `json:"processingAt"`
+	CompletedAt      *time.Time     `json:"completedAt"`
+	FailedAt         *time.Time     `json:"failedAt"`
+	EncodedFiles     EncodedFiles   `json:"encodedFiles"`
+	Metadata         *VideoMetadata `json:"metadata"`
+	DurationMismatch bool           `json:"durationMismatch"`
+	Thumbnails       []ImageVariant `json:"thumbnails"`
+	Banners          []ImageVariant `json:"banners"`
+	Genres           []Genre        `json:"genres"`
+	Categories       []Category     `json:"categories"`
+	CastMembers      []CastMember   `json:"castMembers"`
+	IsActive         bool           `json:"isActive"`
+	CreatedAt        time.Time      `json:"createdAt"`
+	UpdatedAt        time.Time      `json:"updatedAt"`
+	DeletedAt        *time.Time     `json:"deletedAt"`
 }
 
 func NewVideo() Video {
 	return Video{}
 }
+
+// SetImages dispatches variants to the thumbnails and banners of the video
+func (v *Video) SetImages(variants []ImageVariant) {
+	v.Thumbnails = make([]ImageVariant, 0)
+	v.Banners = make([]ImageVariant, 0)
+	for _, variant := range variants {
+		switch variant.Kind {
+		case VideoFileKindThumb:
+			v.Thumbnails = append(v.Thumbnails, variant)
+		case VideoFileKindBanner:
+			v.Banners = append(v.Banners, variant)
+		}
+	}
+}
+
+// File returns the storage path of the given kind, or an empty string
+// when the video has no file of that kind
+func (v Video) File(kind VideoFileKind) string {
+	var file *string
+	switch kind {
+	case VideoFileKindVideo:
+		file = v.VideoFile
+	case VideoFileKindTrailer:
+		file = v.TrailerFile
+	case VideoFileKindThumb:
+		file = v.ThumbFile
+	case VideoFileKindBanner:
+		file = v.BannerFile
+	}
+	if file == nil {
+		return ""
+	}
+	return *file
+}
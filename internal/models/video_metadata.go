@@ -0,0 +1,50 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math"
+)
+
+// durationTolerance is how far, in seconds, the declared duration may be
+// from the file one, the declared duration is in whole minutes
+const durationTolerance = 60
+
+// VideoMetadata is the technical metadata extracted from the video file, it
+// is persisted as a JSON object
+type VideoMetadata struct {
+	DurationSeconds float64 `json:"durationSeconds"`
+	Width           int     `json:"width"`
+	Height          int     `json:"height"`
+	Codec           string  `json:"codec"`
+}
+
+func (m VideoMetadata) Value() (driver.Value, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (m *VideoMetadata) Scan(src interface{}) error {
+	var data []byte
+	switch value := src.(type) {
+	case []byte:
+		data = value
+	case string:
+		data = []byte(value)
+	default:
+		return errors.New("models: unsupported video metadata type")
+	}
+	return json.Unmarshal(data, m)
+}
+
+// SetMetadata stores the metadata of the video file and flags the video
+// when its declared duration does not match the file
+func (v *Video) SetMetadata(metadata VideoMetadata) {
+	v.Metadata = &metadata
+	declared := float64(v.Duration * 60)
+	v.DurationMismatch = math.Abs(declared-metadata.DurationSeconds) >= durationTolerance
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVideo_SetMetadata(t *testing.T) {
+	testCases := []struct {
+		name     string
+		declared int
+		seconds  float64
+		mismatch bool
+	}{
+		{name: "Should accept a duration rounded to the minute", declared: 90, seconds: 5395.5, mismatch: false},
+		{name: "Should flag a duration off by more than a minute", declared: 90, seconds: 5200, mismatch: true},
+		{name: "Should flag a video without declared duration", declared: 0, seconds: 120, mismatch: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			video := Video{Duration: tc.declared}
+			video.SetMetadata(VideoMetadata{DurationSeconds: tc.seconds, Width: 1920, Height: 1080, Codec: "avc1"})
+			require.Equal(t, tc.mismatch, video.DurationMismatch)
+			require.Equal(t, tc.seconds, video.Metadata.DurationSeconds)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrIllegalTransition = errors.New("video: illegal status transition")
+
+type VideoStatus string
+
+const (
+	VideoStatusPending    VideoStatus = "pending"
+	VideoStatusProcessing VideoStatus = "processing"
+	VideoStatusCompleted  VideoStatus = "completed"
+	VideoStatusFailed     VideoStatus = "failed"
+)
+
+// videoTransitions lists, for each status, the statuses a video may move to.
+// A completed encoding is final, a failed one can only be queued again.
+var videoTransitions = map[VideoStatus][]VideoStatus{
+	VideoStatusPending:    {VideoStatusProcessing, VideoStatusFailed},
+	VideoStatusProcessing: {VideoStatusCompleted, VideoStatusFailed},
+	VideoStatusFailed:     {VideoStatusPending},
+	VideoStatusCompleted:  {},
+}
+
+func VideoStatuses() []VideoStatus {
+	return []VideoStatus{
+		VideoStatusPending,
+		VideoStatusProcessing,
+		VideoStatusCompleted,
+		VideoStatusFailed,
+	}
+}
+
+func (s VideoStatus) IsValid() bool {
+	_, ok := videoTransitions[s]
+	return ok
+}
+
+func (s VideoStatus) CanTransitionTo(next VideoStatus) bool {
+	for _, allowed := range videoTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionTo moves the video to next, stamping the time of the transition.
+// The reason is only kept for failures and cleared by any other transition.
+func (v *Video) TransitionTo(next VideoStatus, reason string, at time.Time) error {
+	if !v.Status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %v to %v", ErrIllegalTransition, v.Status, next)
+	}
+	v.Status = next
+	v.StatusError = nil
+	switch next {
+	case VideoStatusPending:
+		v.ProcessingAt = nil
+		v.CompletedAt = nil
+		v.FailedAt = nil
+	case VideoStatusProcessing:
+		v.ProcessingAt = &at
+	case VideoStatusCompleted:
+		v.CompletedAt = &at
+	case VideoStatusFailed:
+		v.FailedAt = &at
+		v.StatusError = &reason
+	}
+	return nil
+}
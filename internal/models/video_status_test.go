@@ -0,0 +1,55 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVideoStatus_CanTransitionTo(t *testing.T) {
+	testCases := []struct {
+		from    VideoStatus
+		to      VideoStatus
+		allowed bool
+	}{
+		{VideoStatusPending, VideoStatusProcessing, true},
+		{VideoStatusPending, VideoStatusFailed, true},
+		{VideoStatusPending, VideoStatusCompleted, false},
+		{VideoStatusProcessing, VideoStatusCompleted, true},
+		{VideoStatusProcessing, VideoStatusFailed, true},
+		{VideoStatusProcessing, VideoStatusPending, false},
+		{VideoStatusFailed, VideoStatusPending, true},
+		{VideoStatusFailed, VideoStatusCompleted, false},
+		{VideoStatusCompleted, VideoStatusProcessing, false},
+		{VideoStatusCompleted, VideoStatusFailed, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.from)+" to "+string(tc.to), func(t *testing.T) {
+			require.Equal(t, tc.allowed, tc.from.CanTransitionTo(tc.to))
+		})
+	}
+}
+
+func TestVideo_TransitionTo(t *testing.T) {
+	at := time.Now().UTC()
+	video := Video{Status: VideoStatusPending}
+
+	require.NoError(t, video.TransitionTo(VideoStatusProcessing, "", at))
+	require.Equal(t, VideoStatusProcessing, video.Status)
+	require.Equal(t, &at, video.ProcessingAt)
+
+	require.NoError(t, video.TransitionTo(VideoStatusFailed, "codec not supported", at))
+	require.Equal(t, "codec not supported", *video.StatusError)
+	require.Equal(t, &at, video.FailedAt)
+
+	err := video.TransitionTo(VideoStatusCompleted, "", at)
+	require.ErrorIs(t, err, ErrIllegalTransition)
+	require.Equal(t, VideoStatusFailed, video.Status)
+
+	require.NoError(t, video.TransitionTo(VideoStatusPending, "", at))
+	require.Nil(t, video.StatusError)
+	require.Nil(t, video.ProcessingAt)
+	require.Nil(t, video.FailedAt)
+}
@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/metrics"
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/gofrs/uuid"
+)
+
+const apiKeyColumns = "id, name, prefix, array_to_string(scopes, ','), expires_at, last_used_at, revoked_at, created_by, created_at"
+
+type APIKeyDB interface {
+	Save(ctx context.Context, key models.APIKey, hash string) (models.APIKey, error)
+	GetAll(ctx context.Context) ([]models.APIKey, error)
+	GetByHash(ctx context.Context, hash string) (models.APIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
+	Touch(ctx context.Context, id uuid.UUID) error
+}
+
+type APIKeyRepository struct {
+	db  *sql.DB
+	log logger.Logger
+}
+
+func NewAPIKeyRepository(db *sql.DB, log logger.Logger) APIKeyRepository {
+	return APIKeyRepository{
+		db, log,
+	}
+}
+
+func (a *APIKeyRepository) saveIntoAPIKey(ctx context.Context, row RepoReader) (models.APIKey, error) {
+	log := logger.FromContext(ctx, a.log)
+	var key models.APIKey
+	var scopes string
+	err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Prefix,
+		&scopes,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+		&key.CreatedBy,
+		&key.CreatedAt)
+	if err != nil {
+		log.Error(err.Error())
+		return models.APIKey{}, err
+	}
+	key.Scopes = strings.Split(scopes, ",")
+	return key, nil
+}
+
+func (a *APIKeyRepository) Save(ctx context.Context, key models.APIKey, hash string) (models.APIKey, error) {
+	defer metrics.ObserveQuery("api_key", "Save")()
+	insertStatement := `INSERT INTO api_keys(name, prefix, key_hash, scopes, expires_at, created_by)
+		VALUES($1, $2, $3, string_to_array($4, ','), $5, $6)
+		RETURNING ` + apiKeyColumns
+	row := a.db.QueryRowContext(ctx, insertStatement,
+		key.Name, key.Prefix, hash, strings.Join(key.Scopes, ","), key.ExpiresAt, key.CreatedBy)
+	saved, err := a.saveIntoAPIKey(ctx, row)
+	if err != nil {
+		return models.APIKey{}, ErrOnSave
+	}
+	return saved, nil
+}
+
+func (a *APIKeyRepository) GetAll(ctx context.Context) ([]models.APIKey, error) {
+	defer metrics.ObserveQuery("api_key", "GetAll")()
+	log := logger.FromContext(ctx, a.log)
+	var keys []models.APIKey
+	query := "SELECT " + apiKeyColumns + " FROM api_keys ORDER BY created_at DESC"
+	rows, err := a.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Error(err.Error())
+		return []models.APIKey{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		key, err := a.saveIntoAPIKey(ctx, rows)
+		if err != nil {
+			return []models.APIKey{}, err
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err.Error())
+		return []models.APIKey{}, err
+	}
+	if len(keys) == 0 {
+		return make([]models.APIKey, 0), nil
+	}
+	return keys, nil
+}
+
+func (a *APIKeyRepository) GetByHash(ctx context.Context, hash string) (models.APIKey, error) {
+	defer metrics.ObserveQuery("api_key", "GetByHash")()
+	query := "SELECT " + apiKeyColumns + " FROM api_keys WHERE key_hash=$1"
+	row := a.db.QueryRowContext(ctx, query, hash)
+	key, err := a.saveIntoAPIKey(ctx, row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.APIKey{}, ErrNoResult
+		}
+		return models.APIKey{}, err
+	}
+	return key, nil
+}
+
+// Revoke marks the key revoked, revoking it twice gives ErrNoResult
+func (a *APIKeyRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	defer metrics.ObserveQuery("api_key", "Revoke")()
+	log := logger.FromContext(ctx, a.log)
+	query := "UPDATE api_keys SET revoked_at=(NOW()) WHERE id=$1 AND revoked_at IS NULL"
+	exec, err := a.db.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Error(err.Error())
+		return ErrOnUpdate
+	}
+	affected, err := exec.RowsAffected()
+	if err != nil {
+		log.Error(err.Error())
+		return ErrOnUpdate
+	}
+	if affected == 0 {
+		return ErrNoResult
+	}
+	return nil
+}
+
+// Touch records the key was used, at most once a minute so busy clients do
+// not write on every request
+func (a *APIKeyRepository) Touch(ctx context.Context, id uuid.UUID) error {
+	defer metrics.ObserveQuery("api_key", "Touch")()
+	log := logger.FromContext(ctx, a.log)
+	query := `UPDATE api_keys SET last_used_at=(NOW())
+		WHERE id=$1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')`
+	if _, err := a.db.ExecContext(ctx, query, id); err != nil {
+		log.Error(err.Error())
+		return ErrOnUpdate
+	}
+	return nil
+}
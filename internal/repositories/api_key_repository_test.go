@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	mock_logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger/mocks"
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyRepository_GetByHash(t *testing.T) {
+	hash := "ab12"
+	id := uuid.Must(uuid.NewV4())
+	query := regexp.QuoteMeta("SELECT " + apiKeyColumns + " FROM api_keys WHERE key_hash=$1")
+	columns := []string{"id", "name", "prefix", "scopes", "expires_at", "last_used_at", "revoked_at", "created_by", "created_at"}
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Return the key with its scopes",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				rows := sqlmock.NewRows(columns).
+					AddRow(id, "ingest", "vck_abcdefgh", "catalog:read,media:upload", nil, nil, nil, "admin-1", time.Now())
+				mock.ExpectQuery(query).WithArgs(hash).WillReturnRows(rows)
+				SUT := NewAPIKeyRepository(db, log)
+				key, err := SUT.GetByHash(context.Background(), hash)
+				require.NoError(t, err)
+				require.Equal(t, id, key.ID)
+				require.Equal(t, []string{"catalog:read", "media:upload"}, key.Scopes)
+				require.Nil(t, key.ExpiresAt)
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+		{
+			name: "Return ErrNoResult when hash is unknown",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				log.EXPECT().Error(gomock.Any()).Times(1)
+				mock.ExpectQuery(query).WithArgs(hash).WillReturnError(sql.ErrNoRows)
+				SUT := NewAPIKeyRepository(db, log)
+				_, err := SUT.GetByHash(context.Background(), hash)
+				require.ErrorIs(t, err, ErrNoResult)
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			tc.testCase(t, db, mock, ctrl)
+		})
+	}
+}
+
+func TestAPIKeyRepository_Revoke(t *testing.T) {
+	id := uuid.Must(uuid.NewV4())
+	query := regexp.QuoteMeta("UPDATE api_keys SET revoked_at=(NOW()) WHERE id=$1 AND revoked_at IS NULL")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec(query).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 0))
+	SUT := NewAPIKeyRepository(db, mock_logger.NewMockLogger(ctrl))
+	require.NoError(t, SUT.Revoke(context.Background(), id))
+	require.ErrorIs(t, SUT.Revoke(context.Background(), id), ErrNoResult)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
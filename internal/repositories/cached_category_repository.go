@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/cache"
+	"github.com/gofrs/uuid"
+)
+
+const categoriesCacheKey = "category:list"
+
+func categoryCacheKey(id uuid.UUID) string {
+	return "category:" + id.String()
+}
+
+// CachedCategoryRepository reads categories through the cache and
+// invalidates them on every write
+type CachedCategoryRepository struct {
+	Category
+	cache *cache.ReadThrough
+}
+
+func NewCachedCategoryRepository(category Category, cache *cache.ReadThrough) CachedCategoryRepository {
+	return CachedCategoryRepository{
+		Category: category,
+		cache:    cache,
+	}
+}
+
+func (c *CachedCategoryRepository) GetCategories(ctx context.Context) ([]models.Category, error) {
+	var categories []models.Category
+	err := c.cache.Load(ctx, categoriesCacheKey, &categories, func(ctx context.Context) (interface{}, error) {
+		return c.Category.GetCategories(ctx)
+	})
+	return categories, err
+}
+
+func (c *CachedCategoryRepository) GetByID(ctx context.Context, id uuid.UUID) (models.Category, error) {
+	var category models.Category
+	err := c.cache.Load(ctx, categoryCacheKey(id), &category, func(ctx context.Context) (interface{}, error) {
+		return c.Category.GetByID(ctx, id)
+	})
+	return category, err
+}
+
+func (c *CachedCategoryRepository) Save(ctx context.Context, name string, description string) (models.Category, error) {
+	category, err := c.Category.Save(ctx, name, description)
+	if err == nil {
+		_ = c.cache.Invalidate(categoriesCacheKey)
+	}
+	return category, err
+}
+
+// Update also drops cached genres as they embed their categories
+func (c *CachedCategoryRepository) Update(ctx context.Context, id uuid.UUID, fields []string, values ...interface{}) error {
+	err := c.Category.Update(ctx, id, fields, values...)
+	if err == nil {
+		_ = c.cache.Invalidate(categoryCacheKey(id), categoriesCacheKey, "genre:*")
+	}
+	return err
+}
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	mock_repositories "github.com/ayrtonsato/video-catalog-golang/internal/repositories/mocks"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/cache"
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedCategoryRepository(t *testing.T) {
+	id := uuid.Must(uuid.NewV4())
+	category := models.Category{Id: id, Name: "Drama", IsActive: true, CreatedAt: time.Unix(1600000000, 0).UTC()}
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, ctrl *gomock.Controller, readThrough *cache.ReadThrough)
+	}{
+		{
+			name: "Should read the database once",
+			testCase: func(t *testing.T, ctrl *gomock.Controller, readThrough *cache.ReadThrough) {
+				repo := mock_repositories.NewMockCategory(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), id).Times(1).Return(category, nil)
+				SUT := NewCachedCategoryRepository(repo, readThrough)
+				for i := 0; i < 3; i++ {
+					got, err := SUT.GetByID(context.Background(), id)
+					require.NoError(t, err)
+					require.Equal(t, category, got)
+				}
+			},
+		},
+		{
+			name: "Should not cache missing categories",
+			testCase: func(t *testing.T, ctrl *gomock.Controller, readThrough *cache.ReadThrough) {
+				repo := mock_repositories.NewMockCategory(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), id).Times(2).Return(models.Category{}, ErrNoResult)
+				SUT := NewCachedCategoryRepository(repo, readThrough)
+				_, err := SUT.GetByID(context.Background(), id)
+				require.ErrorIs(t, err, ErrNoResult)
+				_, err = SUT.GetByID(context.Background(), id)
+				require.ErrorIs(t, err, ErrNoResult)
+			},
+		},
+		{
+			name: "Should read again after an update",
+			testCase: func(t *testing.T, ctrl *gomock.Controller, readThrough *cache.ReadThrough) {
+				repo := mock_repositories.NewMockCategory(ctrl)
+				updated := category
+				updated.Name = "Comedy"
+				gomock.InOrder(
+					repo.EXPECT().GetCategories(gomock.Any()).Times(1).Return([]models.Category{category}, nil),
+					repo.EXPECT().Update(gomock.Any(), id, []string{"name"}, "Comedy").Times(1).Return(nil),
+					repo.EXPECT().GetCategories(gomock.Any()).Times(1).Return([]models.Category{updated}, nil),
+				)
+				SUT := NewCachedCategoryRepository(repo, readThrough)
+				got, err := SUT.GetCategories(context.Background())
+				require.NoError(t, err)
+				require.Equal(t, "Drama", got[0].Name)
+				require.NoError(t, SUT.Update(context.Background(), id, []string{"name"}, "Comedy"))
+				got, err = SUT.GetCategories(context.Background())
+				require.NoError(t, err)
+				require.Equal(t, "Comedy", got[0].Name)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			readThrough, err := cache.NewReadThrough(cache.NewLRU(100), nil, time.Minute, time.Second)
+			require.NoError(t, err)
+			tc.testCase(t, ctrl, readThrough)
+		})
+	}
+}
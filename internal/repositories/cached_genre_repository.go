@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/cache"
+	"github.com/gofrs/uuid"
+)
+
+const genresCacheKey = "genre:list"
+
+func genreCacheKey(id uuid.UUID) string {
+	return "genre:" + id.String()
+}
+
+func genreWithCategoriesCacheKey(id uuid.UUID) string {
+	return "genre:" + id.String() + ":categories"
+}
+
+// CachedGenreRepository reads genres through the cache and invalidates them
+// on every write
+type CachedGenreRepository struct {
+	GenreDB
+	cache *cache.ReadThrough
+}
+
+func NewCachedGenreRepository(genre GenreDB, cache *cache.ReadThrough) CachedGenreRepository {
+	return CachedGenreRepository{
+		GenreDB: genre,
+		cache:   cache,
+	}
+}
+
+func (c *CachedGenreRepository) GetGenres(ctx context.Context) ([]models.Genre, error) {
+	var genres []models.Genre
+	err := c.cache.Load(ctx, genresCacheKey, &genres, func(ctx context.Context) (interface{}, error) {
+		return c.GenreDB.GetGenres(ctx)
+	})
+	return genres, err
+}
+
+func (c *CachedGenreRepository) GetByID(ctx context.Context, id uuid.UUID) (models.Genre, error) {
+	var genre models.Genre
+	err := c.cache.Load(ctx, genreCacheKey(id), &genre, func(ctx context.Context) (interface{}, error) {
+		return c.GenreDB.GetByID(ctx, id)
+	})
+	return genre, err
+}
+
+func (c *CachedGenreRepository) GetGenreByIDWithCategories(ctx context.Context, id uuid.UUID) (models.Genre, error) {
+	var genre models.Genre
+	err := c.cache.Load(ctx, genreWithCategoriesCacheKey(id), &genre, func(ctx context.Context) (interface{}, error) {
+		return c.GenreDB.GetGenreByIDWithCategories(ctx, id)
+	})
+	return genre, err
+}
+
+func (c *CachedGenreRepository) Save(ctx context.Context, name string, categories []uuid.UUID) (models.Genre, error) {
+	genre, err := c.GenreDB.Save(ctx, name, categories)
+	if err == nil {
+		_ = c.cache.Invalidate(genresCacheKey)
+	}
+	return genre, err
+}
+
+func (c *CachedGenreRepository) Update(ctx context.Context, id uuid.UUID, fields []string, values ...interface{}) error {
+	err := c.GenreDB.Update(ctx, id, fields, values...)
+	if err == nil {
+		_ = c.cache.Invalidate(genresCacheKey, genreCacheKey(id), genreWithCategoriesCacheKey(id))
+	}
+	return err
+}
+
+func (c *CachedGenreRepository) Delete(ctx context.Context, genre models.Genre) error {
+	err := c.GenreDB.Delete(ctx, genre)
+	if err == nil {
+		_ = c.cache.Invalidate(genresCacheKey, genreCacheKey(genre.ID), genreWithCategoriesCacheKey(genre.ID))
+	}
+	return err
+}
@@ -4,16 +4,17 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"github.com/ayrtonsato/video-catalog-golang/internal/metrics"
 	"github.com/ayrtonsato/video-catalog-golang/internal/models"
 	logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger"
 	"github.com/gofrs/uuid"
 )
 
 type Category interface {
-	GetCategories() ([]models.Category, error)
-	Save(name string, description string) (models.Category, error)
-	Update(id uuid.UUID, fields []string, values ...interface{}) error
-	GetByID(id uuid.UUID) (models.Category, error)
+	GetCategories(ctx context.Context) ([]models.Category, error)
+	Save(ctx context.Context, name string, description string) (models.Category, error)
+	Update(ctx context.Context, id uuid.UUID, fields []string, values ...interface{}) error
+	GetByID(ctx context.Context, id uuid.UUID) (models.Category, error)
 }
 
 type CategoryRepository struct {
@@ -27,7 +28,8 @@ func NewCategoryRepository(db *sql.DB, log logger.Logger) CategoryRepository {
 	}
 }
 
-func (c *CategoryRepository) saveIntoCategory(row RepoReader) (models.Category, error) {
+func (c *CategoryRepository) saveIntoCategory(ctx context.Context, row RepoReader) (models.Category, error) {
+	log := logger.FromContext(ctx, c.log)
 	var category models.Category
 	err := row.Scan(
 		&category.Id,
@@ -38,31 +40,33 @@ func (c *CategoryRepository) saveIntoCategory(row RepoReader) (models.Category,
 		&category.UpdatedAt,
 		&category.DeletedAt)
 	if err != nil {
-		c.log.Error(err.Error())
+		log.Error(err.Error())
 		return models.Category{}, err
 	}
 	return category, nil
 }
 
-func (c *CategoryRepository) GetCategories() ([]models.Category, error) {
+func (c *CategoryRepository) GetCategories(ctx context.Context) ([]models.Category, error) {
+	defer metrics.ObserveQuery("category", "GetCategories")()
+	log := logger.FromContext(ctx, c.log)
 	var categories []models.Category
 	rows, err := c.db.QueryContext(
-		context.Background(), "SELECT id, name, description, is_active, created_at, updated_at, deleted_at FROM categories",
+		ctx, "SELECT id, name, description, is_active, created_at, updated_at, deleted_at FROM categories",
 	)
 	if err != nil {
-		c.log.Error(err.Error())
+		log.Error(err.Error())
 		return []models.Category{}, err
 	}
 	defer rows.Close()
 	for rows.Next() {
-		newCategory, err := c.saveIntoCategory(rows)
+		newCategory, err := c.saveIntoCategory(ctx, rows)
 		if err != nil {
 			return []models.Category{}, err
 		}
 		categories = append(categories, newCategory)
 	}
 	if err := rows.Err(); err != nil {
-		c.log.Error(err.Error())
+		log.Error(err.Error())
 		return []models.Category{}, err
 	}
 	if len(categories) == 0 {
@@ -71,41 +75,45 @@ func (c *CategoryRepository) GetCategories() ([]models.Category, error) {
 	return categories, nil
 }
 
-func (c *CategoryRepository) Save(name string, description string) (models.Category, error) {
+func (c *CategoryRepository) Save(ctx context.Context, name string, description string) (models.Category, error) {
+	defer metrics.ObserveQuery("category", "Save")()
+	log := logger.FromContext(ctx, c.log)
 	insertStatement := `INSERT INTO categories(name, description)
 		VALUES($1, $2)
 		RETURNING id, name, description, is_active, created_at, updated_at, deleted_at
 	`
-	stmt, err := c.db.Prepare(insertStatement)
+	stmt, err := c.db.PrepareContext(ctx, insertStatement)
 	if err != nil {
-		c.log.Error(err.Error())
+		log.Error(err.Error())
 		return models.Category{}, err
 	}
 	defer stmt.Close()
-	row := stmt.QueryRow(name, description)
-	return c.saveIntoCategory(row)
+	row := stmt.QueryRowContext(ctx, name, description)
+	return c.saveIntoCategory(ctx, row)
 }
 
-func (c *CategoryRepository) Update(id uuid.UUID, fields []string, values ...interface{}) error {
+func (c *CategoryRepository) Update(ctx context.Context, id uuid.UUID, fields []string, values ...interface{}) error {
+	defer metrics.ObserveQuery("category", "Update")()
+	log := logger.FromContext(ctx, c.log)
 	updateStmt, err := DynamicUpdateQuery("categories", fields)
 	if err != nil {
-		c.log.Error(err.Error())
+		log.Error(err.Error())
 	}
-	stmt, err := c.db.Prepare(updateStmt)
+	stmt, err := c.db.PrepareContext(ctx, updateStmt)
 	if err != nil {
-		c.log.Error(err.Error())
+		log.Error(err.Error())
 		return err
 	}
 	defer stmt.Close()
 	values = append(values, id)
-	exec, err := stmt.Exec(values...)
+	exec, err := stmt.ExecContext(ctx, values...)
 	if err != nil {
-		c.log.Error(err.Error())
+		log.Error(err.Error())
 		return err
 	}
 	affected, err := exec.RowsAffected()
 	if err != nil {
-		c.log.Error(err.Error())
+		log.Error(err.Error())
 		return err
 	}
 	if affected > 0 {
@@ -114,10 +122,11 @@ func (c *CategoryRepository) Update(id uuid.UUID, fields []string, values ...int
 	return errors.New("repository: failed to update row")
 }
 
-func (c *CategoryRepository) GetByID(id uuid.UUID) (models.Category, error) {
+func (c *CategoryRepository) GetByID(ctx context.Context, id uuid.UUID) (models.Category, error) {
+	defer metrics.ObserveQuery("category", "GetByID")()
 	query := "SELECT * FROM categories WHERE id=$1"
-	row := c.db.QueryRow(query, id)
-	category, err := c.saveIntoCategory(row)
+	row := c.db.QueryRowContext(ctx, query, id)
+	category, err := c.saveIntoCategory(ctx, row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return models.Category{}, ErrNoResult
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	_ "github.com/jackc/pgx/v4/stdlib"
 	"regexp"
@@ -58,7 +59,7 @@ func TestCategoryRepository_GetCategories(t *testing.T) {
 							true,
 							fakeCategory.CreatedAt,
 							fakeCategory.UpdatedAt, nil))
-				list, err := SUT.GetCategories()
+				list, err := SUT.GetCategories(context.Background())
 				require.NoError(t, err)
 				require.True(t, cmp.Equal(list, fakeListCategory))
 
@@ -81,7 +82,7 @@ func TestCategoryRepository_GetCategories(t *testing.T) {
 				mock.ExpectQuery(re).
 					WillReturnError(sql.ErrNoRows)
 				log.EXPECT().Error(gomock.Any()).Times(1)
-				list, err := SUT.GetCategories()
+				list, err := SUT.GetCategories(context.Background())
 				require.Error(t, err)
 				require.ErrorIs(t, err, sql.ErrNoRows)
 
@@ -149,7 +150,7 @@ func TestCategoryRepository_Save(t *testing.T) {
 					ExpectQuery().
 					WithArgs("valid_name", "valid_description").
 					WillReturnRows(rows)
-				category, err := SUT.Save("valid_name", "valid_description")
+				category, err := SUT.Save(context.Background(), "valid_name", "valid_description")
 
 				require.NoError(t, err)
 				require.Equal(t, category, fakeCategory)
@@ -173,7 +174,7 @@ func TestCategoryRepository_Save(t *testing.T) {
 					WithArgs("invalid_name", "invalid_description").
 					WillReturnError(sql.ErrNoRows)
 				log.EXPECT().Error(gomock.Any()).Times(1)
-				category, err := SUT.Save("invalid_name", "invalid_description")
+				category, err := SUT.Save(context.Background(), "invalid_name", "invalid_description")
 				require.Error(t, err)
 				require.ErrorIs(t, err, sql.ErrNoRows)
 				require.Equal(t, category, models.Category{})
@@ -218,7 +219,7 @@ func TestCategoryRepository_Update(t *testing.T) {
 				log.EXPECT().Error(gomock.Any()).Times(0)
 				fields := []string{"name", "description"}
 				values := []interface{}{"other_name", "other_description"}
-				err = SUT.Update(newUUID, fields, values...)
+				err = SUT.Update(context.Background(), newUUID, fields, values...)
 				require.NoError(t, err)
 			},
 		},
@@ -240,7 +241,7 @@ func TestCategoryRepository_Update(t *testing.T) {
 				log.EXPECT().Error(gomock.Eq(sql.ErrConnDone.Error())).Times(1)
 				fields := []string{"name", "description"}
 				values := []interface{}{"other_name", "other_description"}
-				err = SUT.Update(newUUID, fields, values...)
+				err = SUT.Update(context.Background(), newUUID, fields, values...)
 				require.Error(t, err)
 				require.ErrorIs(t, err, sql.ErrConnDone)
 			},
@@ -294,7 +295,7 @@ func TestCategoryRepository_GetByID(t *testing.T) {
 				re := regexp.QuoteMeta("SELECT * FROM categories WHERE id=$1")
 				mock.ExpectQuery(re).
 					WithArgs(newUUID).WillReturnRows(fields)
-				category, err := SUT.GetByID(newUUID)
+				category, err := SUT.GetByID(context.Background(), newUUID)
 				require.NoError(t, err)
 				require.Equal(t, category, fakeCategory)
 			},
@@ -313,7 +314,7 @@ func TestCategoryRepository_GetByID(t *testing.T) {
 					WithArgs(newUUID).
 					WillReturnError(sql.ErrNoRows)
 				log.EXPECT().Error(gomock.Eq(sql.ErrNoRows.Error())).Times(1)
-				_, err := SUT.GetByID(newUUID)
+				_, err := SUT.GetByID(context.Background(), newUUID)
 				require.Error(t, err)
 				require.ErrorIs(t, err, ErrNoResult)
 			},
@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/ayrtonsato/video-catalog-golang/internal/metrics"
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/gofrs/uuid"
+)
+
+type DeadLetterDB interface {
+	Save(ctx context.Context, source string, payload []byte, reason string) (models.DeadLetter, error)
+	GetDeadLetters(ctx context.Context, source string) ([]models.DeadLetter, error)
+	GetByID(ctx context.Context, id uuid.UUID) (models.DeadLetter, error)
+	MarkReplayed(ctx context.Context, id uuid.UUID) error
+}
+
+type DeadLetterRepository struct {
+	db  *sql.DB
+	log logger.Logger
+}
+
+func NewDeadLetterRepository(db *sql.DB, log logger.Logger) DeadLetterRepository {
+	return DeadLetterRepository{
+		db, log,
+	}
+}
+
+func (d *DeadLetterRepository) saveIntoDeadLetter(ctx context.Context, row RepoReader) (models.DeadLetter, error) {
+	log := logger.FromContext(ctx, d.log)
+	var deadLetter models.DeadLetter
+	var payload []byte
+	err := row.Scan(
+		&deadLetter.ID,
+		&deadLetter.Source,
+		&payload,
+		&deadLetter.Reason,
+		&deadLetter.CreatedAt,
+		&deadLetter.ReplayedAt)
+	if err != nil {
+		log.Error(err.Error())
+		return models.DeadLetter{}, err
+	}
+	deadLetter.Payload = string(payload)
+	return deadLetter, nil
+}
+
+func (d *DeadLetterRepository) Save(ctx context.Context, source string, payload []byte, reason string) (models.DeadLetter, error) {
+	defer metrics.ObserveQuery("dead_letter", "Save")()
+	log := logger.FromContext(ctx, d.log)
+	insertStatement := `INSERT INTO dead_letters(source, payload, reason)
+		VALUES($1, $2, $3)
+		RETURNING id, source, payload, reason, created_at, replayed_at
+	`
+	stmt, err := d.db.PrepareContext(ctx, insertStatement)
+	if err != nil {
+		log.Error(err.Error())
+		return models.DeadLetter{}, ErrOnSave
+	}
+	defer stmt.Close()
+	row := stmt.QueryRowContext(ctx, source, payload, reason)
+	deadLetter, err := d.saveIntoDeadLetter(ctx, row)
+	if err != nil {
+		return models.DeadLetter{}, ErrOnSave
+	}
+	return deadLetter, nil
+}
+
+// GetDeadLetters lists the messages not replayed yet, filtered by source
+// unless it is empty
+func (d *DeadLetterRepository) GetDeadLetters(ctx context.Context, source string) ([]models.DeadLetter, error) {
+	defer metrics.ObserveQuery("dead_letter", "GetDeadLetters")()
+	log := logger.FromContext(ctx, d.log)
+	var deadLetters []models.DeadLetter
+	query := "SELECT id, source, payload, reason, created_at, replayed_at FROM dead_letters WHERE replayed_at IS NULL"
+	args := make([]interface{}, 0, 1)
+	if source != "" {
+		query = query + " AND source=$1"
+		args = append(args, source)
+	}
+	query = query + " ORDER BY created_at"
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err.Error())
+		return []models.DeadLetter{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		deadLetter, err := d.saveIntoDeadLetter(ctx, rows)
+		if err != nil {
+			return []models.DeadLetter{}, err
+		}
+		deadLetters = append(deadLetters, deadLetter)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err.Error())
+		return []models.DeadLetter{}, err
+	}
+	if len(deadLetters) == 0 {
+		return make([]models.DeadLetter, 0), nil
+	}
+	return deadLetters, nil
+}
+
+func (d *DeadLetterRepository) GetByID(ctx context.Context, id uuid.UUID) (models.DeadLetter, error) {
+	defer metrics.ObserveQuery("dead_letter", "GetByID")()
+	query := "SELECT id, source, payload, reason, created_at, replayed_at FROM dead_letters WHERE id=$1"
+	row := d.db.QueryRowContext(ctx, query, id)
+	deadLetter, err := d.saveIntoDeadLetter(ctx, row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.DeadLetter{}, ErrNoResult
+		}
+		return models.DeadLetter{}, err
+	}
+	return deadLetter, nil
+}
+
+func (d *DeadLetterRepository) MarkReplayed(ctx context.Context, id uuid.UUID) error {
+	defer metrics.ObserveQuery("dead_letter", "MarkReplayed")()
+	log := logger.FromContext(ctx, d.log)
+	query := "UPDATE dead_letters SET replayed_at=(NOW()) WHERE id=$1 AND replayed_at IS NULL"
+	exec, err := d.db.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Error(err.Error())
+		return ErrOnUpdate
+	}
+	affected, err := exec.RowsAffected()
+	if err != nil {
+		log.Error(err.Error())
+		return ErrOnUpdate
+	}
+	if affected == 0 {
+		return ErrStaleObject
+	}
+	return nil
+}
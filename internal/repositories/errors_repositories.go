@@ -7,4 +7,7 @@ var (
 	ErrOnSave   = errors.New("sql: error to save object")
 	ErrOnUpdate = errors.New("sql: failed to update object")
 	ErrOnDelete = errors.New("sql: failed to delete object")
+
+	ErrStaleObject      = errors.New("sql: object was modified concurrently")
+	ErrDuplicateMessage = errors.New("sql: message already processed")
 )
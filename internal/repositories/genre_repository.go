@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"github.com/ayrtonsato/video-catalog-golang/internal/metrics"
 	"github.com/ayrtonsato/video-catalog-golang/internal/models"
 	logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger"
 	"github.com/gofrs/uuid"
@@ -16,12 +17,12 @@ type GenreWithCategories struct {
 }
 
 type GenreDB interface {
-	GetGenres() ([]models.Genre, error)
-	GetByID(id uuid.UUID) (models.Genre, error)
-	GetGenreByIDWithCategories(id uuid.UUID) (models.Genre, error)
-	Save(name string, categories []uuid.UUID) (models.Genre, error)
-	Update(id uuid.UUID, fields []string, values ...interface{}) error
-	Delete(genre models.Genre) error
+	GetGenres(ctx context.Context) ([]models.Genre, error)
+	GetByID(ctx context.Context, id uuid.UUID) (models.Genre, error)
+	GetGenreByIDWithCategories(ctx context.Context, id uuid.UUID) (models.Genre, error)
+	Save(ctx context.Context, name string, categories []uuid.UUID) (models.Genre, error)
+	Update(ctx context.Context, id uuid.UUID, fields []string, values ...interface{}) error
+	Delete(ctx context.Context, genre models.Genre) error
 }
 
 type GenreRepository struct {
@@ -35,7 +36,8 @@ func NewGenreRepository(db *sql.DB, log logger.Logger) GenreRepository {
 	}
 }
 
-func (g *GenreRepository) saveIntoGenres(row RepoReader) (models.Genre, error) {
+func (g *GenreRepository) saveIntoGenres(ctx context.Context, row RepoReader) (models.Genre, error) {
+	log := logger.FromContext(ctx, g.log)
 	var genre models.Genre
 	err := row.Scan(
 		&genre.ID,
@@ -45,29 +47,31 @@ func (g *GenreRepository) saveIntoGenres(row RepoReader) (models.Genre, error) {
 		&genre.UpdatedAt,
 		&genre.DeletedAt)
 	if err != nil {
-		g.log.Error(err.Error())
+		log.Error(err.Error())
 		return models.Genre{}, err
 	}
 	return genre, nil
 }
 
-func (g *GenreRepository) GetGenres() ([]models.Genre, error) {
+func (g *GenreRepository) GetGenres(ctx context.Context) ([]models.Genre, error) {
+	defer metrics.ObserveQuery("genre", "GetGenres")()
+	log := logger.FromContext(ctx, g.log)
 	var genres []models.Genre
 	rows, err := g.db.QueryContext(
-		context.Background(),
+		ctx,
 		"SELECT id, name, is_active, created_at, updated_at, deleted_at FROM genres WHERE is_active=false",
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			g.log.Error(err.Error())
+			log.Error(err.Error())
 			return []models.Genre{}, ErrNoResult
 		}
-		g.log.Error(err.Error())
+		log.Error(err.Error())
 		return []models.Genre{}, err
 	}
 	defer rows.Close()
 	for rows.Next() {
-		newGenre, err := g.saveIntoGenres(rows)
+		newGenre, err := g.saveIntoGenres(ctx, rows)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
 				return []models.Genre{}, ErrNoResult
@@ -77,7 +81,7 @@ func (g *GenreRepository) GetGenres() ([]models.Genre, error) {
 		genres = append(genres, newGenre)
 	}
 	if err := rows.Err(); err != nil {
-		g.log.Error(err.Error())
+		log.Error(err.Error())
 		return []models.Genre{}, err
 	}
 	if len(genres) == 0 {
@@ -86,10 +90,11 @@ func (g *GenreRepository) GetGenres() ([]models.Genre, error) {
 	return genres, nil
 }
 
-func (g *GenreRepository) GetGenreByID(id uuid.UUID) (models.Genre, error) {
+func (g *GenreRepository) GetGenreByID(ctx context.Context, id uuid.UUID) (models.Genre, error) {
+	defer metrics.ObserveQuery("genre", "GetGenreByID")()
 	query := "SELECT * FROM genres WHERE id=$1 AND is_active=false"
-	row := g.db.QueryRow(query, id)
-	genre, err := g.saveIntoGenres(row)
+	row := g.db.QueryRowContext(ctx, query, id)
+	genre, err := g.saveIntoGenres(ctx, row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return models.Genre{}, ErrNoResult
@@ -99,7 +104,9 @@ func (g *GenreRepository) GetGenreByID(id uuid.UUID) (models.Genre, error) {
 	return genre, nil
 }
 
-func (g *GenreRepository) Save(name string, categories []uuid.UUID) (models.Genre, error) {
+func (g *GenreRepository) Save(ctx context.Context, name string, categories []uuid.UUID) (models.Genre, error) {
+	defer metrics.ObserveQuery("genre", "Save")()
+	log := logger.FromContext(ctx, g.log)
 	insertGenreStatement := `INSERT INTO genres(name)
 		VALUES($1)
 		RETURNING id, name, is_active, created_at, updated_at, deleted_at
@@ -107,63 +114,65 @@ func (g *GenreRepository) Save(name string, categories []uuid.UUID) (models.Genr
 	insertRelationStatement := `INSERT INTO categories_genres(category_id, genre_id)
 		VALUES($1, $2)
 	`
-	tx, err := g.db.BeginTx(context.Background(), nil)
+	tx, err := g.db.BeginTx(ctx, nil)
 	if err != nil {
 		return models.Genre{}, ErrOnSave
 	}
-	stmt, err := tx.Prepare(insertGenreStatement)
+	stmt, err := tx.PrepareContext(ctx, insertGenreStatement)
 	if err != nil {
-		TransactionRollback(tx, g.log, err)
+		TransactionRollback(tx, log, err)
 		return models.Genre{}, ErrOnSave
 	}
 	defer stmt.Close()
-	row := stmt.QueryRow(name)
-	genre, err := g.saveIntoGenres(row)
+	row := stmt.QueryRowContext(ctx, name)
+	genre, err := g.saveIntoGenres(ctx, row)
 	if err != nil {
-		TransactionRollback(tx, g.log, err)
+		TransactionRollback(tx, log, err)
 		return genre, ErrOnSave
 	}
 	for _, category := range categories {
-		stmtRelation, err := tx.Prepare(insertRelationStatement)
+		stmtRelation, err := tx.PrepareContext(ctx, insertRelationStatement)
 		if err != nil {
-			g.log.Error(err.Error())
-			TransactionRollback(tx, g.log, err)
+			log.Error(err.Error())
+			TransactionRollback(tx, log, err)
 			return models.Genre{}, ErrOnSave
 		}
 		defer stmtRelation.Close()
-		_, err = stmtRelation.Exec(category, genre.ID)
+		_, err = stmtRelation.ExecContext(ctx, category, genre.ID)
 		if err != nil {
-			g.log.Error(err.Error())
-			TransactionRollback(tx, g.log, err)
+			log.Error(err.Error())
+			TransactionRollback(tx, log, err)
 			return models.Genre{}, ErrOnSave
 		}
 	}
-	if errCommit := TransactionCommit(tx, g.log); errCommit != nil {
+	if errCommit := TransactionCommit(tx, log); errCommit != nil {
 		return models.Genre{}, ErrOnSave
 	}
 	return genre, nil
 }
 
-func (g *GenreRepository) Update(id uuid.UUID, fields []string, values ...interface{}) error {
+func (g *GenreRepository) Update(ctx context.Context, id uuid.UUID, fields []string, values ...interface{}) error {
+	defer metrics.ObserveQuery("genre", "Update")()
+	log := logger.FromContext(ctx, g.log)
 	updateStmt, err := DynamicUpdateQuery("genres", fields)
 	if err != nil {
-		g.log.Error(err.Error())
+		log.Error(err.Error())
 	}
-	stmt, err := g.db.Prepare(updateStmt)
+	stmt, err := g.db.PrepareContext(ctx, updateStmt)
 	if err != nil {
-		g.log.Error(err.Error())
+		log.Error(err.Error())
 		return ErrOnUpdate
 	}
 	defer stmt.Close()
 	values = append(values, id)
-	exec, err := stmt.Exec(values...)
+	exec, err := stmt.ExecContext(ctx, values...)
 	if err != nil {
-		g.log.Error(err.Error())
+		log.Error(err.Error())
 		return ErrOnUpdate
 	}
 	affected, err := exec.RowsAffected()
 	if err != nil {
-		g.log.Error(err.Error())
+		log.Error(err.Error())
 		return ErrOnUpdate
 	}
 	if affected > 0 {
@@ -172,43 +181,45 @@ func (g *GenreRepository) Update(id uuid.UUID, fields []string, values ...interf
 	return ErrOnUpdate
 }
 
-func (g *GenreRepository) Delete(genre models.Genre) error {
+func (g *GenreRepository) Delete(ctx context.Context, genre models.Genre) error {
+	defer metrics.ObserveQuery("genre", "Delete")()
+	log := logger.FromContext(ctx, g.log)
 	updateStmt, err := DynamicUpdateQuery("genres", []string{"is_active", "deleted_at"})
-	tx, err := g.db.BeginTx(context.Background(), nil)
+	tx, err := g.db.BeginTx(ctx, nil)
 	if err != nil {
-		g.log.Error(err.Error())
+		log.Error(err.Error())
 		return ErrOnDelete
 	}
 
 	// soft delete genre first
-	stmt, err := tx.Prepare(updateStmt)
+	stmt, err := tx.PrepareContext(ctx, updateStmt)
 	if err != nil {
-		g.log.Error(err.Error())
+		log.Error(err.Error())
 		return ErrOnUpdate
 	}
 	defer stmt.Close()
-	_, err = stmt.Exec(false, time.Now().UTC(), genre.ID)
+	_, err = stmt.ExecContext(ctx, false, time.Now().UTC(), genre.ID)
 	if err != nil {
-		g.log.Error(err.Error())
+		log.Error(err.Error())
 		return ErrOnDelete
 	}
 
 	// delete relationship btw category and genre
 	query := `DELETE FROM categories_genres WHERE genre_id=$1`
-	stmt, err = tx.Prepare(query)
+	stmt, err = tx.PrepareContext(ctx, query)
 	if err != nil {
-		TransactionRollback(tx, g.log, err)
+		TransactionRollback(tx, log, err)
 		return ErrOnDelete
 	}
 	defer stmt.Close()
 
-	_, err = stmt.Exec(genre.ID)
+	_, err = stmt.ExecContext(ctx, genre.ID)
 	if err != nil {
-		g.log.Error(err.Error())
-		TransactionRollback(tx, g.log, err)
+		log.Error(err.Error())
+		TransactionRollback(tx, log, err)
 		return ErrOnDelete
 	}
-	if errCommit := TransactionCommit(tx, g.log); errCommit != nil {
+	if errCommit := TransactionCommit(tx, log); errCommit != nil {
 		return ErrOnDelete
 	}
 	return nil
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/ayrtonsato/video-catalog-golang/internal/models"
@@ -54,7 +55,7 @@ func TestGenreRepository_GetGenres(t *testing.T) {
 							true,
 							fakeGenre.CreatedAt,
 							fakeGenre.UpdatedAt, nil))
-				list, err := SUT.GetGenres()
+				list, err := SUT.GetGenres(context.Background())
 				require.NoError(t, err)
 				require.True(t, cmp.Equal(list, fakeListGenre))
 
@@ -74,7 +75,7 @@ func TestGenreRepository_GetGenres(t *testing.T) {
 
 				mock.ExpectQuery(re).
 					WillReturnError(sql.ErrNoRows)
-				_, err := SUT.GetGenres()
+				_, err := SUT.GetGenres(context.Background())
 				require.Error(t, err)
 				require.ErrorIs(t, err, ErrNoResult)
 
@@ -129,7 +130,7 @@ func TestGenreRepository_GetGenreByID(t *testing.T) {
 				re := regexp.QuoteMeta("SELECT * FROM genres WHERE id=$1")
 				mock.ExpectQuery(re).
 					WithArgs(fakeGenre.ID).WillReturnRows(fields)
-				genre, err := SUT.GetGenreByID(fakeGenre.ID)
+				genre, err := SUT.GetGenreByID(context.Background(), fakeGenre.ID)
 				require.NoError(t, err)
 				require.Equal(t, genre, fakeGenre)
 
@@ -147,7 +148,7 @@ func TestGenreRepository_GetGenreByID(t *testing.T) {
 				re := regexp.QuoteMeta("SELECT * FROM genres WHERE id=$1")
 				mock.ExpectQuery(re).
 					WillReturnError(sql.ErrNoRows)
-				_, err := SUT.GetGenreByID(fakeGenre.ID)
+				_, err := SUT.GetGenreByID(context.Background(), fakeGenre.ID)
 
 				require.Error(t, err)
 				require.ErrorIs(t, err, ErrNoResult)
@@ -213,7 +214,7 @@ func TestGenreRepository_Save(t *testing.T) {
 					WithArgs(catID[0], fakeGenre.ID).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 				mock.ExpectCommit()
-				genre, err := SUT.Save("valid_name", catID)
+				genre, err := SUT.Save(context.Background(), "valid_name", catID)
 				require.NoError(t, err)
 				require.Equal(t, genre, fakeGenre)
 				if err := mock.ExpectationsWereMet(); err != nil {
@@ -234,7 +235,7 @@ func TestGenreRepository_Save(t *testing.T) {
 					WithArgs(sqlmock.AnyArg()).
 					WillReturnError(sql.ErrNoRows)
 				mock.ExpectRollback()
-				_, err := SUT.Save("invalid_name", catID)
+				_, err := SUT.Save(context.Background(), "invalid_name", catID)
 				require.Error(t, err)
 				require.ErrorIs(t, err, ErrOnSave)
 				if err := mock.ExpectationsWereMet(); err != nil {
@@ -269,7 +270,7 @@ func TestGenreRepository_Save(t *testing.T) {
 					WillReturnError(sql.ErrNoRows)
 				mock.ExpectRollback()
 
-				_, err := SUT.Save("invalid_name", catID)
+				_, err := SUT.Save(context.Background(), "invalid_name", catID)
 				require.Error(t, err)
 				require.ErrorIs(t, err, ErrOnSave)
 				if err := mock.ExpectationsWereMet(); err != nil {
@@ -315,7 +316,7 @@ func TestGenreRepository_Update(t *testing.T) {
 				log.EXPECT().Error(gomock.Any()).Times(0)
 				fields := []string{"name"}
 				values := []interface{}{"other_name"}
-				err := SUT.Update(newUUID, fields, values...)
+				err := SUT.Update(context.Background(), newUUID, fields, values...)
 				require.NoError(t, err)
 			},
 		},
@@ -336,7 +337,7 @@ func TestGenreRepository_Update(t *testing.T) {
 
 				fields := []string{"name"}
 				values := []interface{}{"other_name"}
-				err := SUT.Update(newUUID, fields, values...)
+				err := SUT.Update(context.Background(), newUUID, fields, values...)
 				require.Error(t, err)
 				require.ErrorIs(t, err, ErrOnUpdate)
 			},
@@ -391,7 +392,7 @@ func TestGenreRepository_Delete(t *testing.T) {
 					WithArgs(fakeGenre.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
-				err := SUT.Delete(fakeGenre)
+				err := SUT.Delete(context.Background(), fakeGenre)
 				require.NoError(t, err)
 			},
 		},
@@ -417,7 +418,7 @@ func TestGenreRepository_Delete(t *testing.T) {
 					WithArgs(fakeGenre.ID).
 					WillReturnError(sql.ErrConnDone)
 				mock.ExpectRollback()
-				err := SUT.Delete(fakeGenre)
+				err := SUT.Delete(context.Background(), fakeGenre)
 				require.Error(t, err)
 				require.ErrorIs(t, err, ErrOnDelete)
 			},
@@ -438,7 +439,7 @@ func TestGenreRepository_Delete(t *testing.T) {
 					WithArgs(false, sqlmock.AnyArg(), fakeGenre.ID).
 					WillReturnError(sql.ErrConnDone)
 				mock.ExpectRollback()
-				err := SUT.Delete(fakeGenre)
+				err := SUT.Delete(context.Background(), fakeGenre)
 				require.Error(t, err)
 				require.ErrorIs(t, err, ErrOnDelete)
 			},
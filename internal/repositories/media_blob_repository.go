@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/metrics"
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+)
+
+const mediaBlobColumns = "hash, path, size, ref_count, created_at, updated_at"
+
+type MediaBlobDB interface {
+	Acquire(ctx context.Context, blob models.MediaBlob) (models.MediaBlob, error)
+	Release(ctx context.Context, path string) error
+}
+
+type MediaBlobRepository struct {
+	db  *sql.DB
+	log logger.Logger
+}
+
+func NewMediaBlobRepository(db *sql.DB, log logger.Logger) MediaBlobRepository {
+	return MediaBlobRepository{
+		db, log,
+	}
+}
+
+func (m *MediaBlobRepository) saveIntoMediaBlob(ctx context.Context, row RepoReader) (models.MediaBlob, error) {
+	log := logger.FromContext(ctx, m.log)
+	var blob models.MediaBlob
+	err := row.Scan(
+		&blob.Hash,
+		&blob.Path,
+		&blob.Size,
+		&blob.RefCount,
+		&blob.CreatedAt,
+		&blob.UpdatedAt)
+	if err != nil {
+		log.Error(err.Error())
+		return models.MediaBlob{}, err
+	}
+	return blob, nil
+}
+
+// Acquire records one more reference to the blob with the hash of blob in a
+// single statement, creating it on the first one. A returned RefCount of 1
+// means the row was created as rows are deleted with their last reference
+func (m *MediaBlobRepository) Acquire(ctx context.Context, blob models.MediaBlob) (models.MediaBlob, error) {
+	defer metrics.ObserveQuery("media_blob", "Acquire")()
+	query := `INSERT INTO media_blobs(hash, path, size, ref_count)
+		VALUES($1, $2, $3, 1)
+		ON CONFLICT (hash) DO UPDATE
+		SET ref_count=media_blobs.ref_count + 1, updated_at=(NOW())
+		RETURNING ` + mediaBlobColumns
+	row := m.db.QueryRowContext(ctx, query, blob.Hash, blob.Path, blob.Size)
+	acquired, err := m.saveIntoMediaBlob(ctx, row)
+	if err != nil {
+		return models.MediaBlob{}, ErrOnSave
+	}
+	return acquired, nil
+}
+
+// Release drops one reference to the blob stored at path, the row is removed
+// with the last one and the file is left to the media garbage collector
+func (m *MediaBlobRepository) Release(ctx context.Context, path string) error {
+	defer metrics.ObserveQuery("media_blob", "Release")()
+	log := logger.FromContext(ctx, m.log)
+	updateStatement := `UPDATE media_blobs SET ref_count=ref_count - 1, updated_at=(NOW())
+		WHERE path=$1 AND ref_count > 0`
+	deleteStatement := "DELETE FROM media_blobs WHERE path=$1 AND ref_count = 0"
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err.Error())
+		return ErrOnUpdate
+	}
+	exec, err := tx.ExecContext(ctx, updateStatement, path)
+	if err != nil {
+		log.Error(err.Error())
+		TransactionRollback(tx, log, err)
+		return ErrOnUpdate
+	}
+	if affected, err := exec.RowsAffected(); err != nil || affected == 0 {
+		TransactionRollback(tx, log, err)
+		if err != nil {
+			return ErrOnUpdate
+		}
+		return ErrNoResult
+	}
+	if _, err = tx.ExecContext(ctx, deleteStatement, path); err != nil {
+		log.Error(err.Error())
+		TransactionRollback(tx, log, err)
+		return ErrOnUpdate
+	}
+	if errCommit := TransactionCommit(tx, log); errCommit != nil {
+		return ErrOnUpdate
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	mock_logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMediaBlobRepository_Release(t *testing.T) {
+	path := "blobs/sha256/ab/cd/abcd.mp4"
+	updateBlob := regexp.QuoteMeta("UPDATE media_blobs SET ref_count=ref_count - 1")
+	deleteBlob := regexp.QuoteMeta("DELETE FROM media_blobs WHERE path=$1 AND ref_count = 0")
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Decrement and remove unreferenced blob in one transaction",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				mock.ExpectBegin()
+				mock.ExpectExec(updateBlob).WithArgs(path).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(deleteBlob).WithArgs(path).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				SUT := NewMediaBlobRepository(db, log)
+				require.NoError(t, SUT.Release(context.Background(), path))
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+		{
+			name: "Return ErrNoResult when blob is not referenced",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				mock.ExpectBegin()
+				mock.ExpectExec(updateBlob).WithArgs(path).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				SUT := NewMediaBlobRepository(db, log)
+				require.ErrorIs(t, SUT.Release(context.Background(), path), ErrNoResult)
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			tc.testCase(t, db, mock, ctrl)
+		})
+	}
+}
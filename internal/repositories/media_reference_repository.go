@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/metrics"
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+)
+
+type MediaReferenceDB interface {
+	GetReferences(ctx context.Context) (models.MediaReferences, error)
+}
+
+type MediaReferenceRepository struct {
+	db  *sql.DB
+	log logger.Logger
+}
+
+func NewMediaReferenceRepository(db *sql.DB, log logger.Logger) MediaReferenceRepository {
+	return MediaReferenceRepository{
+		db, log,
+	}
+}
+
+// GetReferences collects every file referenced by the catalog, soft deleted
+// videos included as they can still be restored. The encoder may list only
+// its manifests so the whole encoded directory of an encoded video is kept,
+// other files are only kept when their exact path is referenced
+func (m *MediaReferenceRepository) GetReferences(ctx context.Context) (models.MediaReferences, error) {
+	defer metrics.ObserveQuery("media_reference", "GetReferences")()
+	filesQuery := `SELECT file FROM (
+			SELECT video_file AS file FROM videos
+			UNION ALL SELECT trailer_file FROM videos
+			UNION ALL SELECT thumb_file FROM videos
+			UNION ALL SELECT banner_file FROM videos
+			UNION ALL SELECT path FROM video_images
+			UNION ALL SELECT path FROM video_subtitles
+			UNION ALL SELECT path FROM media_blobs
+		) refs WHERE file IS NOT NULL`
+	encodedQuery := "SELECT jsonb_array_elements_text(encoded_files) FROM videos"
+	encodedVideosQuery := "SELECT id::text FROM videos WHERE jsonb_array_length(encoded_files) > 0"
+
+	files, err := m.queryStrings(ctx, filesQuery)
+	if err != nil {
+		return models.MediaReferences{}, err
+	}
+	encoded, err := m.queryStrings(ctx, encodedQuery)
+	if err != nil {
+		return models.MediaReferences{}, err
+	}
+	videos, err := m.queryStrings(ctx, encodedVideosQuery)
+	if err != nil {
+		return models.MediaReferences{}, err
+	}
+	directories := make([]string, 0, len(videos))
+	for _, id := range videos {
+		directories = append(directories, models.EncodedDir(id))
+	}
+	return models.MediaReferences{
+		Files:       append(files, encoded...),
+		Directories: directories,
+	}, nil
+}
+
+func (m *MediaReferenceRepository) queryStrings(ctx context.Context, query string) ([]string, error) {
+	log := logger.FromContext(ctx, m.log)
+	values := make([]string, 0)
+	rows, err := m.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var value string
+		if err = rows.Scan(&value); err != nil {
+			log.Error(err.Error())
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	if err = rows.Err(); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+	return values, nil
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/metrics"
+	logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+)
+
+type MigrationDB interface {
+	Version(ctx context.Context) (uint, bool, error)
+}
+
+// MigrationRepository reads the schema_migrations table kept by
+// golang-migrate
+type MigrationRepository struct {
+	db  *sql.DB
+	log logger.Logger
+}
+
+func NewMigrationRepository(db *sql.DB, log logger.Logger) MigrationRepository {
+	return MigrationRepository{
+		db, log,
+	}
+}
+
+// Version returns the applied version and whether the last migration failed
+// halfway, ErrNoResult means no migration was applied yet. Errors are left to
+// the caller to log since readiness probes poll it
+func (m *MigrationRepository) Version(ctx context.Context) (uint, bool, error) {
+	defer metrics.ObserveQuery("migration", "Version")()
+	var version int64
+	var dirty bool
+	row := m.db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1")
+	if err := row.Scan(&version, &dirty); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, ErrNoResult
+		}
+		return 0, false, err
+	}
+	return uint(version), dirty, nil
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	mock_logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationRepository_Version(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT version, dirty FROM schema_migrations LIMIT 1")
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Return applied version and dirty flag",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				mock.ExpectQuery(query).
+					WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(12, false))
+				SUT := NewMigrationRepository(db, log)
+				version, dirty, err := SUT.Version(context.Background())
+				require.NoError(t, err)
+				require.Equal(t, uint(12), version)
+				require.False(t, dirty)
+			},
+		},
+		{
+			name: "Return ErrNoResult when no migration was applied",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				mock.ExpectQuery(query).
+					WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}))
+				SUT := NewMigrationRepository(db, log)
+				_, _, err := SUT.Version(context.Background())
+				require.ErrorIs(t, err, ErrNoResult)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			tc.testCase(t, db, mock, ctrl)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("%s", err)
+			}
+		})
+	}
+}
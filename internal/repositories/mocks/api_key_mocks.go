@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repositories/api_key_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ayrtonsato/video-catalog-golang/internal/models"
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAPIKeyDB is a mock of APIKeyDB interface.
+type MockAPIKeyDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyDBMockRecorder
+}
+
+// MockAPIKeyDBMockRecorder is the mock recorder for MockAPIKeyDB.
+type MockAPIKeyDBMockRecorder struct {
+	mock *MockAPIKeyDB
+}
+
+// NewMockAPIKeyDB creates a new mock instance.
+func NewMockAPIKeyDB(ctrl *gomock.Controller) *MockAPIKeyDB {
+	mock := &MockAPIKeyDB{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyDB) EXPECT() *MockAPIKeyDBMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockAPIKeyDB) GetAll(ctx context.Context) ([]models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAPIKeyDBMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAPIKeyDB)(nil).GetAll), ctx)
+}
+
+// GetByHash mocks base method.
+func (m *MockAPIKeyDB) GetByHash(ctx context.Context, hash string) (models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, hash)
+	ret0, _ := ret[0].(models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockAPIKeyDBMockRecorder) GetByHash(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockAPIKeyDB)(nil).GetByHash), ctx, hash)
+}
+
+// Revoke mocks base method.
+func (m *MockAPIKeyDB) Revoke(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPIKeyDBMockRecorder) Revoke(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIKeyDB)(nil).Revoke), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockAPIKeyDB) Save(ctx context.Context, key models.APIKey, hash string) (models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, key, hash)
+	ret0, _ := ret[0].(models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockAPIKeyDBMockRecorder) Save(ctx, key, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAPIKeyDB)(nil).Save), ctx, key, hash)
+}
+
+// Touch mocks base method.
+func (m *MockAPIKeyDB) Touch(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockAPIKeyDBMockRecorder) Touch(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockAPIKeyDB)(nil).Touch), ctx, id)
+}
@@ -5,69 +5,86 @@
 package mock_repositories
 
 import (
+	context "context"
+	reflect "reflect"
+
 	models "github.com/ayrtonsato/video-catalog-golang/internal/models"
 	uuid "github.com/gofrs/uuid"
 	gomock "github.com/golang/mock/gomock"
-	reflect "reflect"
 )
 
-// MockCategory is a mock of Category interface
+// MockCategory is a mock of Category interface.
 type MockCategory struct {
 	ctrl     *gomock.Controller
 	recorder *MockCategoryMockRecorder
 }
 
-// MockCategoryMockRecorder is the mock recorder for MockCategory
+// MockCategoryMockRecorder is the mock recorder for MockCategory.
 type MockCategoryMockRecorder struct {
 	mock *MockCategory
 }
 
-// NewMockCategory creates a new mock instance
+// NewMockCategory creates a new mock instance.
 func NewMockCategory(ctrl *gomock.Controller) *MockCategory {
 	mock := &MockCategory{ctrl: ctrl}
 	mock.recorder = &MockCategoryMockRecorder{mock}
 	return mock
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use
+// EXPECT returns an object that allows the caller to indicate expected use.
 func (m *MockCategory) EXPECT() *MockCategoryMockRecorder {
 	return m.recorder
 }
 
-// GetCategories mocks base method
-func (m *MockCategory) GetCategories() ([]models.Category, error) {
+// GetByID mocks base method.
+func (m *MockCategory) GetByID(ctx context.Context, id uuid.UUID) (models.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategories")
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCategoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCategory)(nil).GetByID), ctx, id)
+}
+
+// GetCategories mocks base method.
+func (m *MockCategory) GetCategories(ctx context.Context) ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategories", ctx)
 	ret0, _ := ret[0].([]models.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCategories indicates an expected call of GetCategories
-func (mr *MockCategoryMockRecorder) GetCategories() *gomock.Call {
+// GetCategories indicates an expected call of GetCategories.
+func (mr *MockCategoryMockRecorder) GetCategories(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*MockCategory)(nil).GetCategories))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*MockCategory)(nil).GetCategories), ctx)
 }
 
-// Save mocks base method
-func (m *MockCategory) Save(name, description string) (models.Category, error) {
+// Save mocks base method.
+func (m *MockCategory) Save(ctx context.Context, name, description string) (models.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Save", name, description)
+	ret := m.ctrl.Call(m, "Save", ctx, name, description)
 	ret0, _ := ret[0].(models.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Save indicates an expected call of Save
-func (mr *MockCategoryMockRecorder) Save(name, description interface{}) *gomock.Call {
+// Save indicates an expected call of Save.
+func (mr *MockCategoryMockRecorder) Save(ctx, name, description interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockCategory)(nil).Save), name, description)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockCategory)(nil).Save), ctx, name, description)
 }
 
-// Update mocks base method
-func (m *MockCategory) Update(id uuid.UUID, fields []string, values ...interface{}) error {
+// Update mocks base method.
+func (m *MockCategory) Update(ctx context.Context, id uuid.UUID, fields []string, values ...interface{}) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{id, fields}
+	varargs := []interface{}{ctx, id, fields}
 	for _, a := range values {
 		varargs = append(varargs, a)
 	}
@@ -76,24 +93,9 @@ func (m *MockCategory) Update(id uuid.UUID, fields []string, values ...interface
 	return ret0
 }
 
-// Update indicates an expected call of Update
-func (mr *MockCategoryMockRecorder) Update(id, fields interface{}, values ...interface{}) *gomock.Call {
+// Update indicates an expected call of Update.
+func (mr *MockCategoryMockRecorder) Update(ctx, id, fields interface{}, values ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{id, fields}, values...)
+	varargs := append([]interface{}{ctx, id, fields}, values...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCategory)(nil).Update), varargs...)
 }
-
-// GetByID mocks base method
-func (m *MockCategory) GetByID(id uuid.UUID) (models.Category, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", id)
-	ret0, _ := ret[0].(models.Category)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetByID indicates an expected call of GetByID
-func (mr *MockCategoryMockRecorder) GetByID(id interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCategory)(nil).GetByID), id)
-}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repositories/dead_letter_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ayrtonsato/video-catalog-golang/internal/models"
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDeadLetterDB is a mock of DeadLetterDB interface.
+type MockDeadLetterDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeadLetterDBMockRecorder
+}
+
+// MockDeadLetterDBMockRecorder is the mock recorder for MockDeadLetterDB.
+type MockDeadLetterDBMockRecorder struct {
+	mock *MockDeadLetterDB
+}
+
+// NewMockDeadLetterDB creates a new mock instance.
+func NewMockDeadLetterDB(ctrl *gomock.Controller) *MockDeadLetterDB {
+	mock := &MockDeadLetterDB{ctrl: ctrl}
+	mock.recorder = &MockDeadLetterDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeadLetterDB) EXPECT() *MockDeadLetterDBMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockDeadLetterDB) GetByID(ctx context.Context, id uuid.UUID) (models.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(models.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockDeadLetterDBMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDeadLetterDB)(nil).GetByID), ctx, id)
+}
+
+// GetDeadLetters mocks base method.
+func (m *MockDeadLetterDB) GetDeadLetters(ctx context.Context, source string) ([]models.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetters", ctx, source)
+	ret0, _ := ret[0].([]models.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetters indicates an expected call of GetDeadLetters.
+func (mr *MockDeadLetterDBMockRecorder) GetDeadLetters(ctx, source interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetters", reflect.TypeOf((*MockDeadLetterDB)(nil).GetDeadLetters), ctx, source)
+}
+
+// MarkReplayed mocks base method.
+func (m *MockDeadLetterDB) MarkReplayed(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReplayed", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkReplayed indicates an expected call of MarkReplayed.
+func (mr *MockDeadLetterDBMockRecorder) MarkReplayed(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReplayed", reflect.TypeOf((*MockDeadLetterDB)(nil).MarkReplayed), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockDeadLetterDB) Save(ctx context.Context, source string, payload []byte, reason string) (models.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, source, payload, reason)
+	ret0, _ := ret[0].(models.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockDeadLetterDBMockRecorder) Save(ctx, source, payload, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockDeadLetterDB)(nil).Save), ctx, source, payload, reason)
+}
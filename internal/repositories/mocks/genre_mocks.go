@@ -5,99 +5,115 @@
 package mock_repositories
 
 import (
+	context "context"
+	reflect "reflect"
+
 	models "github.com/ayrtonsato/video-catalog-golang/internal/models"
 	uuid "github.com/gofrs/uuid"
 	gomock "github.com/golang/mock/gomock"
-	reflect "reflect"
 )
 
-// MockGenreDB is a mock of GenreDB interface
+// MockGenreDB is a mock of GenreDB interface.
 type MockGenreDB struct {
 	ctrl     *gomock.Controller
 	recorder *MockGenreDBMockRecorder
 }
 
-// MockGenreDBMockRecorder is the mock recorder for MockGenreDB
+// MockGenreDBMockRecorder is the mock recorder for MockGenreDB.
 type MockGenreDBMockRecorder struct {
 	mock *MockGenreDB
 }
 
-// NewMockGenreDB creates a new mock instance
+// NewMockGenreDB creates a new mock instance.
 func NewMockGenreDB(ctrl *gomock.Controller) *MockGenreDB {
 	mock := &MockGenreDB{ctrl: ctrl}
 	mock.recorder = &MockGenreDBMockRecorder{mock}
 	return mock
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use
+// EXPECT returns an object that allows the caller to indicate expected use.
 func (m *MockGenreDB) EXPECT() *MockGenreDBMockRecorder {
 	return m.recorder
 }
 
-// GetGenres mocks base method
-func (m *MockGenreDB) GetGenres() ([]models.Genre, error) {
+// Delete mocks base method.
+func (m *MockGenreDB) Delete(ctx context.Context, genre models.Genre) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGenres")
-	ret0, _ := ret[0].([]models.Genre)
+	ret := m.ctrl.Call(m, "Delete", ctx, genre)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGenreDBMockRecorder) Delete(ctx, genre interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGenreDB)(nil).Delete), ctx, genre)
+}
+
+// GetByID mocks base method.
+func (m *MockGenreDB) GetByID(ctx context.Context, id uuid.UUID) (models.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(models.Genre)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetGenres indicates an expected call of GetGenres
-func (mr *MockGenreDBMockRecorder) GetGenres() *gomock.Call {
+// GetByID indicates an expected call of GetByID.
+func (mr *MockGenreDBMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenres", reflect.TypeOf((*MockGenreDB)(nil).GetGenres))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockGenreDB)(nil).GetByID), ctx, id)
 }
 
-// GetByID mocks base method
-func (m *MockGenreDB) GetByID(id uuid.UUID) (models.Genre, error) {
+// GetGenreByIDWithCategories mocks base method.
+func (m *MockGenreDB) GetGenreByIDWithCategories(ctx context.Context, id uuid.UUID) (models.Genre, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", id)
+	ret := m.ctrl.Call(m, "GetGenreByIDWithCategories", ctx, id)
 	ret0, _ := ret[0].(models.Genre)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByID indicates an expected call of GetByID
-func (mr *MockGenreDBMockRecorder) GetByID(id interface{}) *gomock.Call {
+// GetGenreByIDWithCategories indicates an expected call of GetGenreByIDWithCategories.
+func (mr *MockGenreDBMockRecorder) GetGenreByIDWithCategories(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockGenreDB)(nil).GetByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenreByIDWithCategories", reflect.TypeOf((*MockGenreDB)(nil).GetGenreByIDWithCategories), ctx, id)
 }
 
-// GetGenreByIDWithCategories mocks base method
-func (m *MockGenreDB) GetGenreByIDWithCategories(id uuid.UUID) (models.Genre, error) {
+// GetGenres mocks base method.
+func (m *MockGenreDB) GetGenres(ctx context.Context) ([]models.Genre, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGenreByIDWithCategories", id)
-	ret0, _ := ret[0].(models.Genre)
+	ret := m.ctrl.Call(m, "GetGenres", ctx)
+	ret0, _ := ret[0].([]models.Genre)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetGenreByIDWithCategories indicates an expected call of GetGenreByIDWithCategories
-func (mr *MockGenreDBMockRecorder) GetGenreByIDWithCategories(id interface{}) *gomock.Call {
+// GetGenres indicates an expected call of GetGenres.
+func (mr *MockGenreDBMockRecorder) GetGenres(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenreByIDWithCategories", reflect.TypeOf((*MockGenreDB)(nil).GetGenreByIDWithCategories), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenres", reflect.TypeOf((*MockGenreDB)(nil).GetGenres), ctx)
 }
 
-// Save mocks base method
-func (m *MockGenreDB) Save(name string, categories []uuid.UUID) (models.Genre, error) {
+// Save mocks base method.
+func (m *MockGenreDB) Save(ctx context.Context, name string, categories []uuid.UUID) (models.Genre, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Save", name, categories)
+	ret := m.ctrl.Call(m, "Save", ctx, name, categories)
 	ret0, _ := ret[0].(models.Genre)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Save indicates an expected call of Save
-func (mr *MockGenreDBMockRecorder) Save(name, categories interface{}) *gomock.Call {
+// Save indicates an expected call of Save.
+func (mr *MockGenreDBMockRecorder) Save(ctx, name, categories interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockGenreDB)(nil).Save), name, categories)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockGenreDB)(nil).Save), ctx, name, categories)
 }
 
-// Update mocks base method
-func (m *MockGenreDB) Update(id uuid.UUID, fields []string, values ...interface{}) error {
+// Update mocks base method.
+func (m *MockGenreDB) Update(ctx context.Context, id uuid.UUID, fields []string, values ...interface{}) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{id, fields}
+	varargs := []interface{}{ctx, id, fields}
 	for _, a := range values {
 		varargs = append(varargs, a)
 	}
@@ -106,23 +122,9 @@ func (m *MockGenreDB) Update(id uuid.UUID, fields []string, values ...interface{
 	return ret0
 }
 
-// Update indicates an expected call of Update
-func (mr *MockGenreDBMockRecorder) Update(id, fields interface{}, values ...interface{}) *gomock.Call {
+// Update indicates an expected call of Update.
+func (mr *MockGenreDBMockRecorder) Update(ctx, id, fields interface{}, values ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{id, fields}, values...)
+	varargs := append([]interface{}{ctx, id, fields}, values...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGenreDB)(nil).Update), varargs...)
 }
-
-// Delete mocks base method
-func (m *MockGenreDB) Delete(genre models.Genre) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", genre)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// Delete indicates an expected call of Delete
-func (mr *MockGenreDBMockRecorder) Delete(genre interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGenreDB)(nil).Delete), genre)
-}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repositories/media_blob_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ayrtonsato/video-catalog-golang/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMediaBlobDB is a mock of MediaBlobDB interface.
+type MockMediaBlobDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaBlobDBMockRecorder
+}
+
+// MockMediaBlobDBMockRecorder is the mock recorder for MockMediaBlobDB.
+type MockMediaBlobDBMockRecorder struct {
+	mock *MockMediaBlobDB
+}
+
+// NewMockMediaBlobDB creates a new mock instance.
+func NewMockMediaBlobDB(ctrl *gomock.Controller) *MockMediaBlobDB {
+	mock := &MockMediaBlobDB{ctrl: ctrl}
+	mock.recorder = &MockMediaBlobDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaBlobDB) EXPECT() *MockMediaBlobDBMockRecorder {
+	return m.recorder
+}
+
+// Acquire mocks base method.
+func (m *MockMediaBlobDB) Acquire(ctx context.Context, blob models.MediaBlob) (models.MediaBlob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acquire", ctx, blob)
+	ret0, _ := ret[0].(models.MediaBlob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Acquire indicates an expected call of Acquire.
+func (mr *MockMediaBlobDBMockRecorder) Acquire(ctx, blob interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acquire", reflect.TypeOf((*MockMediaBlobDB)(nil).Acquire), ctx, blob)
+}
+
+// Release mocks base method.
+func (m *MockMediaBlobDB) Release(ctx context.Context, path string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, path)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockMediaBlobDBMockRecorder) Release(ctx, path interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockMediaBlobDB)(nil).Release), ctx, path)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repositories/media_reference_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ayrtonsato/video-catalog-golang/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMediaReferenceDB is a mock of MediaReferenceDB interface.
+type MockMediaReferenceDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaReferenceDBMockRecorder
+}
+
+// MockMediaReferenceDBMockRecorder is the mock recorder for MockMediaReferenceDB.
+type MockMediaReferenceDBMockRecorder struct {
+	mock *MockMediaReferenceDB
+}
+
+// NewMockMediaReferenceDB creates a new mock instance.
+func NewMockMediaReferenceDB(ctrl *gomock.Controller) *MockMediaReferenceDB {
+	mock := &MockMediaReferenceDB{ctrl: ctrl}
+	mock.recorder = &MockMediaReferenceDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaReferenceDB) EXPECT() *MockMediaReferenceDBMockRecorder {
+	return m.recorder
+}
+
+// GetReferences mocks base method.
+func (m *MockMediaReferenceDB) GetReferences(ctx context.Context) (models.MediaReferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReferences", ctx)
+	ret0, _ := ret[0].(models.MediaReferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReferences indicates an expected call of GetReferences.
+func (mr *MockMediaReferenceDBMockRecorder) GetReferences(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReferences", reflect.TypeOf((*MockMediaReferenceDB)(nil).GetReferences), ctx)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repositories/subtitle_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ayrtonsato/video-catalog-golang/internal/models"
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSubtitleDB is a mock of SubtitleDB interface.
+type MockSubtitleDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubtitleDBMockRecorder
+}
+
+// MockSubtitleDBMockRecorder is the mock recorder for MockSubtitleDB.
+type MockSubtitleDBMockRecorder struct {
+	mock *MockSubtitleDB
+}
+
+// NewMockSubtitleDB creates a new mock instance.
+func NewMockSubtitleDB(ctrl *gomock.Controller) *MockSubtitleDB {
+	mock := &MockSubtitleDB{ctrl: ctrl}
+	mock.recorder = &MockSubtitleDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubtitleDB) EXPECT() *MockSubtitleDBMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSubtitleDB) Delete(ctx context.Context, videoID uuid.UUID, language string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, videoID, language)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSubtitleDBMockRecorder) Delete(ctx, videoID, language interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSubtitleDB)(nil).Delete), ctx, videoID, language)
+}
+
+// GetByLanguage mocks base method.
+func (m *MockSubtitleDB) GetByLanguage(ctx context.Context, videoID uuid.UUID, language string) (models.Subtitle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByLanguage", ctx, videoID, language)
+	ret0, _ := ret[0].(models.Subtitle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByLanguage indicates an expected call of GetByLanguage.
+func (mr *MockSubtitleDBMockRecorder) GetByLanguage(ctx, videoID, language interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByLanguage", reflect.TypeOf((*MockSubtitleDB)(nil).GetByLanguage), ctx, videoID, language)
+}
+
+// GetByVideoID mocks base method.
+func (m *MockSubtitleDB) GetByVideoID(ctx context.Context, videoID uuid.UUID) ([]models.Subtitle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByVideoID", ctx, videoID)
+	ret0, _ := ret[0].([]models.Subtitle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByVideoID indicates an expected call of GetByVideoID.
+func (mr *MockSubtitleDBMockRecorder) GetByVideoID(ctx, videoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByVideoID", reflect.TypeOf((*MockSubtitleDB)(nil).GetByVideoID), ctx, videoID)
+}
+
+// Save mocks base method.
+func (m *MockSubtitleDB) Save(ctx context.Context, subtitle models.Subtitle) (models.Subtitle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, subtitle)
+	ret0, _ := ret[0].(models.Subtitle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSubtitleDBMockRecorder) Save(ctx, subtitle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSubtitleDB)(nil).Save), ctx, subtitle)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repositories/video_image_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ayrtonsato/video-catalog-golang/internal/models"
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockVideoImageDB is a mock of VideoImageDB interface.
+type MockVideoImageDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockVideoImageDBMockRecorder
+}
+
+// MockVideoImageDBMockRecorder is the mock recorder for MockVideoImageDB.
+type MockVideoImageDBMockRecorder struct {
+	mock *MockVideoImageDB
+}
+
+// NewMockVideoImageDB creates a new mock instance.
+func NewMockVideoImageDB(ctrl *gomock.Controller) *MockVideoImageDB {
+	mock := &MockVideoImageDB{ctrl: ctrl}
+	mock.recorder = &MockVideoImageDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVideoImageDB) EXPECT() *MockVideoImageDBMockRecorder {
+	return m.recorder
+}
+
+// GetByVideoIDs mocks base method.
+func (m *MockVideoImageDB) GetByVideoIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]models.ImageVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByVideoIDs", ctx, ids)
+	ret0, _ := ret[0].(map[uuid.UUID][]models.ImageVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByVideoIDs indicates an expected call of GetByVideoIDs.
+func (mr *MockVideoImageDBMockRecorder) GetByVideoIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByVideoIDs", reflect.TypeOf((*MockVideoImageDB)(nil).GetByVideoIDs), ctx, ids)
+}
+
+// SaveVariants mocks base method.
+func (m *MockVideoImageDB) SaveVariants(ctx context.Context, videoID uuid.UUID, kind models.VideoFileKind, original string, variants []models.ImageVariant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveVariants", ctx, videoID, kind, original, variants)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveVariants indicates an expected call of SaveVariants.
+func (mr *MockVideoImageDBMockRecorder) SaveVariants(ctx, videoID, kind, original, variants interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveVariants", reflect.TypeOf((*MockVideoImageDB)(nil).SaveVariants), ctx, videoID, kind, original, variants)
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repositories/video_repository.go
+
+// Package mock_repositories is a generated GoMock package.
+package mock_repositories
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ayrtonsato/video-catalog-golang/internal/models"
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockVideoDB is a mock of VideoDB interface.
+type MockVideoDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockVideoDBMockRecorder
+}
+
+// MockVideoDBMockRecorder is the mock recorder for MockVideoDB.
+type MockVideoDBMockRecorder struct {
+	mock *MockVideoDB
+}
+
+// NewMockVideoDB creates a new mock instance.
+func NewMockVideoDB(ctrl *gomock.Controller) *MockVideoDB {
+	mock := &MockVideoDB{ctrl: ctrl}
+	mock.recorder = &MockVideoDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVideoDB) EXPECT() *MockVideoDBMockRecorder {
+	return m.recorder
+}
+
+// ApplyEncoderResult mocks base method.
+func (m *MockVideoDB) ApplyEncoderResult(ctx context.Context, messageID string, video models.Video, from models.VideoStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyEncoderResult", ctx, messageID, video, from)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyEncoderResult indicates an expected call of ApplyEncoderResult.
+func (mr *MockVideoDBMockRecorder) ApplyEncoderResult(ctx, messageID, video, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyEncoderResult", reflect.TypeOf((*MockVideoDB)(nil).ApplyEncoderResult), ctx, messageID, video, from)
+}
+
+// GetByID mocks base method.
+func (m *MockVideoDB) GetByID(ctx context.Context, id uuid.UUID) (models.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(models.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockVideoDBMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockVideoDB)(nil).GetByID), ctx, id)
+}
+
+// GetVideos mocks base method.
+func (m *MockVideoDB) GetVideos(ctx context.Context, status models.VideoStatus) ([]models.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVideos", ctx, status)
+	ret0, _ := ret[0].([]models.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVideos indicates an expected call of GetVideos.
+func (mr *MockVideoDBMockRecorder) GetVideos(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideos", reflect.TypeOf((*MockVideoDB)(nil).GetVideos), ctx, status)
+}
+
+// IsEncoderMessageProcessed mocks base method.
+func (m *MockVideoDB) IsEncoderMessageProcessed(ctx context.Context, messageID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEncoderMessageProcessed", ctx, messageID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsEncoderMessageProcessed indicates an expected call of IsEncoderMessageProcessed.
+func (mr *MockVideoDBMockRecorder) IsEncoderMessageProcessed(ctx, messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEncoderMessageProcessed", reflect.TypeOf((*MockVideoDB)(nil).IsEncoderMessageProcessed), ctx, messageID)
+}
+
+// UpdateMediaFiles mocks base method.
+func (m *MockVideoDB) UpdateMediaFiles(ctx context.Context, video, from models.Video) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMediaFiles", ctx, video, from)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMediaFiles indicates an expected call of UpdateMediaFiles.
+func (mr *MockVideoDBMockRecorder) UpdateMediaFiles(ctx, video, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMediaFiles", reflect.TypeOf((*MockVideoDB)(nil).UpdateMediaFiles), ctx, video, from)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockVideoDB) UpdateStatus(ctx context.Context, video models.Video, from models.VideoStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, video, from)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockVideoDBMockRecorder) UpdateStatus(ctx, video, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockVideoDB)(nil).UpdateStatus), ctx, video, from)
+}
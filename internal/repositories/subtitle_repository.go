@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/metrics"
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/gofrs/uuid"
+)
+
+const subtitleColumns = "id, video_id, language, label, path, cue_count, created_at, updated_at"
+
+type SubtitleDB interface {
+	Save(ctx context.Context, subtitle models.Subtitle) (models.Subtitle, error)
+	GetByVideoID(ctx context.Context, videoID uuid.UUID) ([]models.Subtitle, error)
+	GetByLanguage(ctx context.Context, videoID uuid.UUID, language string) (models.Subtitle, error)
+	Delete(ctx context.Context, videoID uuid.UUID, language string) error
+}
+
+type SubtitleRepository struct {
+	db  *sql.DB
+	log logger.Logger
+}
+
+func NewSubtitleRepository(db *sql.DB, log logger.Logger) SubtitleRepository {
+	return SubtitleRepository{
+		db, log,
+	}
+}
+
+func (s *SubtitleRepository) saveIntoSubtitle(ctx context.Context, row RepoReader) (models.Subtitle, error) {
+	log := logger.FromContext(ctx, s.log)
+	var subtitle models.Subtitle
+	err := row.Scan(
+		&subtitle.ID,
+		&subtitle.VideoID,
+		&subtitle.Language,
+		&subtitle.Label,
+		&subtitle.Path,
+		&subtitle.CueCount,
+		&subtitle.CreatedAt,
+		&subtitle.UpdatedAt)
+	if err != nil {
+		log.Error(err.Error())
+		return models.Subtitle{}, err
+	}
+	return subtitle, nil
+}
+
+// Save inserts the track or replaces the one of the same language
+func (s *SubtitleRepository) Save(ctx context.Context, subtitle models.Subtitle) (models.Subtitle, error) {
+	defer metrics.ObserveQuery("subtitle", "Save")()
+	log := logger.FromContext(ctx, s.log)
+	insertStatement := `INSERT INTO video_subtitles(video_id, language, label, path, cue_count)
+		VALUES($1, $2, $3, $4, $5)
+		ON CONFLICT (video_id, language) DO UPDATE
+		SET label=EXCLUDED.label, path=EXCLUDED.path, cue_count=EXCLUDED.cue_count, updated_at=(NOW())
+		RETURNING ` + subtitleColumns
+	stmt, err := s.db.PrepareContext(ctx, insertStatement)
+	if err != nil {
+		log.Error(err.Error())
+		return models.Subtitle{}, ErrOnSave
+	}
+	defer stmt.Close()
+	row := stmt.QueryRowContext(ctx, subtitle.VideoID, subtitle.Language, subtitle.Label, subtitle.Path, subtitle.CueCount)
+	saved, err := s.saveIntoSubtitle(ctx, row)
+	if err != nil {
+		return models.Subtitle{}, ErrOnSave
+	}
+	return saved, nil
+}
+
+func (s *SubtitleRepository) GetByVideoID(ctx context.Context, videoID uuid.UUID) ([]models.Subtitle, error) {
+	defer metrics.ObserveQuery("subtitle", "GetByVideoID")()
+	log := logger.FromContext(ctx, s.log)
+	var subtitles []models.Subtitle
+	query := "SELECT " + subtitleColumns + " FROM video_subtitles WHERE video_id=$1 ORDER BY language"
+	rows, err := s.db.QueryContext(ctx, query, videoID)
+	if err != nil {
+		log.Error(err.Error())
+		return []models.Subtitle{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		subtitle, err := s.saveIntoSubtitle(ctx, rows)
+		if err != nil {
+			return []models.Subtitle{}, err
+		}
+		subtitles = append(subtitles, subtitle)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err.Error())
+		return []models.Subtitle{}, err
+	}
+	if len(subtitles) == 0 {
+		return make([]models.Subtitle, 0), nil
+	}
+	return subtitles, nil
+}
+
+func (s *SubtitleRepository) GetByLanguage(ctx context.Context, videoID uuid.UUID, language string) (models.Subtitle, error) {
+	defer metrics.ObserveQuery("subtitle", "GetByLanguage")()
+	query := "SELECT " + subtitleColumns + " FROM video_subtitles WHERE video_id=$1 AND language=$2"
+	row := s.db.QueryRowContext(ctx, query, videoID, language)
+	subtitle, err := s.saveIntoSubtitle(ctx, row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Subtitle{}, ErrNoResult
+		}
+		return models.Subtitle{}, err
+	}
+	return subtitle, nil
+}
+
+func (s *SubtitleRepository) Delete(ctx context.Context, videoID uuid.UUID, language string) error {
+	defer metrics.ObserveQuery("subtitle", "Delete")()
+	log := logger.FromContext(ctx, s.log)
+	query := "DELETE FROM video_subtitles WHERE video_id=$1 AND language=$2"
+	exec, err := s.db.ExecContext(ctx, query, videoID, language)
+	if err != nil {
+		log.Error(err.Error())
+		return ErrOnDelete
+	}
+	affected, err := exec.RowsAffected()
+	if err != nil {
+		log.Error(err.Error())
+		return ErrOnDelete
+	}
+	if affected == 0 {
+		return ErrNoResult
+	}
+	return nil
+}
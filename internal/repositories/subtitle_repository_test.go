@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	mock_logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger/mocks"
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var subtitleFields = []string{"id", "video_id", "language", "label", "path", "cue_count", "created_at", "updated_at"}
+
+func TestSubtitleRepository_Save(t *testing.T) {
+	subtitle := models.Subtitle{
+		ID:        uuid.Must(uuid.NewV4()),
+		VideoID:   uuid.Must(uuid.NewV4()),
+		Language:  "en",
+		Label:     "English",
+		Path:      "videos/1/subtitles/en.vtt",
+		CueCount:  12,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	query := regexp.QuoteMeta("INSERT INTO video_subtitles(video_id, language, label, path, cue_count)")
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Save or replace track successfully",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				rows := sqlmock.NewRows(subtitleFields).AddRow(
+					subtitle.ID, subtitle.VideoID, subtitle.Language, subtitle.Label,
+					subtitle.Path, subtitle.CueCount, subtitle.CreatedAt, subtitle.UpdatedAt)
+				mock.ExpectPrepare(query).
+					ExpectQuery().
+					WithArgs(subtitle.VideoID, "en", "English", subtitle.Path, 12).
+					WillReturnRows(rows)
+				SUT := NewSubtitleRepository(db, log)
+				saved, err := SUT.Save(context.Background(), subtitle)
+				require.NoError(t, err)
+				require.Equal(t, subtitle, saved)
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+		{
+			name: "Return ErrOnSave when insert fails",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				log.EXPECT().Error(gomock.Any()).Times(1)
+				mock.ExpectPrepare(query).
+					ExpectQuery().
+					WillReturnError(sql.ErrConnDone)
+				SUT := NewSubtitleRepository(db, log)
+				_, err := SUT.Save(context.Background(), subtitle)
+				require.ErrorIs(t, err, ErrOnSave)
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			tc.testCase(t, db, mock, ctrl)
+		})
+	}
+}
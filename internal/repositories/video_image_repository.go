@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/metrics"
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/gofrs/uuid"
+)
+
+type VideoImageDB interface {
+	SaveVariants(ctx context.Context, videoID uuid.UUID, kind models.VideoFileKind, original string, variants []models.ImageVariant) error
+	GetByVideoIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]models.ImageVariant, error)
+}
+
+type VideoImageRepository struct {
+	db  *sql.DB
+	log logger.Logger
+}
+
+func NewVideoImageRepository(db *sql.DB, log logger.Logger) VideoImageRepository {
+	return VideoImageRepository{
+		db, log,
+	}
+}
+
+// imageFileColumns maps the image kinds to the videos column holding the
+// original upload
+var imageFileColumns = map[models.VideoFileKind]string{
+	models.VideoFileKindThumb:  "thumb_file",
+	models.VideoFileKindBanner: "banner_file",
+}
+
+// SaveVariants replaces the variants of kind for the video and points its
+// file column to original, all in the same transaction
+func (v *VideoImageRepository) SaveVariants(ctx context.Context, videoID uuid.UUID,
+	kind models.VideoFileKind,
+	original string,
+	variants []models.ImageVariant) error {
+	defer metrics.ObserveQuery("video_image", "SaveVariants")()
+	log := logger.FromContext(ctx, v.log)
+	column, ok := imageFileColumns[kind]
+	if !ok {
+		return fmt.Errorf("%w: unknown image kind %q", ErrOnSave, kind)
+	}
+	deleteStatement := "DELETE FROM video_images WHERE video_id=$1 AND kind=$2"
+	insertStatement := `INSERT INTO video_images(video_id, kind, width, height, format, path)
+		VALUES($1, $2, $3, $4, $5, $6)
+	`
+	updateStatement := fmt.Sprintf(
+		"UPDATE videos SET %s=$1, updated_at=(NOW()) WHERE id=$2 AND deleted_at IS NULL", column)
+	tx, err := v.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err.Error())
+		return ErrOnSave
+	}
+	exec, err := tx.ExecContext(ctx, updateStatement, original, videoID)
+	if err != nil {
+		log.Error(err.Error())
+		TransactionRollback(tx, log, err)
+		return ErrOnSave
+	}
+	if affected, err := exec.RowsAffected(); err != nil || affected == 0 {
+		TransactionRollback(tx, log, err)
+		if err != nil {
+			return ErrOnSave
+		}
+		return ErrNoResult
+	}
+	if _, err = tx.ExecContext(ctx, deleteStatement, videoID, kind); err != nil {
+		log.Error(err.Error())
+		TransactionRollback(tx, log, err)
+		return ErrOnSave
+	}
+	for _, variant := range variants {
+		_, err = tx.ExecContext(ctx, insertStatement,
+			videoID,
+			kind,
+			variant.Width,
+			variant.Height,
+			variant.Format,
+			variant.Path)
+		if err != nil {
+			log.Error(err.Error())
+			TransactionRollback(tx, log, err)
+			return ErrOnSave
+		}
+	}
+	if errCommit := TransactionCommit(tx, log); errCommit != nil {
+		return ErrOnSave
+	}
+	return nil
+}
+
+// GetByVideoIDs loads the image variants of every video in ids at once,
+// ordered by width, videos without images are absent from the map
+func (v *VideoImageRepository) GetByVideoIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]models.ImageVariant, error) {
+	defer metrics.ObserveQuery("video_image", "GetByVideoIDs")()
+	log := logger.FromContext(ctx, v.log)
+	images := make(map[uuid.UUID][]models.ImageVariant)
+	if len(ids) == 0 {
+		return images, nil
+	}
+	args := make([]string, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id.String())
+	}
+	query := `SELECT video_id, kind, width, height, format, path FROM video_images
+		WHERE video_id = ANY($1::uuid[])
+		ORDER BY video_id, kind, width`
+	rows, err := v.db.QueryContext(ctx, query, args)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var videoID uuid.UUID
+		var variant models.ImageVariant
+		err = rows.Scan(
+			&videoID,
+			&variant.Kind,
+			&variant.Width,
+			&variant.Height,
+			&variant.Format,
+			&variant.Path)
+		if err != nil {
+			log.Error(err.Error())
+			return nil, err
+		}
+		images[videoID] = append(images[videoID], variant)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+	return images, nil
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	mock_logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger/mocks"
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVideoImageRepository_SaveVariants(t *testing.T) {
+	videoID := uuid.Must(uuid.NewV4())
+	original := "videos/1/thumb/original.jpg"
+	variants := []models.ImageVariant{
+		{Kind: models.VideoFileKindThumb, Width: 320, Height: 180, Format: "jpeg", Path: "videos/1/thumb/320w.jpg"},
+	}
+	updateVideo := regexp.QuoteMeta("UPDATE videos SET thumb_file=$1")
+	deleteImages := regexp.QuoteMeta("DELETE FROM video_images")
+	insertImage := regexp.QuoteMeta("INSERT INTO video_images(video_id, kind, width, height, format, path)")
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Replace variants and update video in one transaction",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				mock.ExpectBegin()
+				mock.ExpectExec(updateVideo).
+					WithArgs(original, videoID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(deleteImages).
+					WithArgs(videoID, models.VideoFileKindThumb).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec(insertImage).
+					WithArgs(videoID, models.VideoFileKindThumb, 320, 180, "jpeg", "videos/1/thumb/320w.jpg").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				SUT := NewVideoImageRepository(db, log)
+				err := SUT.SaveVariants(context.Background(), videoID, models.VideoFileKindThumb, original, variants)
+				require.NoError(t, err)
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+		{
+			name: "Return ErrNoResult when video does not exist",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				mock.ExpectBegin()
+				mock.ExpectExec(updateVideo).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				SUT := NewVideoImageRepository(db, log)
+				err := SUT.SaveVariants(context.Background(), videoID, models.VideoFileKindThumb, original, variants)
+				require.ErrorIs(t, err, ErrNoResult)
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			tc.testCase(t, db, mock, ctrl)
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/ayrtonsato/video-catalog-golang/internal/metrics"
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/gofrs/uuid"
+)
+
+const encoderMessageSource = "encoder"
+
+const videoColumns = `id, title, description, year_launched, opened, rating, duration,
+	video_file, trailer_file, thumb_file, banner_file,
+	status, status_error, processing_at, completed_at, failed_at, encoded_files,
+	metadata, duration_mismatch,
+	is_active, created_at, updated_at, deleted_at`
+
+type VideoDB interface {
+	GetVideos(ctx context.Context, status models.VideoStatus) ([]models.Video, error)
+	GetByID(ctx context.Context, id uuid.UUID) (models.Video, error)
+	UpdateStatus(ctx context.Context, video models.Video, from models.VideoStatus) error
+	IsEncoderMessageProcessed(ctx context.Context, messageID string) (bool, error)
+	ApplyEncoderResult(ctx context.Context, messageID string, video models.Video, from models.VideoStatus) error
+	UpdateMediaFiles(ctx context.Context, video models.Video, from models.Video) error
+}
+
+type VideoRepository struct {
+	db  *sql.DB
+	log logger.Logger
+}
+
+func NewVideoRepository(db *sql.DB, log logger.Logger) VideoRepository {
+	return VideoRepository{
+		db, log,
+	}
+}
+
+func (v *VideoRepository) saveIntoVideo(ctx context.Context, row RepoReader) (models.Video, error) {
+	log := logger.FromContext(ctx, v.log)
+	var video models.Video
+	err := row.Scan(
+		&video.Id,
+		&video.Title,
+		&video.Description,
+		&video.YearLaunched,
+		&video.Opened,
+		&video.Rating,
+		&video.Duration,
+		&video.VideoFile,
+		&video.TrailerFile,
+		&video.ThumbFile,
+		&video.BannerFile,
+		&video.Status,
+		&video.StatusError,
+		&video.ProcessingAt,
+		&video.CompletedAt,
+		&video.FailedAt,
+		&video.EncodedFiles,
+		&video.Metadata,
+		&video.DurationMismatch,
+		&video.IsActive,
+		&video.CreatedAt,
+		&video.UpdatedAt,
+		&video.DeletedAt)
+	if err != nil {
+		log.Error(err.Error())
+		return models.Video{}, err
+	}
+	return video, nil
+}
+
+// GetVideos lists the videos, filtered by status unless it is empty
+func (v *VideoRepository) GetVideos(ctx context.Context, status models.VideoStatus) ([]models.Video, error) {
+	defer metrics.ObserveQuery("video", "GetVideos")()
+	log := logger.FromContext(ctx, v.log)
+	var videos []models.Video
+	query := "SELECT " + videoColumns + " FROM videos WHERE deleted_at IS NULL"
+	args := make([]interface{}, 0, 1)
+	if status != "" {
+		query = query + " AND status=$1"
+		args = append(args, status)
+	}
+	query = query + " ORDER BY created_at DESC"
+	rows, err := v.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error(err.Error())
+		return []models.Video{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		video, err := v.saveIntoVideo(ctx, rows)
+		if err != nil {
+			return []models.Video{}, err
+		}
+		videos = append(videos, video)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err.Error())
+		return []models.Video{}, err
+	}
+	if len(videos) == 0 {
+		return make([]models.Video, 0), nil
+	}
+	return videos, nil
+}
+
+func (v *VideoRepository) GetByID(ctx context.Context, id uuid.UUID) (models.Video, error) {
+	defer metrics.ObserveQuery("video", "GetByID")()
+	query := "SELECT " + videoColumns + " FROM videos WHERE id=$1 AND deleted_at IS NULL"
+	row := v.db.QueryRowContext(ctx, query, id)
+	video, err := v.saveIntoVideo(ctx, row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Video{}, ErrNoResult
+		}
+		return models.Video{}, err
+	}
+	return video, nil
+}
+
+// UpdateStatus persists the status fields of video, only if its status is
+// still from, so two concurrent transitions cannot both succeed
+func (v *VideoRepository) UpdateStatus(ctx context.Context, video models.Video, from models.VideoStatus) error {
+	defer metrics.ObserveQuery("video", "UpdateStatus")()
+	log := logger.FromContext(ctx, v.log)
+	query := `UPDATE videos
+		SET status=$1, status_error=$2, processing_at=$3, completed_at=$4, failed_at=$5, updated_at=(NOW())
+		WHERE id=$6 AND status=$7 AND deleted_at IS NULL`
+	stmt, err := v.db.PrepareContext(ctx, query)
+	if err != nil {
+		log.Error(err.Error())
+		return ErrOnUpdate
+	}
+	defer stmt.Close()
+	exec, err := stmt.ExecContext(ctx,
+		video.Status,
+		video.StatusError,
+		video.ProcessingAt,
+		video.CompletedAt,
+		video.FailedAt,
+		video.Id,
+		from)
+	if err != nil {
+		log.Error(err.Error())
+		return ErrOnUpdate
+	}
+	affected, err := exec.RowsAffected()
+	if err != nil {
+		log.Error(err.Error())
+		return ErrOnUpdate
+	}
+	if affected == 0 {
+		return ErrStaleObject
+	}
+	return nil
+}
+
+func (v *VideoRepository) IsEncoderMessageProcessed(ctx context.Context, messageID string) (bool, error) {
+	defer metrics.ObserveQuery("video", "IsEncoderMessageProcessed")()
+	log := logger.FromContext(ctx, v.log)
+	query := "SELECT EXISTS(SELECT 1 FROM processed_messages WHERE source=$1 AND message_id=$2)"
+	var processed bool
+	err := v.db.QueryRowContext(ctx, query, encoderMessageSource, messageID).Scan(&processed)
+	if err != nil {
+		log.Error(err.Error())
+		return false, err
+	}
+	return processed, nil
+}
+
+// ApplyEncoderResult records messageID as processed and persists the status
+// and encoded files of video in the same transaction, a message that was
+// already recorded is reported with ErrDuplicateMessage and changes nothing
+func (v *VideoRepository) ApplyEncoderResult(ctx context.Context, messageID string, video models.Video, from models.VideoStatus) error {
+	defer metrics.ObserveQuery("video", "ApplyEncoderResult")()
+	log := logger.FromContext(ctx, v.log)
+	insertMessageStatement := `INSERT INTO processed_messages(source, message_id)
+		VALUES($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	updateVideoStatement := `UPDATE videos
+		SET status=$1, status_error=$2, processing_at=$3, completed_at=$4, failed_at=$5,
+			encoded_files=$6, updated_at=(NOW())
+		WHERE id=$7 AND status=$8 AND deleted_at IS NULL`
+	tx, err := v.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(err.Error())
+		return ErrOnUpdate
+	}
+	exec, err := tx.ExecContext(ctx, insertMessageStatement, encoderMessageSource, messageID)
+	if err != nil {
+		log.Error(err.Error())
+		TransactionRollback(tx, log, err)
+		return ErrOnUpdate
+	}
+	if affected, err := exec.RowsAffected(); err != nil || affected == 0 {
+		TransactionRollback(tx, log, err)
+		if err != nil {
+			return ErrOnUpdate
+		}
+		return ErrDuplicateMessage
+	}
+	exec, err = tx.ExecContext(ctx, updateVideoStatement,
+		video.Status,
+		video.StatusError,
+		video.ProcessingAt,
+		video.CompletedAt,
+		video.FailedAt,
+		video.EncodedFiles,
+		video.Id,
+		from)
+	if err != nil {
+		log.Error(err.Error())
+		TransactionRollback(tx, log, err)
+		return ErrOnUpdate
+	}
+	if affected, err := exec.RowsAffected(); err != nil || affected == 0 {
+		TransactionRollback(tx, log, err)
+		if err != nil {
+			return ErrOnUpdate
+		}
+		return ErrStaleObject
+	}
+	if errCommit := TransactionCommit(tx, log); errCommit != nil {
+		return ErrOnUpdate
+	}
+	return nil
+}
+
+// UpdateMediaFiles persists the video and trailer files of video along with
+// the metadata extracted from the video file, only if its files are still
+// those of from so the file replaced by two uploads is released only once
+func (v *VideoRepository) UpdateMediaFiles(ctx context.Context, video models.Video, from models.Video) error {
+	defer metrics.ObserveQuery("video", "UpdateMediaFiles")()
+	log := logger.FromContext(ctx, v.log)
+	query := `UPDATE videos
+		SET video_file=$1, trailer_file=$2, metadata=$3, duration_mismatch=$4, updated_at=(NOW())
+		WHERE id=$5 AND deleted_at IS NULL
+		AND video_file IS NOT DISTINCT FROM $6 AND trailer_file IS NOT DISTINCT FROM $7`
+	stmt, err := v.db.PrepareContext(ctx, query)
+	if err != nil {
+		log.Error(err.Error())
+		return ErrOnUpdate
+	}
+	defer stmt.Close()
+	exec, err := stmt.ExecContext(ctx,
+		video.VideoFile,
+		video.TrailerFile,
+		video.Metadata,
+		video.DurationMismatch,
+		video.Id,
+		from.VideoFile,
+		from.TrailerFile)
+	if err != nil {
+		log.Error(err.Error())
+		return ErrOnUpdate
+	}
+	affected, err := exec.RowsAffected()
+	if err != nil {
+		log.Error(err.Error())
+		return ErrOnUpdate
+	}
+	if affected == 0 {
+		return ErrStaleObject
+	}
+	return nil
+}
@@ -0,0 +1,381 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	mock_logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger/mocks"
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var videoFields = []string{
+	"id", "title", "description", "year_launched", "opened", "rating", "duration",
+	"video_file", "trailer_file", "thumb_file", "banner_file",
+	"status", "status_error", "processing_at", "completed_at", "failed_at", "encoded_files",
+	"metadata", "duration_mismatch",
+	"is_active", "created_at", "updated_at", "deleted_at",
+}
+
+func TestVideoRepository_GetByID(t *testing.T) {
+	videoFile := "videos/video.mp4"
+	fakeVideo := models.Video{
+		Id:           uuid.Must(uuid.NewV4()),
+		Title:        "valid_title",
+		Description:  "valid_description",
+		YearLaunched: 2021,
+		Opened:       true,
+		Rating:       "L",
+		Duration:     90,
+		VideoFile:    &videoFile,
+		Status:       models.VideoStatusPending,
+		EncodedFiles: models.EncodedFiles{},
+		IsActive:     true,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+	query := regexp.QuoteMeta("FROM videos WHERE id=$1 AND deleted_at IS NULL")
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Return video successfully",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				rows := sqlmock.NewRows(videoFields).AddRow(
+					fakeVideo.Id, fakeVideo.Title, fakeVideo.Description, fakeVideo.YearLaunched,
+					fakeVideo.Opened, fakeVideo.Rating, fakeVideo.Duration,
+					videoFile, nil, nil, nil,
+					"pending", nil, nil, nil, nil, []byte("[]"),
+					nil, false,
+					fakeVideo.IsActive, fakeVideo.CreatedAt, fakeVideo.UpdatedAt, nil)
+				mock.ExpectQuery(query).WithArgs(fakeVideo.Id).WillReturnRows(rows)
+				SUT := NewVideoRepository(db, log)
+				video, err := SUT.GetByID(context.Background(), fakeVideo.Id)
+				require.NoError(t, err)
+				require.Equal(t, fakeVideo, video)
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+		{
+			name: "Return ErrNoResult when video does not exist",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				log.EXPECT().Error(sql.ErrNoRows.Error()).Times(1)
+				mock.ExpectQuery(query).WithArgs(fakeVideo.Id).WillReturnError(sql.ErrNoRows)
+				SUT := NewVideoRepository(db, log)
+				_, err := SUT.GetByID(context.Background(), fakeVideo.Id)
+				require.ErrorIs(t, err, ErrNoResult)
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			tc.testCase(t, db, mock, ctrl)
+		})
+	}
+}
+
+func TestVideoRepository_GetVideos(t *testing.T) {
+	fakeVideo := models.Video{
+		Id:           uuid.Must(uuid.NewV4()),
+		Title:        "valid_title",
+		Rating:       "L",
+		Status:       models.VideoStatusFailed,
+		EncodedFiles: models.EncodedFiles{},
+		IsActive:     true,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Return videos filtered by status",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				rows := sqlmock.NewRows(videoFields).AddRow(
+					fakeVideo.Id, fakeVideo.Title, "", 0, false, fakeVideo.Rating, 0,
+					nil, nil, nil, nil,
+					"failed", nil, nil, nil, nil, []byte("[]"),
+					nil, false,
+					true, fakeVideo.CreatedAt, fakeVideo.UpdatedAt, nil)
+				mock.ExpectQuery(regexp.QuoteMeta("WHERE deleted_at IS NULL AND status=$1")).
+					WithArgs(models.VideoStatusFailed).
+					WillReturnRows(rows)
+				SUT := NewVideoRepository(db, log)
+				videos, err := SUT.GetVideos(context.Background(), models.VideoStatusFailed)
+				require.NoError(t, err)
+				require.Equal(t, []models.Video{fakeVideo}, videos)
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+		{
+			name: "Return an empty list without filter",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				mock.ExpectQuery(regexp.QuoteMeta("WHERE deleted_at IS NULL ORDER BY created_at DESC")).
+					WithArgs().
+					WillReturnRows(sqlmock.NewRows(videoFields))
+				SUT := NewVideoRepository(db, log)
+				videos, err := SUT.GetVideos(context.Background(), "")
+				require.NoError(t, err)
+				require.Equal(t, []models.Video{}, videos)
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			tc.testCase(t, db, mock, ctrl)
+		})
+	}
+}
+
+func TestVideoRepository_UpdateStatus(t *testing.T) {
+	processingAt := time.Now().UTC()
+	video := models.Video{
+		Id:           uuid.Must(uuid.NewV4()),
+		Status:       models.VideoStatusProcessing,
+		ProcessingAt: &processingAt,
+	}
+	query := regexp.QuoteMeta("UPDATE videos")
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Update status successfully",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				mock.ExpectPrepare(query).
+					ExpectExec().
+					WithArgs(video.Status, nil, &processingAt, nil, nil, video.Id, models.VideoStatusPending).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				SUT := NewVideoRepository(db, log)
+				err := SUT.UpdateStatus(context.Background(), video, models.VideoStatusPending)
+				require.NoError(t, err)
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+		{
+			name: "Return ErrStaleObject when status changed meanwhile",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				mock.ExpectPrepare(query).
+					ExpectExec().
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				SUT := NewVideoRepository(db, log)
+				err := SUT.UpdateStatus(context.Background(), video, models.VideoStatusPending)
+				require.ErrorIs(t, err, ErrStaleObject)
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			tc.testCase(t, db, mock, ctrl)
+		})
+	}
+}
+
+func TestVideoRepository_ApplyEncoderResult(t *testing.T) {
+	completedAt := time.Now().UTC()
+	video := models.Video{
+		Id:           uuid.Must(uuid.NewV4()),
+		Status:       models.VideoStatusCompleted,
+		CompletedAt:  &completedAt,
+		EncodedFiles: models.EncodedFiles{"videos/1/encoded/manifest.mpd"},
+	}
+	insertMessage := regexp.QuoteMeta("INSERT INTO processed_messages(source, message_id)")
+	updateVideo := regexp.QuoteMeta("UPDATE videos")
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Record message and update video in one transaction",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				mock.ExpectBegin()
+				mock.ExpectExec(insertMessage).
+					WithArgs("encoder", "message-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(updateVideo).
+					WithArgs(video.Status, nil, nil, &completedAt, nil,
+						`["videos/1/encoded/manifest.mpd"]`, video.Id, models.VideoStatusProcessing).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				SUT := NewVideoRepository(db, log)
+				err := SUT.ApplyEncoderResult(context.Background(), "message-1", video, models.VideoStatusProcessing)
+				require.NoError(t, err)
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+		{
+			name: "Return ErrDuplicateMessage when message was already processed",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				mock.ExpectBegin()
+				mock.ExpectExec(insertMessage).
+					WithArgs("encoder", "message-1").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				SUT := NewVideoRepository(db, log)
+				err := SUT.ApplyEncoderResult(context.Background(), "message-1", video, models.VideoStatusProcessing)
+				require.ErrorIs(t, err, ErrDuplicateMessage)
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+		{
+			name: "Return ErrStaleObject when video status changed meanwhile",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				mock.ExpectBegin()
+				mock.ExpectExec(insertMessage).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(updateVideo).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				SUT := NewVideoRepository(db, log)
+				err := SUT.ApplyEncoderResult(context.Background(), "message-1", video, models.VideoStatusProcessing)
+				require.ErrorIs(t, err, ErrStaleObject)
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			tc.testCase(t, db, mock, ctrl)
+		})
+	}
+}
+
+func TestVideoRepository_UpdateMediaFiles(t *testing.T) {
+	videoFile := "videos/1/video/source.mp4"
+	metadata := models.VideoMetadata{DurationSeconds: 5400, Width: 1920, Height: 1080, Codec: "avc1"}
+	video := models.Video{
+		Id:               uuid.Must(uuid.NewV4()),
+		VideoFile:        &videoFile,
+		Metadata:         &metadata,
+		DurationMismatch: true,
+	}
+	query := regexp.QuoteMeta("UPDATE videos")
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Update files and metadata successfully",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				mock.ExpectPrepare(query).
+					ExpectExec().
+					WithArgs(&videoFile, nil,
+						`{"durationSeconds":5400,"width":1920,"height":1080,"codec":"avc1"}`,
+						true, video.Id, nil, nil).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				SUT := NewVideoRepository(db, log)
+				require.NoError(t, SUT.UpdateMediaFiles(context.Background(), video, models.Video{Id: video.Id}))
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+		{
+			name: "Return ErrStaleObject when the files changed meanwhile",
+			testCase: func(t *testing.T, db *sql.DB, mock sqlmock.Sqlmock, ctrl *gomock.Controller) {
+				log := mock_logger.NewMockLogger(ctrl)
+				mock.ExpectPrepare(query).
+					ExpectExec().
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				SUT := NewVideoRepository(db, log)
+				require.ErrorIs(t, SUT.UpdateMediaFiles(context.Background(), video, models.Video{Id: video.Id}), ErrStaleObject)
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("%s", err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			tc.testCase(t, db, mock, ctrl)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package routes
+
+import (
+	"database/sql"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/consumers"
+	"github.com/ayrtonsato/video-catalog-golang/internal/controllers"
+	"github.com/ayrtonsato/video-catalog-golang/internal/middlewares"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminRoutes struct {
+	router *gin.Engine
+	db     *sql.DB
+	log    logger.Logger
+}
+
+func NewAdminRoutes(router *gin.Engine, db *sql.DB, log logger.Logger) AdminRoutes {
+	return AdminRoutes{
+		router, db, log,
+	}
+}
+
+func (r AdminRoutes) Routes() {
+	r.router.GET("/admin/dead-letters", r.GetDeadLetters)
+	r.router.POST("/admin/dead-letters/:id/replay", r.ReplayDeadLetter)
+}
+
+func (r *AdminRoutes) deadLetterService() services.DeadLetterDBService {
+	deadLetterRepo := repositories.NewDeadLetterRepository(r.db, r.log)
+	videoRepo := repositories.NewVideoRepository(r.db, r.log)
+	encoder := services.NewEncoderResultDBService(&videoRepo)
+	return services.NewDeadLetterDBService(&deadLetterRepo, map[string]services.MessageProcessor{
+		consumers.EncoderSource: &encoder,
+	})
+}
+
+func (r *AdminRoutes) GetDeadLetters(ctx *gin.Context) {
+	var dto controllers.GetDeadLettersDTO
+	if !bindQuery(ctx, &dto) {
+		return
+	}
+	serv := r.deadLetterService()
+	ctrl := controllers.NewGetDeadLettersController(&serv, dto)
+	resp := ctrl.Handle(ctx.Request.Context())
+
+	respond(ctx, resp)
+}
+
+func (r *AdminRoutes) ReplayDeadLetter(ctx *gin.Context) {
+	params := make(map[string]interface{})
+	params["id"] = middlewares.UUIDParam(ctx, "id")
+
+	serv := r.deadLetterService()
+	ctrl := controllers.NewReplayDeadLetterController(&serv, params)
+	resp := ctrl.Handle(ctx.Request.Context())
+
+	respond(ctx, resp)
+}
@@ -0,0 +1,71 @@
+package routes
+
+import (
+	"database/sql"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/auth"
+	"github.com/ayrtonsato/video-catalog-golang/internal/controllers"
+	"github.com/ayrtonsato/video-catalog-golang/internal/middlewares"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyRoutes struct {
+	router *gin.Engine
+	db     *sql.DB
+	log    logger.Logger
+}
+
+func NewAPIKeyRoutes(router *gin.Engine, db *sql.DB, log logger.Logger) APIKeyRoutes {
+	return APIKeyRoutes{
+		router, db, log,
+	}
+}
+
+func (r APIKeyRoutes) Routes() {
+	r.router.GET("/admin/api-keys", r.GetAPIKeys)
+	r.router.POST("/admin/api-keys", middlewares.Consumes(gin.MIMEJSON), r.IssueAPIKey)
+	r.router.DELETE("/admin/api-keys/:id", r.RevokeAPIKey)
+}
+
+func (r *APIKeyRoutes) apiKeyService() services.APIKeysDBService {
+	repository := repositories.NewAPIKeyRepository(r.db, r.log)
+	return services.NewAPIKeysDBService(&repository)
+}
+
+func (r *APIKeyRoutes) GetAPIKeys(ctx *gin.Context) {
+	serv := r.apiKeyService()
+	ctrl := controllers.NewGetAPIKeysController(&serv)
+	resp := ctrl.Handle(ctx.Request.Context())
+
+	respond(ctx, resp)
+}
+
+func (r *APIKeyRoutes) IssueAPIKey(ctx *gin.Context) {
+	var dto controllers.IssueAPIKeyDTO
+	if !bindJSON(ctx, &dto) {
+		return
+	}
+	if principal, ok := auth.FromContext(ctx.Request.Context()); ok {
+		dto.CreatedBy = principal.Subject
+	}
+	validation := controllers.NewIssueAPIKeyValidation(&dto)
+	serv := r.apiKeyService()
+	ctrl := controllers.NewIssueAPIKeyController(&serv, dto, validation)
+	resp := ctrl.Handle(ctx.Request.Context())
+
+	respond(ctx, resp)
+}
+
+func (r *APIKeyRoutes) RevokeAPIKey(ctx *gin.Context) {
+	params := make(map[string]interface{})
+	params["id"] = middlewares.UUIDParam(ctx, "id")
+
+	serv := r.apiKeyService()
+	ctrl := controllers.NewRevokeAPIKeyController(&serv, params)
+	resp := ctrl.Handle(ctx.Request.Context())
+
+	respond(ctx, resp)
+}
@@ -0,0 +1,82 @@
+package routes
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+// errTrailingData is reported when the JSON body holds more than one value
+var errTrailingData = errors.New("routes: data after the JSON body")
+
+// bindJSON decodes the JSON body into dto, an empty body leaves dto empty so
+// the validation reports the missing fields. Malformed bodies and bodies
+// with data after the first value are answered with a 400 and bindJSON
+// returns false
+func bindJSON(ctx *gin.Context, dto interface{}) bool {
+	if ctx.Request.Body == nil {
+		return true
+	}
+	decoder := json.NewDecoder(ctx.Request.Body)
+	err := decoder.Decode(dto)
+	if errors.Is(err, io.EOF) {
+		return true
+	}
+	if err == nil {
+		if err = decoder.Decode(&json.RawMessage{}); errors.Is(err, io.EOF) {
+			return true
+		}
+		err = errTrailingData
+	}
+	respond(ctx, helpers.HTTPInvalidJSON(err))
+	return false
+}
+
+// bindQuery binds the query string into dto, values which cannot be parsed
+// like bind_ip=maybe are answered with a 400
+func bindQuery(ctx *gin.Context, dto interface{}) bool {
+	if err := ctx.ShouldBindQuery(dto); err != nil {
+		respond(ctx, helpers.HTTPInvalidQuery())
+		return false
+	}
+	return true
+}
+
+// bindForm binds the fields of a multipart form into dto, bodies bigger
+// than the route's limit are answered with a 413 and broken forms with a 400
+func bindForm(ctx *gin.Context, dto interface{}) bool {
+	if err := ctx.ShouldBind(dto); err != nil {
+		respondFormError(ctx, err)
+		return false
+	}
+	return true
+}
+
+// formFile is the file of the multipart field name, nil when the field is
+// missing so the validation reports it
+func formFile(ctx *gin.Context, name string) (*multipart.FileHeader, bool) {
+	file, err := ctx.FormFile(name)
+	if errors.Is(err, http.ErrMissingFile) {
+		return nil, true
+	}
+	if err != nil {
+		respondFormError(ctx, err)
+		return nil, false
+	}
+	return file, true
+}
+
+func respondFormError(ctx *gin.Context, err error) {
+	// http.MaxBytesReader has no error type to match before Go 1.19
+	if strings.Contains(err.Error(), "request body too large") {
+		respond(ctx, helpers.HTTPRequestTooLarge())
+		return
+	}
+	respond(ctx, helpers.HTTPInvalidForm())
+}
@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/helpers"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name   string
+		body   string
+		bound  bool
+		detail string
+	}{
+		{name: "single value", body: `{"name": "a"}`, bound: true},
+		{name: "trailing whitespace", body: "{\"name\": \"a\"}\n", bound: true},
+		{name: "empty body", body: "", bound: true},
+		{name: "second value", body: `{"name": "a"}{"name": "b"}`, detail: "the body is not valid JSON"},
+		{name: "trailing garbage", body: `{"name": "a"} garbage`, detail: "the body is not valid JSON"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			var dto struct {
+				Name string `json:"name"`
+			}
+			require.Equal(t, tc.bound, bindJSON(ctx, &dto))
+			if tc.bound {
+				return
+			}
+			require.Equal(t, http.StatusBadRequest, recorder.Code)
+			var problem helpers.Problem
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+			require.Equal(t, tc.detail, problem.Detail)
+		})
+	}
+}
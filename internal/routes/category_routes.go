@@ -3,114 +3,104 @@ package routes
 import (
 	"database/sql"
 	"github.com/ayrtonsato/video-catalog-golang/internal/controllers"
+	"github.com/ayrtonsato/video-catalog-golang/internal/middlewares"
 	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
 	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/cache"
 	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
 	"github.com/gin-gonic/gin"
-	"github.com/gofrs/uuid"
 )
 
 type CategoryRoutes struct {
 	router *gin.Engine
 	db     *sql.DB
 	log    logger.Logger
+	cache  *cache.ReadThrough
 }
 
-func NewCategoryRoutes(router *gin.Engine, db *sql.DB, log logger.Logger) CategoryRoutes {
+// NewCategoryRoutes reads categories through cache when it is not nil
+func NewCategoryRoutes(router *gin.Engine, db *sql.DB, log logger.Logger, cache *cache.ReadThrough) CategoryRoutes {
 	return CategoryRoutes{
-		router, db, log,
+		router, db, log, cache,
 	}
 }
 
 func (r CategoryRoutes) Routes() {
-	r.router.POST("/category", r.CreateCategory)
+	json := middlewares.Consumes(gin.MIMEJSON)
+	r.router.POST("/category", json, r.CreateCategory)
 	r.router.GET("/category", r.GetCategories)
 	r.router.GET("/category/:id", r.GetSingleCategory)
-	r.router.PUT("/category/:id", r.UpdateCategory)
+	r.router.PUT("/category/:id", json, r.UpdateCategory)
 	r.router.DELETE("/category/:id", r.DeleteCategory)
 }
 
-func (r *CategoryRoutes) GetCategories(ctx *gin.Context) {
+func (r *CategoryRoutes) repository() repositories.Category {
 	repository := repositories.NewCategoryRepository(r.db, r.log)
-	service := services.NewGetCategoriesDbService(&repository)
+	if r.cache == nil {
+		return &repository
+	}
+	cached := repositories.NewCachedCategoryRepository(&repository, r.cache)
+	return &cached
+}
+
+func (r *CategoryRoutes) GetCategories(ctx *gin.Context) {
+	repository := r.repository()
+	service := services.NewGetCategoriesDbService(repository)
 	controller := controllers.NewGetCategoriesController(&service)
-	resp := controller.Handle()
-	ctx.JSON(resp.Code, gin.H{
-		"body": resp.Body,
-	})
+	resp := controller.Handle(ctx.Request.Context())
+	respondWrapped(ctx, resp)
 }
 
 func (r *CategoryRoutes) CreateCategory(ctx *gin.Context) {
 	var json controllers.SaveCategoryDTO
-	if err := ctx.ShouldBindJSON(&json); err != nil {
-		json = controllers.SaveCategoryDTO{}
+	if !bindJSON(ctx, &json) {
+		return
 	}
 	validation := controllers.NewSaveCategoryValidation(&json)
-	repository := repositories.NewCategoryRepository(r.db, r.log)
-	service := services.NewSaveDbCategoryService(&repository)
+	repository := r.repository()
+	service := services.NewSaveDbCategoryService(repository)
 	controller := controllers.NewSaveCategoryController(&service, json, validation)
-	resp := controller.Handle()
-	ctx.JSON(resp.Code, gin.H{
-		"body": resp.Body,
-	})
+	resp := controller.Handle(ctx.Request.Context())
+	respondWrapped(ctx, resp)
 }
 
 func (r *CategoryRoutes) UpdateCategory(ctx *gin.Context) {
 	params := make(map[string]interface{})
-
-	id := ctx.Param("id")
-	newUUID, err := uuid.FromString(id)
-	if err != nil {
-		r.log.Error(err)
-	}
-
-	params["id"] = newUUID
+	params["id"] = middlewares.UUIDParam(ctx, "id")
 
 	var dto controllers.UpdateCategoryDTO
-	if err = ctx.ShouldBindJSON(&dto); err != nil {
-		r.log.Error(err)
+	if !bindJSON(ctx, &dto) {
+		return
 	}
 	val := controllers.NewUpdateCategoryValidation(&dto)
-	repo := repositories.NewCategoryRepository(r.db, r.log)
-	serv := services.NewUpdateDbCategoryService(&repo)
+	repo := r.repository()
+	serv := services.NewUpdateDbCategoryService(repo)
 	ctrl := controllers.NewUpdateCategoryController(&serv, dto, val, params)
-	resp := ctrl.Handle()
+	resp := ctrl.Handle(ctx.Request.Context())
 
-	ctx.JSON(resp.Code, resp.Body)
+	respond(ctx, resp)
 }
 
 func (r *CategoryRoutes) DeleteCategory(ctx *gin.Context) {
 	params := make(map[string]interface{})
+	params["id"] = middlewares.UUIDParam(ctx, "id")
 
-	id := ctx.Param("id")
-	newUUID, err := uuid.FromString(id)
-	if err != nil {
-		r.log.Error(err)
-	}
-	params["id"] = newUUID
-
-	repo := repositories.NewCategoryRepository(r.db, r.log)
-	serv := services.NewDeleteDBCategoryService(&repo)
+	repo := r.repository()
+	serv := services.NewDeleteDBCategoryService(repo)
 	ctrl := controllers.NewDeleteCategoryController(&serv, params)
-	resp := ctrl.Handle()
+	resp := ctrl.Handle(ctx.Request.Context())
 
-	ctx.JSON(resp.Code, resp.Body)
+	respond(ctx, resp)
 }
 
 func (r *CategoryRoutes) GetSingleCategory(ctx *gin.Context) {
 	params := make(map[string]interface{})
+	params["id"] = middlewares.UUIDParam(ctx, "id")
 
-	id := ctx.Param("id")
-	newUUID, err := uuid.FromString(id)
-	if err != nil {
-		r.log.Error(err)
-	}
-	params["id"] = newUUID
-
-	repo := repositories.NewCategoryRepository(r.db, r.log)
-	serv := services.NewGetCategoriesDbService(&repo)
+	repo := r.repository()
+	serv := services.NewGetCategoriesDbService(repo)
 	ctrl := controllers.NewGetSingleCategoryController(&serv, params)
-	resp := ctrl.Handle()
+	resp := ctrl.Handle(ctx.Request.Context())
 
-	ctx.JSON(resp.Code, resp.Body)
+	respond(ctx, resp)
 }
@@ -178,12 +178,12 @@ func TestCategoryRoutes_GetSingleCategory(t *testing.T) {
 			},
 		},
 		{
-			name: "404 NotFound when id is not an uuid",
+			name: "400 BadRequest when id is not an uuid",
 			urlFn: func(category *models.Category) string {
 				return fmt.Sprintf("/category/teste")
 			},
 			response: func(t *testing.T, r *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, r.Code)
+				require.Equal(t, http.StatusBadRequest, r.Code)
 			},
 		},
 	}
@@ -243,7 +243,7 @@ func TestCategoryRoutes_UpdateCategory(t *testing.T) {
 			},
 		},
 		{
-			name: "404 NotFound when id is not an uuid",
+			name: "400 BadRequest when id is not an uuid",
 			body: gin.H{
 				"name":        "diff_name",
 				"description": "diff_desc",
@@ -252,7 +252,7 @@ func TestCategoryRoutes_UpdateCategory(t *testing.T) {
 				return fmt.Sprintf("/category/teste")
 			},
 			response: func(t *testing.T, r *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, r.Code)
+				require.Equal(t, http.StatusBadRequest, r.Code)
 			},
 		},
 		{
@@ -341,12 +341,12 @@ func TestCategoryRoutes_DeleteCategory(t *testing.T) {
 			},
 		},
 		{
-			name: "404 NotFound when id is not an uuid",
+			name: "400 BadRequest when id is not an uuid",
 			urlFn: func(category *models.Category) string {
 				return fmt.Sprintf("/category/teste")
 			},
 			response: func(t *testing.T, r *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusNotFound, r.Code)
+				require.Equal(t, http.StatusBadRequest, r.Code)
 			},
 		},
 	}
@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/ayrtonsato/video-catalog-golang/pkg/health"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthRoutes struct {
+	router  *gin.Engine
+	checker *health.Checker
+}
+
+func NewHealthRoutes(router *gin.Engine, checker *health.Checker) HealthRoutes {
+	return HealthRoutes{
+		router, checker,
+	}
+}
+
+func (r HealthRoutes) Routes() {
+	r.router.GET("/healthz", r.Liveness)
+	r.router.GET("/readyz", r.Readiness)
+}
+
+// Liveness only tells the process is serving, dependencies are left to
+// readiness so an outage of the database does not get every instance
+// restarted
+func (r *HealthRoutes) Liveness(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, health.Report{Status: health.StatusOK, Checks: map[string]health.Result{}})
+}
+
+func (r *HealthRoutes) Readiness(ctx *gin.Context) {
+	if r.checker == nil {
+		r.Liveness(ctx)
+		return
+	}
+	report := r.checker.Run(ctx.Request.Context())
+	code := http.StatusOK
+	if !report.OK() {
+		code = http.StatusServiceUnavailable
+	}
+	ctx.JSON(code, report)
+}
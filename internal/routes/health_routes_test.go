@@ -0,0 +1,52 @@
+package routes_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/routes"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/health"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	databaseUp := true
+	checker := health.NewChecker(time.Second)
+	checker.Add("database", func(ctx context.Context) (string, error) {
+		if !databaseUp {
+			return "", errors.New("connection refused")
+		}
+		return "", nil
+	})
+	router := gin.New()
+	routes.NewHealthRoutes(router, checker).Routes()
+
+	serve := func(path string) (int, health.Report) {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		var report health.Report
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+		return recorder.Code, report
+	}
+
+	code, report := serve("/readyz")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, health.StatusOK, report.Checks["database"].Status)
+
+	databaseUp = false
+	code, report = serve("/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, health.StatusFail, report.Status)
+	require.Equal(t, "connection refused", report.Checks["database"].Error)
+
+	code, report = serve("/healthz")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, health.StatusOK, report.Status)
+}
@@ -0,0 +1,177 @@
+package routes
+
+import (
+	"database/sql"
+	"errors"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/controllers"
+	"github.com/ayrtonsato/video-catalog-golang/internal/helpers"
+	"github.com/ayrtonsato/video-catalog-golang/internal/middlewares"
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/signedurl"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// MediaOptions groups what routes need to store and sign media files
+type MediaOptions struct {
+	Storage        storage.Storage
+	Signer         signedurl.Signer
+	BaseURL        string
+	URLTTL         time.Duration
+	ImageWidths    []int
+	JPEGQuality    int
+	MaxUploadBytes int64
+	MaxVideoBytes  int64
+}
+
+func (o MediaOptions) urls() services.MediaURLs {
+	return services.NewMediaURLs(o.Signer, o.BaseURL, o.URLTTL)
+}
+
+type MediaRoutes struct {
+	router *gin.Engine
+	db     *sql.DB
+	log    logger.Logger
+	media  MediaOptions
+}
+
+func NewMediaRoutes(router *gin.Engine, db *sql.DB, log logger.Logger, media MediaOptions) MediaRoutes {
+	return MediaRoutes{
+		router, db, log, media,
+	}
+}
+
+func (r MediaRoutes) Routes() {
+	r.router.GET("/video/:id/files/:kind/url", r.SignVideoFile)
+	form := middlewares.Consumes(gin.MIMEMultipartPOSTForm)
+	r.router.POST("/video/:id/thumb", form, r.UploadVideoImage(models.VideoFileKindThumb))
+	r.router.POST("/video/:id/banner", form, r.UploadVideoImage(models.VideoFileKindBanner))
+	r.router.POST("/video/:id/video", form, r.UploadVideoFile(models.VideoFileKindVideo))
+	r.router.POST("/video/:id/trailer", form, r.UploadVideoFile(models.VideoFileKindTrailer))
+	r.router.GET("/media/*path", r.Download)
+}
+
+func (r *MediaRoutes) SignVideoFile(ctx *gin.Context) {
+	params := make(map[string]interface{})
+	params["id"] = middlewares.UUIDParam(ctx, "id")
+
+	var dto controllers.SignMediaDTO
+	if !bindQuery(ctx, &dto) {
+		return
+	}
+	dto.Kind = ctx.Param("kind")
+	dto.ClientIP = middlewares.ClientIPOf(ctx)
+
+	val := controllers.NewSignMediaValidation(&dto)
+	repo := repositories.NewVideoRepository(r.db, r.log)
+	serv := services.NewSignMediaDBService(&repo, r.media.Signer, r.media.BaseURL, r.media.URLTTL)
+	ctrl := controllers.NewSignMediaController(&serv, dto, val, params)
+	resp := ctrl.Handle(ctx.Request.Context())
+
+	respond(ctx, resp)
+}
+
+// UploadVideoImage reads the image from the multipart field "file", bodies
+// bigger than MaxUploadBytes are rejected with a 413
+func (r *MediaRoutes) UploadVideoImage(kind models.VideoFileKind) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		params := make(map[string]interface{})
+		params["id"] = middlewares.UUIDParam(ctx, "id")
+
+		dto := controllers.UploadVideoImageDTO{Kind: string(kind)}
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, r.media.MaxUploadBytes)
+		file, ok := formFile(ctx, "file")
+		if !ok {
+			return
+		}
+		if file != nil {
+			content, err := file.Open()
+			if err != nil {
+				r.log.Error(err)
+			} else {
+				defer content.Close()
+				dto.File = content
+			}
+		}
+
+		val := controllers.NewUploadVideoImageValidation(&dto)
+		videoRepo := repositories.NewVideoRepository(r.db, r.log)
+		imageRepo := repositories.NewVideoImageRepository(r.db, r.log)
+		serv := services.NewUploadVideoImageDBService(&videoRepo, &imageRepo, r.media.Storage, r.media.urls(),
+			services.ImageOptions{Widths: r.media.ImageWidths, JPEGQuality: r.media.JPEGQuality})
+		ctrl := controllers.NewUploadVideoImageController(&serv, dto, val, params)
+		resp := ctrl.Handle(ctx.Request.Context())
+
+		respond(ctx, resp)
+	}
+}
+
+// UploadVideoFile reads the mp4 or mov file from the multipart field
+// "file", bodies bigger than MaxVideoBytes are rejected with a 413
+func (r *MediaRoutes) UploadVideoFile(kind models.VideoFileKind) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		params := make(map[string]interface{})
+		params["id"] = middlewares.UUIDParam(ctx, "id")
+
+		dto := controllers.UploadVideoFileDTO{Kind: string(kind)}
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, r.media.MaxVideoBytes)
+		file, ok := formFile(ctx, "file")
+		if !ok {
+			return
+		}
+		if file != nil {
+			content, err := file.Open()
+			if err != nil {
+				r.log.Error(err)
+			} else {
+				defer content.Close()
+				dto.File = content
+				dto.Size = file.Size
+			}
+		}
+
+		val := controllers.NewUploadVideoFileValidation(&dto)
+		videoRepo := repositories.NewVideoRepository(r.db, r.log)
+		blobRepo := repositories.NewMediaBlobRepository(r.db, r.log)
+		serv := services.NewUploadVideoFileDBService(&videoRepo, &blobRepo, r.media.Storage)
+		ctrl := controllers.NewUploadVideoFileController(&serv, dto, val, params)
+		resp := ctrl.Handle(ctx.Request.Context())
+
+		respond(ctx, resp)
+	}
+}
+
+func (r *MediaRoutes) Download(ctx *gin.Context) {
+	file := strings.TrimPrefix(ctx.Param("path"), "/")
+	serv := services.NewDownloadMediaService(r.media.Storage, r.media.Signer)
+	media, err := serv.Open(ctx.Request.Context(), file, ctx.Request.URL.Query(), middlewares.ClientIPOf(ctx))
+	if err != nil {
+		if !errors.Is(err, services.ErrForbidden) && !errors.Is(err, services.ErrNotFound) {
+			r.log.Error(err)
+		}
+		respond(ctx, helpers.HTTPServiceError(err))
+		return
+	}
+	defer media.Content.Close()
+
+	headers := map[string]string{
+		"Cache-Control": "private, no-store",
+	}
+	if media.ContentDisposition != "" {
+		headers["Content-Disposition"] = media.ContentDisposition
+	}
+	contentType := mime.TypeByExtension(path.Ext(file))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	ctx.DataFromReader(200, media.Size, contentType, media.Content, headers)
+}
@@ -0,0 +1,52 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/middlewares"
+	"github.com/ayrtonsato/video-catalog-golang/internal/routes"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/signedurl"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMediaRoutes_DownloadBoundToClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	root := t.TempDir()
+	file := "videos/1/thumb/original.jpg"
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "videos/1/thumb"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, file), []byte("image"), 0o644))
+	signer, err := signedurl.NewSigner("k1", map[string][]byte{"k1": []byte("secret")})
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(middlewares.ClientIP(nil))
+	routes.NewMediaRoutes(router, nil, nil, routes.MediaOptions{
+		Storage: storage.NewLocalStorage(root),
+		Signer:  signer,
+	}).Routes()
+	query := signer.Sign(file, signedurl.Options{
+		Expires:  time.Now().Add(time.Hour),
+		ClientIP: "203.0.113.7",
+	})
+	download := func(remote string, forwarded string) int {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/media/"+file+"?"+query.Encode(), nil)
+		request.RemoteAddr = remote
+		if forwarded != "" {
+			request.Header.Set("X-Forwarded-For", forwarded)
+		}
+		router.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	require.Equal(t, http.StatusOK, download("203.0.113.7:1234", ""))
+	require.Equal(t, http.StatusForbidden, download("198.51.100.1:1234", ""))
+	require.Equal(t, http.StatusForbidden, download("198.51.100.1:1234", "203.0.113.7"))
+}
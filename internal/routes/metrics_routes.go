@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/ayrtonsato/video-catalog-golang/internal/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type MetricsRoutes struct {
+	router *gin.Engine
+}
+
+func NewMetricsRoutes(router *gin.Engine) MetricsRoutes {
+	return MetricsRoutes{
+		router,
+	}
+}
+
+func (r MetricsRoutes) Routes() {
+	r.router.GET("/metrics", gin.WrapH(promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{})))
+}
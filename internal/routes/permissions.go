@@ -0,0 +1,40 @@
+package routes
+
+import "github.com/ayrtonsato/video-catalog-golang/internal/auth"
+
+// Permissions maps every protected route, as "METHOD path", to the
+// permission it requires, a route missing here is denied to everyone. Which
+// roles hold a permission is set by the configured auth.Policy
+var Permissions = map[string]auth.Permission{
+	"GET /category":        auth.CategoryRead,
+	"GET /category/:id":    auth.CategoryRead,
+	"POST /category":       auth.CategoryCreate,
+	"PUT /category/:id":    auth.CategoryUpdate,
+	"DELETE /category/:id": auth.CategoryDelete,
+
+	"GET /video":                            auth.VideoRead,
+	"GET /video/:id":                        auth.VideoRead,
+	"PATCH /video/:id/status":               auth.VideoUpdate,
+	"GET /video/:id/files/:kind/url":        auth.VideoRead,
+	"POST /video/:id/thumb":                 auth.MediaUpload,
+	"POST /video/:id/banner":                auth.MediaUpload,
+	"POST /video/:id/video":                 auth.MediaUpload,
+	"POST /video/:id/trailer":               auth.MediaUpload,
+	"GET /video/:id/subtitles":              auth.VideoRead,
+	"POST /video/:id/subtitles":             auth.MediaUpload,
+	"DELETE /video/:id/subtitles/:language": auth.VideoUpdate,
+
+	"GET /admin/dead-letters":             auth.AdminManage,
+	"POST /admin/dead-letters/:id/replay": auth.AdminManage,
+	"GET /admin/api-keys":                 auth.AdminManage,
+	"POST /admin/api-keys":                auth.AdminManage,
+	"DELETE /admin/api-keys/:id":          auth.AdminManage,
+
+	"GET /metrics": auth.MetricsRead,
+}
+
+// PublicRoutes are served without a token, media downloads are authorized by
+// their signed url and probes must not depend on the identity provider.
+// Paths are matched exactly, /media/ is the only prefix. Scrapers of
+// /metrics use an api key with the metrics:read scope
+var PublicRoutes = []string{"/media/", "/healthz", "/readyz"}
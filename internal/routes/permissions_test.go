@@ -0,0 +1,35 @@
+package routes_test
+
+import (
+	"testing"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/middlewares"
+	"github.com/ayrtonsato/video-catalog-golang/internal/routes"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissions_CoverEveryRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	routes.NewCategoryRoutes(router, nil, nil, nil).Routes()
+	routes.NewVideoRoutes(router, nil, nil, routes.MediaOptions{}).Routes()
+	routes.NewAdminRoutes(router, nil, nil).Routes()
+	routes.NewAPIKeyRoutes(router, nil, nil).Routes()
+	routes.NewMediaRoutes(router, nil, nil, routes.MediaOptions{}).Routes()
+	routes.NewSubtitleRoutes(router, nil, nil, routes.MediaOptions{}).Routes()
+	routes.NewHealthRoutes(router, nil).Routes()
+	routes.NewMetricsRoutes(router).Routes()
+
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		key := route.Method + " " + route.Path
+		registered[key] = true
+		if !middlewares.IsPublic(route.Path, routes.PublicRoutes) {
+			require.Contains(t, routes.Permissions, key)
+		}
+	}
+	for key := range routes.Permissions {
+		require.True(t, registered[key], "%s is not a registered route", key)
+	}
+}
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"github.com/ayrtonsato/video-catalog-golang/internal/helpers"
+	"github.com/ayrtonsato/video-catalog-golang/internal/middlewares"
+	"github.com/ayrtonsato/video-catalog-golang/internal/protocols"
+	"github.com/gin-gonic/gin"
+)
+
+// respond writes the response of a controller, errors are written as
+// problem details
+func respond(ctx *gin.Context, resp protocols.HttpResponse) {
+	if problem, ok := resp.Body.(helpers.Problem); ok {
+		middlewares.WriteProblem(ctx, problem)
+		return
+	}
+	ctx.JSON(resp.Code, resp.Body)
+}
+
+// respondWrapped is respond for the routes which nest successful bodies in
+// a "body" field
+func respondWrapped(ctx *gin.Context, resp protocols.HttpResponse) {
+	if _, ok := resp.Body.(helpers.Problem); ok {
+		respond(ctx, resp)
+		return
+	}
+	ctx.JSON(resp.Code, gin.H{
+		"body": resp.Body,
+	})
+}
@@ -0,0 +1,94 @@
+package routes
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/controllers"
+	"github.com/ayrtonsato/video-catalog-golang/internal/middlewares"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type SubtitleRoutes struct {
+	router *gin.Engine
+	db     *sql.DB
+	log    logger.Logger
+	media  MediaOptions
+}
+
+func NewSubtitleRoutes(router *gin.Engine, db *sql.DB, log logger.Logger, media MediaOptions) SubtitleRoutes {
+	return SubtitleRoutes{
+		router, db, log, media,
+	}
+}
+
+func (r SubtitleRoutes) Routes() {
+	r.router.GET("/video/:id/subtitles", r.GetSubtitles)
+	r.router.POST("/video/:id/subtitles", middlewares.Consumes(gin.MIMEMultipartPOSTForm), r.UploadSubtitle)
+	r.router.DELETE("/video/:id/subtitles/:language", r.DeleteSubtitle)
+}
+
+func (r *SubtitleRoutes) service() services.SubtitlesDBService {
+	videoRepo := repositories.NewVideoRepository(r.db, r.log)
+	subtitleRepo := repositories.NewSubtitleRepository(r.db, r.log)
+	return services.NewSubtitlesDBService(&videoRepo, &subtitleRepo, r.media.Storage, r.media.urls())
+}
+
+func (r *SubtitleRoutes) params(ctx *gin.Context) map[string]interface{} {
+	params := make(map[string]interface{})
+	params["id"] = middlewares.UUIDParam(ctx, "id")
+	return params
+}
+
+func (r *SubtitleRoutes) GetSubtitles(ctx *gin.Context) {
+	serv := r.service()
+	ctrl := controllers.NewGetSubtitlesController(&serv, r.params(ctx))
+	resp := ctrl.Handle(ctx.Request.Context())
+
+	respond(ctx, resp)
+}
+
+// UploadSubtitle reads the SRT or WebVTT file from the multipart field "file"
+func (r *SubtitleRoutes) UploadSubtitle(ctx *gin.Context) {
+	params := r.params(ctx)
+
+	var dto controllers.UploadSubtitleDTO
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, r.media.MaxUploadBytes)
+	if !bindForm(ctx, &dto) {
+		return
+	}
+	file, ok := formFile(ctx, "file")
+	if !ok {
+		return
+	}
+	if file != nil {
+		content, err := file.Open()
+		if err != nil {
+			r.log.Error(err)
+		} else {
+			defer content.Close()
+			dto.File = content
+		}
+	}
+
+	val := controllers.NewUploadSubtitleValidation(&dto)
+	serv := r.service()
+	ctrl := controllers.NewUploadSubtitleController(&serv, dto, val, params)
+	resp := ctrl.Handle(ctx.Request.Context())
+
+	respond(ctx, resp)
+}
+
+func (r *SubtitleRoutes) DeleteSubtitle(ctx *gin.Context) {
+	params := r.params(ctx)
+	params["language"] = ctx.Param("language")
+
+	serv := r.service()
+	ctrl := controllers.NewDeleteSubtitleController(&serv, params)
+	resp := ctrl.Handle(ctx.Request.Context())
+
+	respond(ctx, resp)
+}
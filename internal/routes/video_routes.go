@@ -0,0 +1,76 @@
+package routes
+
+import (
+	"database/sql"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/controllers"
+	"github.com/ayrtonsato/video-catalog-golang/internal/middlewares"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type VideoRoutes struct {
+	router *gin.Engine
+	db     *sql.DB
+	log    logger.Logger
+	media  MediaOptions
+}
+
+func NewVideoRoutes(router *gin.Engine, db *sql.DB, log logger.Logger, media MediaOptions) VideoRoutes {
+	return VideoRoutes{
+		router, db, log, media,
+	}
+}
+
+func (r VideoRoutes) Routes() {
+	r.router.GET("/video", r.GetVideos)
+	r.router.GET("/video/:id", r.GetSingleVideo)
+	r.router.PATCH("/video/:id/status", middlewares.Consumes(gin.MIMEJSON), r.UpdateVideoStatus)
+}
+
+func (r *VideoRoutes) GetVideos(ctx *gin.Context) {
+	var dto controllers.GetVideosDTO
+	if !bindQuery(ctx, &dto) {
+		return
+	}
+	val := controllers.NewGetVideosValidation(&dto)
+	repo := repositories.NewVideoRepository(r.db, r.log)
+	imageRepo := repositories.NewVideoImageRepository(r.db, r.log)
+	serv := services.NewGetVideosDBService(&repo, &imageRepo, r.media.urls())
+	ctrl := controllers.NewGetVideosController(&serv, dto, val)
+	resp := ctrl.Handle(ctx.Request.Context())
+
+	respond(ctx, resp)
+}
+
+func (r *VideoRoutes) GetSingleVideo(ctx *gin.Context) {
+	params := make(map[string]interface{})
+	params["id"] = middlewares.UUIDParam(ctx, "id")
+
+	repo := repositories.NewVideoRepository(r.db, r.log)
+	imageRepo := repositories.NewVideoImageRepository(r.db, r.log)
+	serv := services.NewGetVideosDBService(&repo, &imageRepo, r.media.urls())
+	ctrl := controllers.NewGetSingleVideoController(&serv, params)
+	resp := ctrl.Handle(ctx.Request.Context())
+
+	respond(ctx, resp)
+}
+
+func (r *VideoRoutes) UpdateVideoStatus(ctx *gin.Context) {
+	params := make(map[string]interface{})
+	params["id"] = middlewares.UUIDParam(ctx, "id")
+
+	var dto controllers.UpdateVideoStatusDTO
+	if !bindJSON(ctx, &dto) {
+		return
+	}
+	val := controllers.NewUpdateVideoStatusValidation(&dto)
+	repo := repositories.NewVideoRepository(r.db, r.log)
+	serv := services.NewUpdateVideoStatusDBService(&repo)
+	ctrl := controllers.NewUpdateVideoStatusController(&serv, dto, val, params)
+	resp := ctrl.Handle(ctx.Request.Context())
+
+	respond(ctx, resp)
+}
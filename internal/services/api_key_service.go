@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/auth"
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/internal/tracing"
+	"github.com/gofrs/uuid"
+)
+
+// apiKeyPrefix marks keys of this service so leaked ones are easy to find
+const apiKeyPrefix = "vck_"
+
+type APIKeys interface {
+	GetAPIKeys(ctx context.Context) ([]models.APIKey, error)
+	Issue(ctx context.Context, name string, scopes []string, expiresAt *time.Time, createdBy string) (models.IssuedAPIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
+}
+
+type APIKeysDBService struct {
+	repository repositories.APIKeyDB
+	now        func() time.Time
+}
+
+func NewAPIKeysDBService(repository repositories.APIKeyDB) APIKeysDBService {
+	return APIKeysDBService{
+		repository: repository,
+		now:        time.Now,
+	}
+}
+
+func (a *APIKeysDBService) GetAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	ctx, span := tracing.Start(ctx, "APIKeysDBService.GetAPIKeys")
+	defer span.End()
+	return a.repository.GetAll(ctx)
+}
+
+// Issue generates a random key and stores its SHA-256 hash, the key itself is
+// only returned here. expiresAt is stored in UTC whatever offset it was sent
+// with
+func (a *APIKeysDBService) Issue(ctx context.Context, name string,
+	scopes []string,
+	expiresAt *time.Time,
+	createdBy string) (models.IssuedAPIKey, error) {
+	ctx, span := tracing.Start(ctx, "APIKeysDBService.Issue")
+	defer span.End()
+	if expiresAt != nil {
+		utc := expiresAt.UTC()
+		expiresAt = &utc
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return models.IssuedAPIKey{}, err
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	saved, err := a.repository.Save(ctx, models.APIKey{
+		Name:      name,
+		Prefix:    key[:len(apiKeyPrefix)+8],
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+		CreatedBy: createdBy,
+	}, hashAPIKey(key))
+	if err != nil {
+		return models.IssuedAPIKey{}, ErrSaveFailed
+	}
+	return models.IssuedAPIKey{APIKey: saved, Key: key}, nil
+}
+
+func (a *APIKeysDBService) Revoke(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "APIKeysDBService.Revoke")
+	defer span.End()
+	err := a.repository.Revoke(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNoResult) {
+			return ErrNotFound
+		}
+		return ErrUpdateFailed
+	}
+	return nil
+}
+
+// Authenticate resolves an active key to a principal holding the scopes of
+// the key and no roles, scopes carry the fixed auth.ScopePermissions and are
+// not granted anything by the authorization policy
+func (a *APIKeysDBService) Authenticate(ctx context.Context, key string) (auth.Principal, error) {
+	ctx, span := tracing.Start(ctx, "APIKeysDBService.Authenticate")
+	defer span.End()
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return auth.Principal{}, ErrInvalidAPIKey
+	}
+	found, err := a.repository.GetByHash(ctx, hashAPIKey(key))
+	if err != nil {
+		if errors.Is(err, repositories.ErrNoResult) {
+			return auth.Principal{}, ErrInvalidAPIKey
+		}
+		return auth.Principal{}, err
+	}
+	if !found.Active(a.now()) {
+		return auth.Principal{}, ErrInvalidAPIKey
+	}
+	// last used tracking is informative, a failed update must not reject
+	// the request
+	_ = a.repository.Touch(ctx, found.ID)
+	return auth.Principal{Subject: "apikey:" + found.ID.String(), Scopes: found.Scopes}, nil
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/auth"
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	mock_repositories "github.com/ayrtonsato/video-catalog-golang/internal/repositories/mocks"
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeysDBService_Issue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock_repositories.NewMockAPIKeyDB(ctrl)
+	var storedHash string
+	repo.EXPECT().
+		Save(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, key models.APIKey, hash string) (models.APIKey, error) {
+			storedHash = hash
+			key.ID = uuid.Must(uuid.NewV4())
+			return key, nil
+		})
+	SUT := NewAPIKeysDBService(repo)
+	issued, err := SUT.Issue(context.Background(), "ingest", []string{models.ScopeMediaUpload}, nil, "admin-1")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(issued.Key, "vck_"))
+	require.Equal(t, issued.Key[:12], issued.Prefix)
+	require.Equal(t, hashAPIKey(issued.Key), storedHash)
+	require.NotContains(t, storedHash, issued.Key)
+}
+
+func TestAPIKeysDBService_Issue_ExpiresAtInUTC(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mock_repositories.NewMockAPIKeyDB(ctrl)
+	saoPaulo := time.FixedZone("-03", -3*60*60)
+	expiresAt := time.Date(2030, 1, 1, 21, 0, 0, 0, saoPaulo)
+	repo.EXPECT().
+		Save(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, key models.APIKey, hash string) (models.APIKey, error) {
+			require.Equal(t, time.UTC, key.ExpiresAt.Location())
+			require.Equal(t, time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC), *key.ExpiresAt)
+			return key, nil
+		})
+	SUT := NewAPIKeysDBService(repo)
+	_, err := SUT.Issue(context.Background(), "ingest", []string{models.ScopeMediaUpload}, &expiresAt, "admin-1")
+	require.NoError(t, err)
+	require.Equal(t, saoPaulo, expiresAt.Location())
+}
+
+func TestAPIKeysDBService_Authenticate(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	id := uuid.Must(uuid.NewV4())
+	key := "vck_secret"
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Should return a principal with the scopes as roles",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				repo := mock_repositories.NewMockAPIKeyDB(ctrl)
+				repo.EXPECT().GetByHash(gomock.Any(), hashAPIKey(key)).Times(1).
+					Return(models.APIKey{ID: id, Scopes: []string{models.ScopeCatalogRead}}, nil)
+				repo.EXPECT().Touch(gomock.Any(), id).Times(1).Return(repositories.ErrOnUpdate)
+				SUT := NewAPIKeysDBService(repo)
+				principal, err := SUT.Authenticate(context.Background(), key)
+				require.NoError(t, err)
+				require.Equal(t, auth.Principal{Subject: "apikey:" + id.String(), Scopes: []string{"catalog:read"}}, principal)
+			},
+		},
+		{
+			name: "Should reject an unknown key",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				repo := mock_repositories.NewMockAPIKeyDB(ctrl)
+				repo.EXPECT().GetByHash(gomock.Any(), hashAPIKey(key)).Times(1).Return(models.APIKey{}, repositories.ErrNoResult)
+				SUT := NewAPIKeysDBService(repo)
+				_, err := SUT.Authenticate(context.Background(), key)
+				require.ErrorIs(t, err, ErrInvalidAPIKey)
+			},
+		},
+		{
+			name: "Should reject expired and revoked keys",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				repo := mock_repositories.NewMockAPIKeyDB(ctrl)
+				repo.EXPECT().GetByHash(gomock.Any(), hashAPIKey(key)).Times(1).Return(models.APIKey{ID: id, ExpiresAt: &past}, nil)
+				repo.EXPECT().GetByHash(gomock.Any(), hashAPIKey(key)).Times(1).Return(models.APIKey{ID: id, RevokedAt: &past}, nil)
+				SUT := NewAPIKeysDBService(repo)
+				_, err := SUT.Authenticate(context.Background(), key)
+				require.ErrorIs(t, err, ErrInvalidAPIKey)
+				_, err = SUT.Authenticate(context.Background(), key)
+				require.ErrorIs(t, err, ErrInvalidAPIKey)
+			},
+		},
+		{
+			name: "Should reject keys without the prefix without a lookup",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				repo := mock_repositories.NewMockAPIKeyDB(ctrl)
+				SUT := NewAPIKeysDBService(repo)
+				_, err := SUT.Authenticate(context.Background(), "Bearer abc")
+				require.ErrorIs(t, err, ErrInvalidAPIKey)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tc.testCase(t, ctrl)
+		})
+	}
+}
@@ -1,27 +1,31 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"github.com/ayrtonsato/video-catalog-golang/internal/metrics"
 	"github.com/ayrtonsato/video-catalog-golang/internal/models"
 	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/internal/tracing"
 	"github.com/gofrs/uuid"
 	"time"
 )
 
 type ReaderCategory interface {
-	GetCategories() ([]models.Category, error)
-	GetCategory(id uuid.UUID) (models.Category, error)
+	GetCategories(ctx context.Context) ([]models.Category, error)
+	GetCategory(ctx context.Context, id uuid.UUID) (models.Category, error)
 }
 
 type WriterCategory interface {
-	Save(name string, description string) (models.Category, error)
+	Save(ctx context.Context, name string, description string) (models.Category, error)
 }
 
 type UpdateCategory interface {
-	Update(id uuid.UUID, name string, description string) error
+	Update(ctx context.Context, id uuid.UUID, name string, description string) error
 }
 
 type DeleteCategory interface {
-	Delete(id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
 }
 
 type GetCategoriesDbService struct {
@@ -34,13 +38,17 @@ func NewGetCategoriesDbService(category repositories.Category) GetCategoriesDbSe
 	}
 }
 
-func (g *GetCategoriesDbService) GetCategories() ([]models.Category, error) {
-	return g.category.GetCategories()
+func (g *GetCategoriesDbService) GetCategories(ctx context.Context) ([]models.Category, error) {
+	ctx, span := tracing.Start(ctx, "GetCategoriesDbService.GetCategories")
+	defer span.End()
+	return g.category.GetCategories(ctx)
 }
 
-func (g *GetCategoriesDbService) GetCategory(id uuid.UUID) (models.Category, error) {
-	category, err := g.category.GetByID(id)
-	if err == repositories.ErrNoResult {
+func (g *GetCategoriesDbService) GetCategory(ctx context.Context, id uuid.UUID) (models.Category, error) {
+	ctx, span := tracing.Start(ctx, "GetCategoriesDbService.GetCategory")
+	defer span.End()
+	category, err := g.category.GetByID(ctx, id)
+	if errors.Is(err, repositories.ErrNoResult) {
 		return category, ErrNotFound
 	}
 	return category, err
@@ -56,8 +64,15 @@ func NewSaveDbCategoryService(category repositories.Category) SaveDbCategoryServ
 	}
 }
 
-func (s *SaveDbCategoryService) Save(name string, description string) (models.Category, error) {
-	return s.category.Save(name, description)
+func (s *SaveDbCategoryService) Save(ctx context.Context, name string, description string) (models.Category, error) {
+	ctx, span := tracing.Start(ctx, "SaveDbCategoryService.Save")
+	defer span.End()
+	category, err := s.category.Save(ctx, name, description)
+	if err != nil {
+		return category, err
+	}
+	metrics.CountEvent("category", metrics.EventCreated)
+	return category, nil
 }
 
 type UpdateDbCategoryService struct {
@@ -70,12 +85,17 @@ func NewUpdateDbCategoryService(category repositories.Category) UpdateDbCategory
 	}
 }
 
-func (n *UpdateDbCategoryService) Update(id uuid.UUID, name string, description string) error {
-	_, err := n.category.GetByID(id)
+func (n *UpdateDbCategoryService) Update(ctx context.Context, id uuid.UUID, name string, description string) error {
+	ctx, span := tracing.Start(ctx, "UpdateDbCategoryService.Update")
+	defer span.End()
+	_, err := n.category.GetByID(ctx, id)
 	if err != nil {
-		return ErrNotFound
+		if errors.Is(err, repositories.ErrNoResult) {
+			return ErrNotFound
+		}
+		return err
 	}
-	err = n.category.Update(id, []string{"name", "description"}, name, description)
+	err = n.category.Update(ctx, id, []string{"name", "description"}, name, description)
 	if err != nil {
 		return ErrUpdateFailed
 	}
@@ -92,14 +112,20 @@ func NewDeleteDBCategoryService(category repositories.Category) DeleteDBCategory
 	}
 }
 
-func (d *DeleteDBCategoryService) Delete(id uuid.UUID) error {
-	_, err := d.category.GetByID(id)
+func (d *DeleteDBCategoryService) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "DeleteDBCategoryService.Delete")
+	defer span.End()
+	_, err := d.category.GetByID(ctx, id)
 	if err != nil {
-		return ErrNotFound
+		if errors.Is(err, repositories.ErrNoResult) {
+			return ErrNotFound
+		}
+		return err
 	}
-	err = d.category.Update(id, []string{"is_active", "deleted_at"}, false, time.Now().UTC())
+	err = d.category.Update(ctx, id, []string{"is_active", "deleted_at"}, false, time.Now().UTC())
 	if err != nil {
 		return ErrUpdateFailed
 	}
+	metrics.CountEvent("category", metrics.EventDeleted)
 	return nil
 }
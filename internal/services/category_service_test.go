@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"github.com/ayrtonsato/video-catalog-golang/internal/models"
 	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
@@ -36,12 +37,12 @@ func TestGetCategoriesDbService_GetCategories(t *testing.T) {
 				ctgRepository := mock_repositories.NewMockCategory(ctrl)
 				ctgRepository.
 					EXPECT().
-					GetCategories().
+					GetCategories(gomock.Any()).
 					Times(1)
 				SUT := GetCategoriesDbService{
 					category: ctgRepository,
 				}
-				_, _ = SUT.GetCategories()
+				_, _ = SUT.GetCategories(context.Background())
 			},
 		},
 		{
@@ -51,13 +52,13 @@ func TestGetCategoriesDbService_GetCategories(t *testing.T) {
 				ctgRepository := mock_repositories.NewMockCategory(ctrl)
 				ctgRepository.
 					EXPECT().
-					GetCategories().
+					GetCategories(gomock.Any()).
 					Times(1).
 					Return(listCategories, nil)
 				SUT := GetCategoriesDbService{
 					category: ctgRepository,
 				}
-				result, err := SUT.GetCategories()
+				result, err := SUT.GetCategories(context.Background())
 				require.NoError(t, err)
 				require.Equal(t, result, listCategories)
 			},
@@ -68,13 +69,13 @@ func TestGetCategoriesDbService_GetCategories(t *testing.T) {
 				ctgRepository := mock_repositories.NewMockCategory(ctrl)
 				ctgRepository.
 					EXPECT().
-					GetCategories().
+					GetCategories(gomock.Any()).
 					Times(1).
 					Return([]models.Category{}, errors.New("fake_error"))
 				SUT := GetCategoriesDbService{
 					category: ctgRepository,
 				}
-				_, err := SUT.GetCategories()
+				_, err := SUT.GetCategories(context.Background())
 				require.NotEmpty(t, err)
 				require.Equal(t, err.Error(), "fake_error")
 			},
@@ -114,12 +115,12 @@ func TestSaveDbCategory_Save(t *testing.T) {
 				ctgRepository := mock_repositories.NewMockCategory(ctrl)
 				ctgRepository.
 					EXPECT().
-					Save(gomock.Eq("valid_name"), gomock.Eq("valid_description")).
+					Save(gomock.Any(), gomock.Eq("valid_name"), gomock.Eq("valid_description")).
 					Times(1)
 				SUT := SaveDbCategoryService{
 					category: ctgRepository,
 				}
-				_, _ = SUT.Save("valid_name", "valid_description")
+				_, _ = SUT.Save(context.Background(), "valid_name", "valid_description")
 			},
 		},
 		{
@@ -128,13 +129,13 @@ func TestSaveDbCategory_Save(t *testing.T) {
 				ctgRepository := mock_repositories.NewMockCategory(ctrl)
 				ctgRepository.
 					EXPECT().
-					Save(gomock.Eq("valid_name"), gomock.Eq("valid_description")).
+					Save(gomock.Any(), gomock.Eq("valid_name"), gomock.Eq("valid_description")).
 					Times(1).
 					Return(fakeCategory, nil)
 				SUT := SaveDbCategoryService{
 					category: ctgRepository,
 				}
-				result, err := SUT.Save("valid_name", "valid_description")
+				result, err := SUT.Save(context.Background(), "valid_name", "valid_description")
 				require.NoError(t, err)
 				require.Equal(t, result, fakeCategory)
 			},
@@ -145,13 +146,13 @@ func TestSaveDbCategory_Save(t *testing.T) {
 				ctgRepository := mock_repositories.NewMockCategory(ctrl)
 				ctgRepository.
 					EXPECT().
-					Save(gomock.Any(), gomock.Any()).
+					Save(gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(models.Category{}, errors.New("fake_error"))
 				SUT := SaveDbCategoryService{
 					category: ctgRepository,
 				}
-				_, err := SUT.Save("valid_name", "valid_description")
+				_, err := SUT.Save(context.Background(), "valid_name", "valid_description")
 				require.NotEmpty(t, err)
 				require.Equal(t, err.Error(), "fake_error")
 			},
@@ -184,11 +185,11 @@ func TestUpdateDbCategoryService_Update(t *testing.T) {
 				ctgRepository := mock_repositories.NewMockCategory(ctrl)
 				ctgRepository.
 					EXPECT().
-					GetByID(uid).
+					GetByID(gomock.Any(), uid).
 					Return(models.Category{}, nil)
 				ctgRepository.
 					EXPECT().
-					Update(
+					Update(gomock.Any(),
 						gomock.Eq(uid),
 						gomock.Eq([]string{"name", "description"}),
 						gomock.Eq(fakeName),
@@ -197,7 +198,7 @@ func TestUpdateDbCategoryService_Update(t *testing.T) {
 				SUT := UpdateDbCategoryService{
 					category: ctgRepository,
 				}
-				err := SUT.Update(uid, fakeName, fakeDesc)
+				err := SUT.Update(context.Background(), uid, fakeName, fakeDesc)
 				require.NoError(t, err)
 			},
 		},
@@ -207,11 +208,11 @@ func TestUpdateDbCategoryService_Update(t *testing.T) {
 				ctgRepository := mock_repositories.NewMockCategory(ctrl)
 				ctgRepository.
 					EXPECT().
-					GetByID(uid).
+					GetByID(gomock.Any(), uid).
 					Return(models.Category{}, nil)
 				ctgRepository.
 					EXPECT().
-					Update(
+					Update(gomock.Any(),
 						gomock.Eq(uid),
 						gomock.Eq([]string{"name", "description"}),
 						gomock.Eq(fakeName),
@@ -220,7 +221,7 @@ func TestUpdateDbCategoryService_Update(t *testing.T) {
 				SUT := UpdateDbCategoryService{
 					category: ctgRepository,
 				}
-				err := SUT.Update(uid, fakeName, fakeDesc)
+				err := SUT.Update(context.Background(), uid, fakeName, fakeDesc)
 				require.Error(t, err)
 				require.True(t, err.Error() == ErrUpdateFailed.Error())
 			},
@@ -231,20 +232,37 @@ func TestUpdateDbCategoryService_Update(t *testing.T) {
 				ctgRepository := mock_repositories.NewMockCategory(ctrl)
 				ctgRepository.
 					EXPECT().
-					GetByID(uid).
-					Return(models.Category{}, ErrNotFound).Times(1)
+					GetByID(gomock.Any(), uid).
+					Return(models.Category{}, repositories.ErrNoResult).Times(1)
 				ctgRepository.
 					EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 				SUT := UpdateDbCategoryService{
 					category: ctgRepository,
 				}
-				err := SUT.Update(uid, fakeName, fakeDesc)
+				err := SUT.Update(context.Background(), uid, fakeName, fakeDesc)
 				require.Error(t, err)
 				require.ErrorIs(t, err, ErrNotFound)
 			},
 		},
+		{
+			name: "Should return lookup errors other than not found",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				ctgRepository := mock_repositories.NewMockCategory(ctrl)
+				lookupErr := errors.New("connection refused")
+				ctgRepository.
+					EXPECT().
+					GetByID(gomock.Any(), uid).
+					Return(models.Category{}, lookupErr).Times(1)
+				SUT := UpdateDbCategoryService{
+					category: ctgRepository,
+				}
+				err := SUT.Update(context.Background(), uid, fakeName, fakeDesc)
+				require.ErrorIs(t, err, lookupErr)
+				require.NotErrorIs(t, err, ErrNotFound)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -271,11 +289,11 @@ func TestDeleteDBCategoryService_Delete(t *testing.T) {
 				ctgRepository := mock_repositories.NewMockCategory(ctrl)
 				ctgRepository.
 					EXPECT().
-					GetByID(uid).
+					GetByID(gomock.Any(), uid).
 					Return(models.Category{}, nil)
 				ctgRepository.
 					EXPECT().
-					Update(
+					Update(gomock.Any(),
 						gomock.Eq(uid),
 						gomock.Eq([]string{"is_active", "deleted_at"}),
 						gomock.Eq(false),
@@ -284,7 +302,7 @@ func TestDeleteDBCategoryService_Delete(t *testing.T) {
 				SUT := DeleteDBCategoryService{
 					category: ctgRepository,
 				}
-				err := SUT.Delete(uid)
+				err := SUT.Delete(context.Background(), uid)
 				require.NoError(t, err)
 			},
 		},
@@ -294,11 +312,11 @@ func TestDeleteDBCategoryService_Delete(t *testing.T) {
 				ctgRepository := mock_repositories.NewMockCategory(ctrl)
 				ctgRepository.
 					EXPECT().
-					GetByID(uid).
+					GetByID(gomock.Any(), uid).
 					Return(models.Category{}, nil)
 				ctgRepository.
 					EXPECT().
-					Update(
+					Update(gomock.Any(),
 						gomock.Eq(uid),
 						gomock.Eq([]string{"is_active", "deleted_at"}),
 						gomock.Eq(false),
@@ -307,7 +325,7 @@ func TestDeleteDBCategoryService_Delete(t *testing.T) {
 				SUT := DeleteDBCategoryService{
 					category: ctgRepository,
 				}
-				err := SUT.Delete(uid)
+				err := SUT.Delete(context.Background(), uid)
 				require.Error(t, err)
 				require.True(t, err.Error() == ErrUpdateFailed.Error())
 			},
@@ -318,20 +336,37 @@ func TestDeleteDBCategoryService_Delete(t *testing.T) {
 				ctgRepository := mock_repositories.NewMockCategory(ctrl)
 				ctgRepository.
 					EXPECT().
-					GetByID(uid).
-					Return(models.Category{}, ErrNotFound).Times(1)
+					GetByID(gomock.Any(), uid).
+					Return(models.Category{}, repositories.ErrNoResult).Times(1)
 				ctgRepository.
 					EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 				SUT := DeleteDBCategoryService{
 					category: ctgRepository,
 				}
-				err := SUT.Delete(uid)
+				err := SUT.Delete(context.Background(), uid)
 				require.Error(t, err)
 				require.ErrorIs(t, err, ErrNotFound)
 			},
 		},
+		{
+			name: "Should return lookup errors other than not found",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				ctgRepository := mock_repositories.NewMockCategory(ctrl)
+				lookupErr := errors.New("connection refused")
+				ctgRepository.
+					EXPECT().
+					GetByID(gomock.Any(), uid).
+					Return(models.Category{}, lookupErr).Times(1)
+				SUT := DeleteDBCategoryService{
+					category: ctgRepository,
+				}
+				err := SUT.Delete(context.Background(), uid)
+				require.ErrorIs(t, err, lookupErr)
+				require.NotErrorIs(t, err, ErrNotFound)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -367,10 +402,10 @@ func TestGetCategoriesDbService_GetCategory(t *testing.T) {
 				ctgRepository := mock_repositories.NewMockCategory(ctrl)
 				ctgRepository.
 					EXPECT().
-					GetByID(uid).
+					GetByID(gomock.Any(), uid).
 					Return(fakeCategory, nil)
 				SUT := NewGetCategoriesDbService(ctgRepository)
-				category, err := SUT.GetCategory(uid)
+				category, err := SUT.GetCategory(context.Background(), uid)
 				require.NoError(t, err)
 				require.Equal(t, category, fakeCategory)
 			},
@@ -381,10 +416,10 @@ func TestGetCategoriesDbService_GetCategory(t *testing.T) {
 				ctgRepository := mock_repositories.NewMockCategory(ctrl)
 				ctgRepository.
 					EXPECT().
-					GetByID(uid).
+					GetByID(gomock.Any(), uid).
 					Return(models.Category{}, repositories.ErrNoResult)
 				SUT := NewGetCategoriesDbService(ctgRepository)
-				category, err := SUT.GetCategory(uid)
+				category, err := SUT.GetCategory(context.Background(), uid)
 				require.Error(t, err)
 				require.True(t, err.Error() == ErrNotFound.Error())
 				require.Equal(t, category, models.Category{})
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/internal/tracing"
+	"github.com/gofrs/uuid"
+)
+
+// MessageProcessor handles the raw payload of a message received from a
+// broker. ErrInvalidMessage, ErrNotFound and ErrConflict are permanent
+// failures, any other error is worth a redelivery.
+type MessageProcessor interface {
+	Process(ctx context.Context, payload []byte) error
+}
+
+type EncoderResultDBService struct {
+	videoRepository repositories.VideoDB
+	now             func() time.Time
+}
+
+func NewEncoderResultDBService(videoRepository repositories.VideoDB) EncoderResultDBService {
+	return EncoderResultDBService{
+		videoRepository: videoRepository,
+		now:             time.Now,
+	}
+}
+
+func (e *EncoderResultDBService) Process(ctx context.Context, payload []byte) error {
+	ctx, span := tracing.Start(ctx, "EncoderResultDBService.Process")
+	defer span.End()
+	var message models.EncoderMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+	}
+	if err := message.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+	}
+	processed, err := e.videoRepository.IsEncoderMessageProcessed(ctx, message.MessageID)
+	if err != nil {
+		return err
+	}
+	if processed {
+		return nil
+	}
+	video, err := e.videoRepository.GetByID(ctx, message.VideoUUID())
+	if err != nil {
+		if errors.Is(err, repositories.ErrNoResult) {
+			return ErrNotFound
+		}
+		return err
+	}
+	from := video.Status
+	at := message.EncodedAt
+	if at.IsZero() {
+		at = e.now()
+	}
+	if err = video.TransitionTo(message.Status, message.Error, at.UTC()); err != nil {
+		return fmt.Errorf("%w: %v", ErrConflict, err)
+	}
+	if message.Status == models.VideoStatusCompleted {
+		video.EncodedFiles = message.Outputs
+	}
+	err = e.videoRepository.ApplyEncoderResult(ctx, message.MessageID, video, from)
+	if errors.Is(err, repositories.ErrDuplicateMessage) {
+		return nil
+	}
+	return err
+}
+
+type DeadLetters interface {
+	GetDeadLetters(ctx context.Context, source string) ([]models.DeadLetter, error)
+	Replay(ctx context.Context, id uuid.UUID) error
+}
+
+type DeadLetterDBService struct {
+	deadLetterRepository repositories.DeadLetterDB
+	processors           map[string]MessageProcessor
+}
+
+// NewDeadLetterDBService builds the service, processors maps each dead
+// letter source to the processor able to replay its messages
+func NewDeadLetterDBService(deadLetterRepository repositories.DeadLetterDB,
+	processors map[string]MessageProcessor) DeadLetterDBService {
+	return DeadLetterDBService{
+		deadLetterRepository: deadLetterRepository,
+		processors:           processors,
+	}
+}
+
+func (d *DeadLetterDBService) GetDeadLetters(ctx context.Context, source string) ([]models.DeadLetter, error) {
+	ctx, span := tracing.Start(ctx, "DeadLetterDBService.GetDeadLetters")
+	defer span.End()
+	return d.deadLetterRepository.GetDeadLetters(ctx, source)
+}
+
+func (d *DeadLetterDBService) Replay(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "DeadLetterDBService.Replay")
+	defer span.End()
+	deadLetter, err := d.deadLetterRepository.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNoResult) {
+			return ErrNotFound
+		}
+		return err
+	}
+	if deadLetter.ReplayedAt != nil {
+		return ErrConflict
+	}
+	processor, ok := d.processors[deadLetter.Source]
+	if !ok {
+		return fmt.Errorf("%w: no processor for %v", ErrReplayFailed, deadLetter.Source)
+	}
+	if err = processor.Process(ctx, []byte(deadLetter.Payload)); err != nil {
+		return fmt.Errorf("%w: %v", ErrReplayFailed, err)
+	}
+	err = d.deadLetterRepository.MarkReplayed(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrStaleObject) {
+			return ErrConflict
+		}
+		return ErrUpdateFailed
+	}
+	return nil
+}
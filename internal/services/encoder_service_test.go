@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	mock_repositories "github.com/ayrtonsato/video-catalog-golang/internal/repositories/mocks"
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoderResultDBService_Process(t *testing.T) {
+	uid := uuid.Must(uuid.NewV4())
+	processingVideo := models.Video{Id: uid, Status: models.VideoStatusProcessing}
+	completedMessage := []byte(fmt.Sprintf(`{
+		"message_id": "message-1",
+		"video_id": "%v",
+		"status": "completed",
+		"outputs": ["videos/%v/encoded/manifest.mpd"],
+		"encoded_at": "2021-05-01T10:00:00Z"
+	}`, uid, uid))
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Should complete the video with the encoded files",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().IsEncoderMessageProcessed(gomock.Any(), "message-1").Times(1).Return(false, nil)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(processingVideo, nil)
+				videoRepo.EXPECT().
+					ApplyEncoderResult(gomock.Any(), "message-1", gomock.Any(), models.VideoStatusProcessing).
+					Times(1).
+					DoAndReturn(func(_ context.Context, messageID string, video models.Video, from models.VideoStatus) error {
+						require.Equal(t, models.VideoStatusCompleted, video.Status)
+						require.Equal(t, time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC), *video.CompletedAt)
+						require.Equal(t, models.EncodedFiles{"videos/" + uid.String() + "/encoded/manifest.mpd"},
+							video.EncodedFiles)
+						return nil
+					})
+				SUT := NewEncoderResultDBService(videoRepo)
+				require.NoError(t, SUT.Process(context.Background(), completedMessage))
+			},
+		},
+		{
+			name: "Should skip messages already processed",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().IsEncoderMessageProcessed(gomock.Any(), "message-1").Times(1).Return(true, nil)
+				SUT := NewEncoderResultDBService(videoRepo)
+				require.NoError(t, SUT.Process(context.Background(), completedMessage))
+			},
+		},
+		{
+			name: "Should ignore a duplicate detected while saving",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().IsEncoderMessageProcessed(gomock.Any(), "message-1").Times(1).Return(false, nil)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(processingVideo, nil)
+				videoRepo.EXPECT().
+					ApplyEncoderResult(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(repositories.ErrDuplicateMessage)
+				SUT := NewEncoderResultDBService(videoRepo)
+				require.NoError(t, SUT.Process(context.Background(), completedMessage))
+			},
+		},
+		{
+			name: "Should return ErrInvalidMessage for malformed json",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				SUT := NewEncoderResultDBService(videoRepo)
+				require.ErrorIs(t, SUT.Process(context.Background(), []byte("{not json")), ErrInvalidMessage)
+			},
+		},
+		{
+			name: "Should return ErrInvalidMessage when schema is not respected",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				SUT := NewEncoderResultDBService(videoRepo)
+				err := SUT.Process(context.Background(), []byte(`{"message_id": "message-1", "video_id": "not-an-uuid", "status": "completed"}`))
+				require.ErrorIs(t, err, ErrInvalidMessage)
+			},
+		},
+		{
+			name: "Should return ErrInvalidMessage for outputs outside the encoded directory",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				SUT := NewEncoderResultDBService(videoRepo)
+				for _, output := range []string{
+					"videos/%v/manifest.mpd",
+					"videos/%v/encoded/../video/source.mp4",
+					"videos/%v/encoded/",
+					"other/%v/encoded/manifest.mpd",
+				} {
+					message := fmt.Sprintf(`{"message_id": "message-1", "video_id": "%v", "status": "completed", "outputs": [%q]}`,
+						uid, fmt.Sprintf(output, uid))
+					require.ErrorIs(t, SUT.Process(context.Background(), []byte(message)), ErrInvalidMessage, output)
+				}
+			},
+		},
+		{
+			name: "Should return ErrConflict on illegal transition",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().IsEncoderMessageProcessed(gomock.Any(), "message-1").Times(1).Return(false, nil)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).
+					Return(models.Video{Id: uid, Status: models.VideoStatusPending}, nil)
+				SUT := NewEncoderResultDBService(videoRepo)
+				require.ErrorIs(t, SUT.Process(context.Background(), completedMessage), ErrConflict)
+			},
+		},
+		{
+			name: "Should return ErrNotFound when video does not exist",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().IsEncoderMessageProcessed(gomock.Any(), "message-1").Times(1).Return(false, nil)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(models.Video{}, repositories.ErrNoResult)
+				SUT := NewEncoderResultDBService(videoRepo)
+				require.ErrorIs(t, SUT.Process(context.Background(), completedMessage), ErrNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tc.testCase(t, ctrl)
+		})
+	}
+}
+
+type processorFunc func(payload []byte) error
+
+func (f processorFunc) Process(_ context.Context, payload []byte) error {
+	return f(payload)
+}
+
+func TestDeadLetterDBService_Replay(t *testing.T) {
+	uid := uuid.Must(uuid.NewV4())
+	deadLetter := models.DeadLetter{
+		ID:      uid,
+		Source:  "encoder",
+		Payload: `{"message_id": "message-1"}`,
+		Reason:  "service: invalid message",
+	}
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Should process the payload and mark it replayed",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				deadLetterRepo := mock_repositories.NewMockDeadLetterDB(ctrl)
+				deadLetterRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(deadLetter, nil)
+				processor := processorFunc(func(payload []byte) error {
+					require.Equal(t, []byte(deadLetter.Payload), payload)
+					return nil
+				})
+				deadLetterRepo.EXPECT().MarkReplayed(gomock.Any(), uid).Times(1).Return(nil)
+				SUT := NewDeadLetterDBService(deadLetterRepo, map[string]MessageProcessor{"encoder": processor})
+				require.NoError(t, SUT.Replay(context.Background(), uid))
+			},
+		},
+		{
+			name: "Should return ErrReplayFailed when processing fails again",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				deadLetterRepo := mock_repositories.NewMockDeadLetterDB(ctrl)
+				deadLetterRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(deadLetter, nil)
+				processor := processorFunc(func(payload []byte) error {
+					return ErrInvalidMessage
+				})
+				SUT := NewDeadLetterDBService(deadLetterRepo, map[string]MessageProcessor{"encoder": processor})
+				err := SUT.Replay(context.Background(), uid)
+				require.ErrorIs(t, err, ErrReplayFailed)
+			},
+		},
+		{
+			name: "Should return ErrConflict when already replayed",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				replayedAt := time.Now()
+				replayed := deadLetter
+				replayed.ReplayedAt = &replayedAt
+				deadLetterRepo := mock_repositories.NewMockDeadLetterDB(ctrl)
+				deadLetterRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(replayed, nil)
+				SUT := NewDeadLetterDBService(deadLetterRepo, map[string]MessageProcessor{})
+				require.ErrorIs(t, SUT.Replay(context.Background(), uid), ErrConflict)
+			},
+		},
+		{
+			name: "Should return ErrNotFound when dead letter does not exist",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				deadLetterRepo := mock_repositories.NewMockDeadLetterDB(ctrl)
+				deadLetterRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(models.DeadLetter{}, repositories.ErrNoResult)
+				SUT := NewDeadLetterDBService(deadLetterRepo, map[string]MessageProcessor{})
+				require.ErrorIs(t, SUT.Replay(context.Background(), uid), ErrNotFound)
+			},
+		},
+		{
+			name: "Should return error when repository fails",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				deadLetterRepo := mock_repositories.NewMockDeadLetterDB(ctrl)
+				deadLetterRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(models.DeadLetter{}, errors.New("fake_error"))
+				SUT := NewDeadLetterDBService(deadLetterRepo, map[string]MessageProcessor{})
+				require.EqualError(t, SUT.Replay(context.Background(), uid), "fake_error")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tc.testCase(t, ctrl)
+		})
+	}
+}
@@ -3,7 +3,16 @@ package services
 import "errors"
 
 var (
-	ErrNotFound     = errors.New("service: object not found")
-	ErrUpdateFailed = errors.New("service: failed to update object")
-	ErrSaveFailed   = errors.New("service: failed to save object")
+	ErrNotFound        = errors.New("service: object not found")
+	ErrUpdateFailed    = errors.New("service: failed to update object")
+	ErrSaveFailed      = errors.New("service: failed to save object")
+	ErrForbidden       = errors.New("service: access denied")
+	ErrConflict        = errors.New("service: object state conflict")
+	ErrInvalidImage    = errors.New("service: invalid image")
+	ErrInvalidVideo    = errors.New("service: invalid video file")
+	ErrInvalidSubtitle = errors.New("service: invalid subtitle")
+	ErrInvalidAPIKey   = errors.New("service: invalid api key")
+
+	ErrInvalidMessage = errors.New("service: invalid message")
+	ErrReplayFailed   = errors.New("service: failed to replay message")
 )
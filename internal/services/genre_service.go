@@ -1,15 +1,18 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"github.com/ayrtonsato/video-catalog-golang/internal/metrics"
 	"github.com/ayrtonsato/video-catalog-golang/internal/models"
 	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/internal/tracing"
 	"github.com/gofrs/uuid"
 )
 
 type ReaderGenre interface {
-	GetGenres() ([]models.Category, error)
-	GetGenreByID(id uuid.UUID) (models.Genre, error)
+	GetGenres(ctx context.Context) ([]models.Category, error)
+	GetGenreByID(ctx context.Context, id uuid.UUID) (models.Genre, error)
 }
 
 type GetGenresDBService struct {
@@ -22,20 +25,24 @@ func NewGetGenresDBService(genreRepository repositories.GenreDB) GetGenresDBServ
 	}
 }
 
-func (g *GetGenresDBService) GetGenres() ([]models.Genre, error) {
-	return g.genreRepository.GetGenres()
+func (g *GetGenresDBService) GetGenres(ctx context.Context) ([]models.Genre, error) {
+	ctx, span := tracing.Start(ctx, "GetGenresDBService.GetGenres")
+	defer span.End()
+	return g.genreRepository.GetGenres(ctx)
 }
 
-func (g *GetGenresDBService) GetGenreByID(id uuid.UUID) (models.Genre, error) {
-	genre, err := g.genreRepository.GetByID(id)
-	if err == repositories.ErrNoResult {
+func (g *GetGenresDBService) GetGenreByID(ctx context.Context, id uuid.UUID) (models.Genre, error) {
+	ctx, span := tracing.Start(ctx, "GetGenresDBService.GetGenreByID")
+	defer span.End()
+	genre, err := g.genreRepository.GetByID(ctx, id)
+	if errors.Is(err, repositories.ErrNoResult) {
 		return genre, ErrNotFound
 	}
 	return genre, err
 }
 
 type SaveGenre interface {
-	Save(name string, categories []uuid.UUID) (models.Genre, error)
+	Save(ctx context.Context, name string, categories []uuid.UUID) (models.Genre, error)
 }
 
 type SaveGenreDBService struct {
@@ -48,16 +55,19 @@ func NewSaveGenreDBService(genreRepository repositories.GenreDB) SaveGenreDBServ
 	}
 }
 
-func (s *SaveGenreDBService) Save(name string, categories []uuid.UUID) (models.Genre, error) {
-	genre, err := s.genreRepository.Save(name, categories)
+func (s *SaveGenreDBService) Save(ctx context.Context, name string, categories []uuid.UUID) (models.Genre, error) {
+	ctx, span := tracing.Start(ctx, "SaveGenreDBService.Save")
+	defer span.End()
+	genre, err := s.genreRepository.Save(ctx, name, categories)
 	if err != nil {
 		return models.Genre{}, ErrSaveFailed
 	}
+	metrics.CountEvent("genre", metrics.EventCreated)
 	return genre, nil
 }
 
 type UpdateGenre interface {
-	Update(id uuid.UUID, name string, categories []uuid.UUID) error
+	Update(ctx context.Context, id uuid.UUID, name string, categories []uuid.UUID) error
 }
 
 type UpdateGenreDBService struct {
@@ -70,12 +80,17 @@ func NewUpdateGenreDBService(genreRepository repositories.GenreDB) UpdateGenreDB
 	}
 }
 
-func (u *UpdateGenreDBService) Update(id uuid.UUID, name string) error {
-	_, err := u.genreRepository.GetByID(id)
+func (u *UpdateGenreDBService) Update(ctx context.Context, id uuid.UUID, name string) error {
+	ctx, span := tracing.Start(ctx, "UpdateGenreDBService.Update")
+	defer span.End()
+	_, err := u.genreRepository.GetByID(ctx, id)
 	if err != nil {
-		return ErrNotFound
+		if errors.Is(err, repositories.ErrNoResult) {
+			return ErrNotFound
+		}
+		return err
 	}
-	err = u.genreRepository.Update(id, []string{"name"}, name)
+	err = u.genreRepository.Update(ctx, id, []string{"name"}, name)
 	if err != nil {
 		return ErrUpdateFailed
 	}
@@ -83,7 +98,7 @@ func (u *UpdateGenreDBService) Update(id uuid.UUID, name string) error {
 }
 
 type DeleteGenre interface {
-	Delete(id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
 }
 
 type DeleteGenreDBService struct {
@@ -96,21 +111,24 @@ func NewDeleteGenreDBService(genreRepository repositories.GenreDB) DeleteGenreDB
 	}
 }
 
-func (d *DeleteGenreDBService) Delete(id uuid.UUID) error {
-	genre, err := d.genreRepository.GetByID(id)
+func (d *DeleteGenreDBService) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "DeleteGenreDBService.Delete")
+	defer span.End()
+	genre, err := d.genreRepository.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, repositories.ErrNoResult) {
 			return ErrNotFound
 		}
-		return ErrUpdateFailed
+		return err
 	}
 	err = d.genreRepository.
-		Delete(genre)
+		Delete(ctx, genre)
 	if err != nil {
 		if errors.Is(err, repositories.ErrNoResult) {
 			return ErrNotFound
 		}
 		return ErrUpdateFailed
 	}
+	metrics.CountEvent("genre", metrics.EventDeleted)
 	return nil
 }
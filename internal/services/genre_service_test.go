@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"github.com/ayrtonsato/video-catalog-golang/internal/models"
 	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
@@ -36,11 +37,11 @@ func TestGetGenresDBService_GetGenres(t *testing.T) {
 				genreRepo := mock_repositories.NewMockGenreDB(ctrl)
 				genreRepo.
 					EXPECT().
-					GetGenres().
+					GetGenres(gomock.Any()).
 					Times(1).
 					Return(listGenres, nil)
 				SUT := NewGetGenresDBService(genreRepo)
-				result, err := SUT.GetGenres()
+				result, err := SUT.GetGenres(context.Background())
 				require.NoError(t, err)
 				require.Equal(t, result, listGenres)
 			},
@@ -51,11 +52,11 @@ func TestGetGenresDBService_GetGenres(t *testing.T) {
 				genreRepo := mock_repositories.NewMockGenreDB(ctrl)
 				genreRepo.
 					EXPECT().
-					GetGenres().
+					GetGenres(gomock.Any()).
 					Times(1).
 					Return([]models.Genre{}, errors.New("fake_error"))
 				SUT := NewGetGenresDBService(genreRepo)
-				_, err := SUT.GetGenres()
+				_, err := SUT.GetGenres(context.Background())
 				require.NotEmpty(t, err)
 				require.Equal(t, err.Error(), "fake_error")
 			},
@@ -94,11 +95,11 @@ func TestGetGenresDBService_GetGenreByID(t *testing.T) {
 				genreRepo := mock_repositories.NewMockGenreDB(ctrl)
 				genreRepo.
 					EXPECT().
-					GetByID(uid).
+					GetByID(gomock.Any(), uid).
 					Times(1).
 					Return(fakeGenre, nil)
 				SUT := NewGetGenresDBService(genreRepo)
-				result, err := SUT.GetGenreByID(uid)
+				result, err := SUT.GetGenreByID(context.Background(), uid)
 				require.NoError(t, err)
 				require.Equal(t, result, fakeGenre)
 			},
@@ -109,11 +110,11 @@ func TestGetGenresDBService_GetGenreByID(t *testing.T) {
 				genreRepo := mock_repositories.NewMockGenreDB(ctrl)
 				genreRepo.
 					EXPECT().
-					GetByID(uid).
+					GetByID(gomock.Any(), uid).
 					Times(1).
 					Return(models.Genre{}, repositories.ErrNoResult)
 				SUT := NewGetGenresDBService(genreRepo)
-				result, err := SUT.GetGenreByID(uid)
+				result, err := SUT.GetGenreByID(context.Background(), uid)
 				require.True(t, err.Error() == ErrNotFound.Error())
 				require.Equal(t, result, models.Genre{})
 			},
@@ -153,11 +154,11 @@ func TestGetGenresDBService_Save(t *testing.T) {
 				genreRepo := mock_repositories.NewMockGenreDB(ctrl)
 				genreRepo.
 					EXPECT().
-					Save(gomock.Eq(fakeGenre.Name), gomock.Eq(listCategories)).
+					Save(gomock.Any(), gomock.Eq(fakeGenre.Name), gomock.Eq(listCategories)).
 					Times(1).
 					Return(fakeGenre, nil)
 				SUT := NewSaveGenreDBService(genreRepo)
-				result, err := SUT.Save("valid_name", listCategories)
+				result, err := SUT.Save(context.Background(), "valid_name", listCategories)
 				require.NoError(t, err)
 				require.Equal(t, result, fakeGenre)
 			},
@@ -168,11 +169,11 @@ func TestGetGenresDBService_Save(t *testing.T) {
 				genreRepo := mock_repositories.NewMockGenreDB(ctrl)
 				genreRepo.
 					EXPECT().
-					Save(gomock.Eq(fakeGenre.Name), gomock.Eq(listCategories)).
+					Save(gomock.Any(), gomock.Eq(fakeGenre.Name), gomock.Eq(listCategories)).
 					Times(1).
 					Return(models.Genre{}, errors.New("fake_error"))
 				SUT := NewSaveGenreDBService(genreRepo)
-				_, err := SUT.Save("valid_name", listCategories)
+				_, err := SUT.Save(context.Background(), "valid_name", listCategories)
 				require.NotEmpty(t, err)
 				require.ErrorIs(t, err, ErrSaveFailed)
 			},
@@ -210,18 +211,18 @@ func TestGetGenresDBService_Update(t *testing.T) {
 				genreRepo := mock_repositories.NewMockGenreDB(ctrl)
 				genreRepo.
 					EXPECT().
-					GetByID(gomock.Eq(uid)).
+					GetByID(gomock.Any(), gomock.Eq(uid)).
 					Times(1).
 					Return(fakeGenre, nil)
 				genreRepo.
 					EXPECT().
-					Update(
+					Update(gomock.Any(),
 						gomock.Eq(uid),
 						gomock.Eq(fields),
 						gomock.Eq(fakeName)).
 					Times(1)
 				SUT := NewUpdateGenreDBService(genreRepo)
-				err := SUT.Update(uid, "fake_name")
+				err := SUT.Update(context.Background(), uid, "fake_name")
 				require.NoError(t, err)
 			},
 		},
@@ -231,18 +232,18 @@ func TestGetGenresDBService_Update(t *testing.T) {
 				genreRepo := mock_repositories.NewMockGenreDB(ctrl)
 				genreRepo.
 					EXPECT().
-					GetByID(gomock.Eq(uid)).
+					GetByID(gomock.Any(), gomock.Eq(uid)).
 					Times(1).
 					Return(fakeGenre, nil)
 				genreRepo.
 					EXPECT().
-					Update(
+					Update(gomock.Any(),
 						gomock.Eq(uid),
 						gomock.Eq(fields),
 						gomock.Eq(fakeName)).
 					Times(1).Return(ErrUpdateFailed)
 				SUT := NewUpdateGenreDBService(genreRepo)
-				err := SUT.Update(uid, "fake_name")
+				err := SUT.Update(context.Background(), uid, "fake_name")
 				require.Error(t, err)
 				require.True(t, err.Error() == ErrUpdateFailed.Error())
 			},
@@ -253,22 +254,38 @@ func TestGetGenresDBService_Update(t *testing.T) {
 				genreRepo := mock_repositories.NewMockGenreDB(ctrl)
 				genreRepo.
 					EXPECT().
-					GetByID(gomock.Eq(uid)).
+					GetByID(gomock.Any(), gomock.Eq(uid)).
 					Times(1).
-					Return(models.Genre{}, ErrNotFound)
+					Return(models.Genre{}, repositories.ErrNoResult)
 				genreRepo.
 					EXPECT().
-					Update(
+					Update(gomock.Any(),
 						gomock.Eq(uid),
 						gomock.Eq(fields),
 						gomock.Eq(fakeName)).
 					Times(0)
 				SUT := NewUpdateGenreDBService(genreRepo)
-				err := SUT.Update(uid, "fake_name")
+				err := SUT.Update(context.Background(), uid, "fake_name")
 				require.Error(t, err)
 				require.ErrorIs(t, err, ErrNotFound)
 			},
 		},
+		{
+			name: "Should return lookup errors other than not found",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				genreRepo := mock_repositories.NewMockGenreDB(ctrl)
+				lookupErr := errors.New("connection refused")
+				genreRepo.
+					EXPECT().
+					GetByID(gomock.Any(), gomock.Eq(uid)).
+					Times(1).
+					Return(models.Genre{}, lookupErr)
+				SUT := NewUpdateGenreDBService(genreRepo)
+				err := SUT.Update(context.Background(), uid, "fake_name")
+				require.ErrorIs(t, err, lookupErr)
+				require.NotErrorIs(t, err, ErrNotFound)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -299,14 +316,14 @@ func TestDeleteGenreDBService_Delete(t *testing.T) {
 				genreRepo := mock_repositories.NewMockGenreDB(ctrl)
 				genreRepo.
 					EXPECT().
-					GetByID(gomock.Eq(fakeGenre.ID)).
+					GetByID(gomock.Any(), gomock.Eq(fakeGenre.ID)).
 					Times(1).Return(fakeGenre, nil)
 				genreRepo.
 					EXPECT().
-					Delete(gomock.Eq(fakeGenre)).
+					Delete(gomock.Any(), gomock.Eq(fakeGenre)).
 					Times(1).Return(nil)
 				SUT := NewDeleteGenreDBService(genreRepo)
-				err := SUT.Delete(fakeGenre.ID)
+				err := SUT.Delete(context.Background(), fakeGenre.ID)
 				require.NoError(t, err)
 			},
 		},
@@ -316,32 +333,47 @@ func TestDeleteGenreDBService_Delete(t *testing.T) {
 				genreRepo := mock_repositories.NewMockGenreDB(ctrl)
 				genreRepo.
 					EXPECT().
-					GetByID(gomock.Eq(fakeGenre.ID)).
+					GetByID(gomock.Any(), gomock.Eq(fakeGenre.ID)).
 					Times(1).Return(models.Genre{}, repositories.ErrNoResult)
 				genreRepo.
 					EXPECT().
-					Delete(gomock.Eq(fakeGenre.ID)).
+					Delete(gomock.Any(), gomock.Eq(fakeGenre.ID)).
 					Times(0)
 				SUT := NewDeleteGenreDBService(genreRepo)
-				err := SUT.Delete(fakeGenre.ID)
+				err := SUT.Delete(context.Background(), fakeGenre.ID)
 				require.Error(t, err)
 				require.ErrorIs(t, err, ErrNotFound)
 			},
 		},
+		{
+			name: "Return lookup errors other than not found",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				genreRepo := mock_repositories.NewMockGenreDB(ctrl)
+				lookupErr := errors.New("connection refused")
+				genreRepo.
+					EXPECT().
+					GetByID(gomock.Any(), gomock.Eq(fakeGenre.ID)).
+					Times(1).Return(models.Genre{}, lookupErr)
+				SUT := NewDeleteGenreDBService(genreRepo)
+				err := SUT.Delete(context.Background(), fakeGenre.ID)
+				require.ErrorIs(t, err, lookupErr)
+				require.NotErrorIs(t, err, ErrNotFound)
+			},
+		},
 		{
 			name: "Throw ErrUpdateFailed when update genre failed",
 			testCase: func(t *testing.T, ctrl *gomock.Controller) {
 				genreRepo := mock_repositories.NewMockGenreDB(ctrl)
 				genreRepo.
 					EXPECT().
-					GetByID(gomock.Eq(fakeGenre.ID)).
+					GetByID(gomock.Any(), gomock.Eq(fakeGenre.ID)).
 					Times(1).Return(fakeGenre, nil)
 				genreRepo.
 					EXPECT().
-					Delete(gomock.Eq(fakeGenre)).
+					Delete(gomock.Any(), gomock.Eq(fakeGenre)).
 					Times(1).Return(repositories.ErrOnUpdate)
 				SUT := NewDeleteGenreDBService(genreRepo)
-				err := SUT.Delete(fakeGenre.ID)
+				err := SUT.Delete(context.Background(), fakeGenre.ID)
 				require.Error(t, err)
 				require.ErrorIs(t, err, ErrUpdateFailed)
 			},
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/internal/tracing"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/imagevariant"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/storage"
+	"github.com/gofrs/uuid"
+)
+
+type ImageOptions struct {
+	Widths      []int
+	JPEGQuality int
+}
+
+type UploadVideoImage interface {
+	Upload(ctx context.Context, id uuid.UUID, kind models.VideoFileKind, image io.Reader) (models.Video, error)
+}
+
+type UploadVideoImageDBService struct {
+	videoRepository repositories.VideoDB
+	imageRepository repositories.VideoImageDB
+	storage         storage.Storage
+	urls            MediaURLs
+	options         ImageOptions
+}
+
+func NewUploadVideoImageDBService(videoRepository repositories.VideoDB,
+	imageRepository repositories.VideoImageDB,
+	storage storage.Storage,
+	urls MediaURLs,
+	options ImageOptions) UploadVideoImageDBService {
+	return UploadVideoImageDBService{
+		videoRepository: videoRepository,
+		imageRepository: imageRepository,
+		storage:         storage,
+		urls:            urls,
+		options:         options,
+	}
+}
+
+// Upload stores the original image of kind and its resized variants under
+// videos/{id}/{kind}/ then returns the video with the urls of every variant
+func (u *UploadVideoImageDBService) Upload(ctx context.Context, id uuid.UUID,
+	kind models.VideoFileKind,
+	image io.Reader) (models.Video, error) {
+	ctx, span := tracing.Start(ctx, "UploadVideoImageDBService.Upload")
+	defer span.End()
+	video, err := u.videoRepository.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNoResult) {
+			return models.Video{}, ErrNotFound
+		}
+		return models.Video{}, err
+	}
+	var original bytes.Buffer
+	src, format, err := imagevariant.Decode(io.TeeReader(image, &original))
+	if errors.Is(err, imagevariant.ErrImageTooLarge) {
+		return models.Video{}, fmt.Errorf("%w: more than %d pixels", ErrInvalidImage, imagevariant.MaxPixels)
+	}
+	if err != nil {
+		return models.Video{}, ErrInvalidImage
+	}
+	generated, err := imagevariant.Generate(src, format, u.options.Widths, u.options.JPEGQuality)
+	if err != nil {
+		return models.Video{}, ErrInvalidImage
+	}
+	originalData, err := imagevariant.Original(original.Bytes(), src, format, u.options.JPEGQuality)
+	if err != nil {
+		return models.Video{}, ErrInvalidImage
+	}
+
+	dir := fmt.Sprintf("videos/%s/%s", id, kind)
+	originalPath := fmt.Sprintf("%s/original.%s", dir, extension(format))
+	if err = u.storage.Put(originalPath, bytes.NewReader(originalData)); err != nil {
+		return models.Video{}, err
+	}
+	variants := make([]models.ImageVariant, 0, len(generated))
+	for _, variant := range generated {
+		path := fmt.Sprintf("%s/%dw.%s", dir, variant.Width, extension(variant.Format))
+		if err = u.storage.Put(path, bytes.NewReader(variant.Data)); err != nil {
+			return models.Video{}, err
+		}
+		variants = append(variants, models.ImageVariant{
+			Kind:   kind,
+			Width:  variant.Width,
+			Height: variant.Height,
+			Format: variant.Format,
+			Path:   path,
+		})
+	}
+
+	err = u.imageRepository.SaveVariants(ctx, id, kind, originalPath, variants)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNoResult) {
+			return models.Video{}, ErrNotFound
+		}
+		return models.Video{}, ErrSaveFailed
+	}
+	switch kind {
+	case models.VideoFileKindThumb:
+		video.ThumbFile = &originalPath
+	case models.VideoFileKindBanner:
+		video.BannerFile = &originalPath
+	}
+
+	images, err := u.imageRepository.GetByVideoIDs(ctx, []uuid.UUID{id})
+	if err != nil {
+		return models.Video{}, err
+	}
+	u.urls.SignImages(images[id])
+	video.SetImages(images[id])
+	return video, nil
+}
+
+func extension(format string) string {
+	if format == imagevariant.FormatPNG {
+		return "png"
+	}
+	return "jpg"
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/gif"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	mock_repositories "github.com/ayrtonsato/video-catalog-golang/internal/repositories/mocks"
+	mock_storage "github.com/ayrtonsato/video-catalog-golang/pkg/storage/mocks"
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadVideoImageDBService_Upload(t *testing.T) {
+	uid := uuid.Must(uuid.NewV4())
+	fakeVideo := models.Video{Id: uid, Title: "fake_title"}
+	var pngImage bytes.Buffer
+	require.NoError(t, png.Encode(&pngImage, image.NewRGBA(image.Rect(0, 0, 800, 400))))
+	options := ImageOptions{Widths: []int{320, 640, 1280}, JPEGQuality: 85}
+	urls := NewMediaURLs(newTestSigner(t), "http://localhost", time.Minute)
+	dir := "videos/" + uid.String() + "/thumb/"
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Should store the original and its variants",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				imageRepo := mock_repositories.NewMockVideoImageDB(ctrl)
+				store := mock_storage.NewMockStorage(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(fakeVideo, nil)
+				store.EXPECT().Put(dir+"original.png", gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().Put(dir+"320w.png", gomock.Any()).Times(1).Return(nil)
+				store.EXPECT().Put(dir+"640w.png", gomock.Any()).Times(1).Return(nil)
+				var saved []models.ImageVariant
+				imageRepo.EXPECT().
+					SaveVariants(gomock.Any(), uid, models.VideoFileKindThumb, dir+"original.png", gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, id uuid.UUID, kind models.VideoFileKind, original string,
+						variants []models.ImageVariant) error {
+						require.Len(t, variants, 2)
+						require.Equal(t, 160, variants[0].Height)
+						saved = variants
+						return nil
+					})
+				imageRepo.EXPECT().
+					GetByVideoIDs(gomock.Any(), []uuid.UUID{uid}).
+					Times(1).
+					DoAndReturn(func(_ context.Context, ids []uuid.UUID) (map[uuid.UUID][]models.ImageVariant, error) {
+						return map[uuid.UUID][]models.ImageVariant{uid: saved}, nil
+					})
+				SUT := NewUploadVideoImageDBService(videoRepo, imageRepo, store, urls, options)
+				video, err := SUT.Upload(context.Background(), uid, models.VideoFileKindThumb, bytes.NewReader(pngImage.Bytes()))
+				require.NoError(t, err)
+				require.Equal(t, dir+"original.png", *video.ThumbFile)
+				require.Len(t, video.Thumbnails, 2)
+				require.True(t, strings.HasPrefix(video.Thumbnails[1].URL, "http://localhost/media/"+dir+"640w.png?"))
+			},
+		},
+		{
+			name: "Should store a gif original as jpeg",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				var gifImage bytes.Buffer
+				require.NoError(t, gif.Encode(&gifImage, image.NewRGBA(image.Rect(0, 0, 100, 50)), nil))
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				imageRepo := mock_repositories.NewMockVideoImageDB(ctrl)
+				store := mock_storage.NewMockStorage(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(fakeVideo, nil)
+				store.EXPECT().Put(dir+"original.jpg", gomock.Any()).Times(1).
+					DoAndReturn(func(_ string, r io.Reader) error {
+						_, source, err := image.DecodeConfig(r)
+						require.NoError(t, err)
+						require.Equal(t, "jpeg", source)
+						return nil
+					})
+				store.EXPECT().Put(dir+"100w.jpg", gomock.Any()).Times(1).Return(nil)
+				imageRepo.EXPECT().
+					SaveVariants(gomock.Any(), uid, models.VideoFileKindThumb, dir+"original.jpg", gomock.Any()).
+					Times(1).
+					Return(nil)
+				imageRepo.EXPECT().GetByVideoIDs(gomock.Any(), []uuid.UUID{uid}).Times(1).Return(nil, nil)
+				SUT := NewUploadVideoImageDBService(videoRepo, imageRepo, store, urls, options)
+				video, err := SUT.Upload(context.Background(), uid, models.VideoFileKindThumb, bytes.NewReader(gifImage.Bytes()))
+				require.NoError(t, err)
+				require.Equal(t, dir+"original.jpg", *video.ThumbFile)
+			},
+		},
+		{
+			name: "Should return ErrInvalidImage when upload is not an image",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(fakeVideo, nil)
+				SUT := NewUploadVideoImageDBService(videoRepo, mock_repositories.NewMockVideoImageDB(ctrl),
+					mock_storage.NewMockStorage(ctrl), urls, options)
+				_, err := SUT.Upload(context.Background(), uid, models.VideoFileKindThumb, strings.NewReader("not an image"))
+				require.ErrorIs(t, err, ErrInvalidImage)
+			},
+		},
+		{
+			name: "Should return ErrNotFound when video does not exist",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(models.Video{}, repositories.ErrNoResult)
+				SUT := NewUploadVideoImageDBService(videoRepo, mock_repositories.NewMockVideoImageDB(ctrl),
+					mock_storage.NewMockStorage(ctrl), urls, options)
+				_, err := SUT.Upload(context.Background(), uid, models.VideoFileKindBanner, bytes.NewReader(pngImage.Bytes()))
+				require.ErrorIs(t, err, ErrNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tc.testCase(t, ctrl)
+		})
+	}
+}
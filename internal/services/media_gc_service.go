@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/internal/tracing"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/storage"
+)
+
+type MediaGarbageCollector interface {
+	Collect(ctx context.Context, dryRun bool) (models.GCReport, error)
+}
+
+type MediaGCService struct {
+	references  repositories.MediaReferenceDB
+	storage     storage.Storage
+	gracePeriod time.Duration
+	now         func() time.Time
+}
+
+func NewMediaGCService(references repositories.MediaReferenceDB,
+	storage storage.Storage,
+	gracePeriod time.Duration) MediaGCService {
+	return MediaGCService{
+		references:  references,
+		storage:     storage,
+		gracePeriod: gracePeriod,
+		now:         time.Now,
+	}
+}
+
+// Collect deletes the files no video references anymore. Files younger than
+// the grace period are kept as their video may not be saved yet, on a dry
+// run the orphans are only reported
+func (m *MediaGCService) Collect(ctx context.Context, dryRun bool) (models.GCReport, error) {
+	ctx, span := tracing.Start(ctx, "MediaGCService.Collect")
+	defer span.End()
+	report := models.GCReport{
+		DryRun:  dryRun,
+		Orphans: make([]models.OrphanFile, 0),
+		Failed:  make([]string, 0),
+	}
+	references, err := m.references.GetReferences(ctx)
+	if err != nil {
+		return report, err
+	}
+	files, err := m.storage.List("")
+	if err != nil {
+		return report, err
+	}
+	referenced := make(map[string]bool, len(references.Files))
+	for _, file := range references.Files {
+		referenced[file] = true
+	}
+	threshold := m.now().Add(-m.gracePeriod)
+
+	for _, file := range files {
+		report.Scanned++
+		if referenced[file.Path] || inDirectories(file.Path, references.Directories) {
+			report.Referenced++
+			continue
+		}
+		if file.ModTime.After(threshold) {
+			report.Recent++
+			continue
+		}
+		report.Orphans = append(report.Orphans, models.OrphanFile{
+			Path:    file.Path,
+			Size:    file.Size,
+			ModTime: file.ModTime,
+		})
+		if dryRun {
+			continue
+		}
+		err = m.storage.Delete(file.Path)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			report.Failed = append(report.Failed, file.Path)
+			continue
+		}
+		report.Deleted++
+		report.FreedBytes += file.Size
+	}
+	return report, nil
+}
+
+func inDirectories(path string, directories []string) bool {
+	for _, directory := range directories {
+		if strings.HasPrefix(path, directory) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	mock_repositories "github.com/ayrtonsato/video-catalog-golang/internal/repositories/mocks"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/storage"
+	mock_storage "github.com/ayrtonsato/video-catalog-golang/pkg/storage/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMediaGCService_Collect(t *testing.T) {
+	now := time.Date(2021, 5, 10, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-48 * time.Hour)
+	references := models.MediaReferences{
+		Files: []string{"videos/1/video/source.mp4", "videos/1/encoded/manifest.mpd",
+			"videos/1/encoded/segment-1.m4s"},
+	}
+	files := []storage.FileInfo{
+		{Path: "videos/1/video/source.mp4", Size: 100, ModTime: old},
+		{Path: "videos/1/encoded/segment-1.m4s", Size: 10, ModTime: old},
+		{Path: "videos/1/thumb/original.jpg", Size: 20, ModTime: old},
+		{Path: "videos/2/video/source.mp4", Size: 30, ModTime: now.Add(-time.Hour)},
+	}
+	newSUT := func(ctrl *gomock.Controller, store storage.Storage) MediaGCService {
+		repo := mock_repositories.NewMockMediaReferenceDB(ctrl)
+		repo.EXPECT().GetReferences(gomock.Any()).Times(1).Return(references, nil)
+		SUT := NewMediaGCService(repo, store, 24*time.Hour)
+		SUT.now = func() time.Time { return now }
+		return SUT
+	}
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Should delete orphans older than the grace period",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				store := mock_storage.NewMockStorage(ctrl)
+				store.EXPECT().List("").Times(1).Return(files, nil)
+				store.EXPECT().Delete("videos/1/thumb/original.jpg").Times(1).Return(nil)
+				SUT := newSUT(ctrl, store)
+				report, err := SUT.Collect(context.Background(), false)
+				require.NoError(t, err)
+				require.Equal(t, 4, report.Scanned)
+				require.Equal(t, 2, report.Referenced)
+				require.Equal(t, 1, report.Recent)
+				require.Len(t, report.Orphans, 1)
+				require.Equal(t, 1, report.Deleted)
+				require.Equal(t, int64(20), report.FreedBytes)
+			},
+		},
+		{
+			name: "Should only report orphans on dry run",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				store := mock_storage.NewMockStorage(ctrl)
+				store.EXPECT().List("").Times(1).Return(files, nil)
+				SUT := newSUT(ctrl, store)
+				report, err := SUT.Collect(context.Background(), true)
+				require.NoError(t, err)
+				require.True(t, report.DryRun)
+				require.Equal(t, "videos/1/thumb/original.jpg", report.Orphans[0].Path)
+				require.Zero(t, report.Deleted)
+			},
+		},
+		{
+			name: "Should keep the segments of an encoder that listed only its manifest",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				repo := mock_repositories.NewMockMediaReferenceDB(ctrl)
+				repo.EXPECT().GetReferences(gomock.Any()).Times(1).Return(models.MediaReferences{
+					Files:       []string{"videos/2/encoded/manifest.mpd"},
+					Directories: []string{"videos/2/encoded/"},
+				}, nil)
+				store := mock_storage.NewMockStorage(ctrl)
+				store.EXPECT().List("").Times(1).Return([]storage.FileInfo{
+					{Path: "videos/2/encoded/manifest.mpd", Size: 1, ModTime: old},
+					{Path: "videos/2/encoded/video/segment-1.m4s", Size: 10, ModTime: old},
+					{Path: "videos/2/encoded/audio/segment-1.m4s", Size: 10, ModTime: old},
+					{Path: "videos/2/video/previous.mp4", Size: 100, ModTime: old},
+				}, nil)
+				store.EXPECT().Delete("videos/2/video/previous.mp4").Times(1).Return(nil)
+				SUT := NewMediaGCService(repo, store, 24*time.Hour)
+				SUT.now = func() time.Time { return now }
+				report, err := SUT.Collect(context.Background(), false)
+				require.NoError(t, err)
+				require.Equal(t, 3, report.Referenced)
+				require.Equal(t, 1, report.Deleted)
+			},
+		},
+		{
+			name: "Should report files that failed to be deleted",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				store := mock_storage.NewMockStorage(ctrl)
+				store.EXPECT().List("").Times(1).Return(files, nil)
+				store.EXPECT().Delete(gomock.Any()).Times(1).Return(errors.New("permission denied"))
+				SUT := newSUT(ctrl, store)
+				report, err := SUT.Collect(context.Background(), false)
+				require.NoError(t, err)
+				require.Equal(t, []string{"videos/1/thumb/original.jpg"}, report.Failed)
+				require.Zero(t, report.Deleted)
+			},
+		},
+		{
+			name: "Should not touch the storage when references cannot be loaded",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				repo := mock_repositories.NewMockMediaReferenceDB(ctrl)
+				repo.EXPECT().GetReferences(gomock.Any()).Times(1).Return(models.MediaReferences{}, errors.New("fake_error"))
+				SUT := NewMediaGCService(repo, mock_storage.NewMockStorage(ctrl), time.Hour)
+				_, err := SUT.Collect(context.Background(), false)
+				require.EqualError(t, err, "fake_error")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tc.testCase(t, ctrl)
+		})
+	}
+}
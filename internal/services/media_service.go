@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/internal/tracing"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/signedurl"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/storage"
+	"github.com/gofrs/uuid"
+)
+
+type SignedURL struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type SignMedia interface {
+	SignVideoFile(ctx context.Context, id uuid.UUID, kind models.VideoFileKind, clientIP string, disposition string) (SignedURL, error)
+}
+
+// MediaURLs builds expiring signed urls to files served under /media
+type MediaURLs struct {
+	signer  signedurl.Signer
+	baseURL string
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+func NewMediaURLs(signer signedurl.Signer, baseURL string, ttl time.Duration) MediaURLs {
+	return MediaURLs{
+		signer:  signer,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+func (m MediaURLs) Sign(file string, clientIP string, disposition string) SignedURL {
+	expires := m.now().Add(m.ttl).UTC().Truncate(time.Second)
+	query := m.signer.Sign(file, signedurl.Options{
+		Expires:     expires,
+		ClientIP:    clientIP,
+		Disposition: disposition,
+	})
+	return SignedURL{
+		URL:       fmt.Sprintf("%s/media/%s?%s", m.baseURL, file, query.Encode()),
+		ExpiresAt: expires,
+	}
+}
+
+// SignImages fills the url of every variant
+func (m MediaURLs) SignImages(variants []models.ImageVariant) {
+	for i := range variants {
+		variants[i].URL = m.Sign(variants[i].Path, "", "").URL
+	}
+}
+
+type SignMediaDBService struct {
+	video repositories.VideoDB
+	urls  MediaURLs
+}
+
+func NewSignMediaDBService(video repositories.VideoDB,
+	signer signedurl.Signer,
+	baseURL string,
+	ttl time.Duration) SignMediaDBService {
+	return SignMediaDBService{
+		video: video,
+		urls:  NewMediaURLs(signer, baseURL, ttl),
+	}
+}
+
+func (s *SignMediaDBService) SignVideoFile(ctx context.Context, id uuid.UUID,
+	kind models.VideoFileKind,
+	clientIP string,
+	disposition string) (SignedURL, error) {
+	ctx, span := tracing.Start(ctx, "SignMediaDBService.SignVideoFile")
+	defer span.End()
+	video, err := s.video.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNoResult) {
+			return SignedURL{}, ErrNotFound
+		}
+		return SignedURL{}, err
+	}
+	file := video.File(kind)
+	if file == "" {
+		return SignedURL{}, ErrNotFound
+	}
+	return s.urls.Sign(file, clientIP, disposition), nil
+}
+
+type MediaFile struct {
+	Content            io.ReadCloser
+	Size               int64
+	ContentDisposition string
+}
+
+type DownloadMedia interface {
+	Open(ctx context.Context, file string, query url.Values, clientIP string) (MediaFile, error)
+}
+
+type DownloadMediaService struct {
+	storage storage.Storage
+	signer  signedurl.Signer
+}
+
+func NewDownloadMediaService(storage storage.Storage, signer signedurl.Signer) DownloadMediaService {
+	return DownloadMediaService{
+		storage: storage,
+		signer:  signer,
+	}
+}
+
+func (d *DownloadMediaService) Open(ctx context.Context, file string, query url.Values, clientIP string) (MediaFile, error) {
+	_, span := tracing.Start(ctx, "DownloadMediaService.Open")
+	defer span.End()
+	opts, err := d.signer.Verify(file, query, clientIP)
+	if err != nil {
+		return MediaFile{}, ErrForbidden
+	}
+	info, err := d.storage.Stat(file)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidPath) {
+			return MediaFile{}, ErrNotFound
+		}
+		return MediaFile{}, err
+	}
+	content, err := d.storage.Open(file)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return MediaFile{}, ErrNotFound
+		}
+		return MediaFile{}, err
+	}
+	disposition := ""
+	if opts.Disposition != "" {
+		disposition = fmt.Sprintf("%s; filename=%q", opts.Disposition, path.Base(file))
+	}
+	return MediaFile{
+		Content:            content,
+		Size:               info.Size,
+		ContentDisposition: disposition,
+	}, nil
+}
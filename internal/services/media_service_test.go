@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	mock_repositories "github.com/ayrtonsato/video-catalog-golang/internal/repositories/mocks"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/signedurl"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/storage"
+	mock_storage "github.com/ayrtonsato/video-catalog-golang/pkg/storage/mocks"
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSigner(t *testing.T) signedurl.Signer {
+	signer, err := signedurl.NewSigner("k1", map[string][]byte{"k1": []byte("secret")})
+	require.NoError(t, err)
+	return signer
+}
+
+func TestSignMediaDBService_SignVideoFile(t *testing.T) {
+	uid := uuid.Must(uuid.NewV4())
+	videoFile := "videos/" + uid.String() + "/video.mp4"
+	fakeVideo := models.Video{
+		Id:        uid,
+		Title:     "fake_title",
+		VideoFile: &videoFile,
+	}
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Should return a signed url for the video file",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(fakeVideo, nil)
+				signer := newTestSigner(t)
+				SUT := NewSignMediaDBService(videoRepo, signer, "http://localhost/", time.Minute)
+				signed, err := SUT.SignVideoFile(context.Background(), uid, models.VideoFileKindVideo, "", "attachment")
+				require.NoError(t, err)
+				require.True(t, strings.HasPrefix(signed.URL, "http://localhost/media/"+videoFile+"?"))
+				require.Contains(t, signed.URL, "disposition=attachment")
+				require.True(t, signed.ExpiresAt.After(time.Now()))
+			},
+		},
+		{
+			name: "Should return ErrNotFound when video has no file of the kind",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(fakeVideo, nil)
+				SUT := NewSignMediaDBService(videoRepo, newTestSigner(t), "", time.Minute)
+				_, err := SUT.SignVideoFile(context.Background(), uid, models.VideoFileKindTrailer, "", "")
+				require.ErrorIs(t, err, ErrNotFound)
+			},
+		},
+		{
+			name: "Should return ErrNotFound when video does not exist",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(models.Video{}, repositories.ErrNoResult)
+				SUT := NewSignMediaDBService(videoRepo, newTestSigner(t), "", time.Minute)
+				_, err := SUT.SignVideoFile(context.Background(), uid, models.VideoFileKindVideo, "", "")
+				require.ErrorIs(t, err, ErrNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tc.testCase(t, ctrl)
+		})
+	}
+}
+
+func TestDownloadMediaService_Open(t *testing.T) {
+	file := "videos/1/video.mp4"
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Should open the file when signature is valid",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				signer := newTestSigner(t)
+				query := signer.Sign(file, signedurl.Options{
+					Expires:     time.Now().Add(time.Minute),
+					Disposition: "attachment",
+				})
+				store := mock_storage.NewMockStorage(ctrl)
+				store.EXPECT().Stat(file).Times(1).Return(storage.FileInfo{Path: file, Size: 4}, nil)
+				store.EXPECT().Open(file).Times(1).
+					Return(ioutil.NopCloser(strings.NewReader("data")), nil)
+				SUT := NewDownloadMediaService(store, signer)
+				media, err := SUT.Open(context.Background(), file, query, "")
+				require.NoError(t, err)
+				require.Equal(t, int64(4), media.Size)
+				require.Equal(t, `attachment; filename="video.mp4"`, media.ContentDisposition)
+			},
+		},
+		{
+			name: "Should return ErrForbidden when signature is invalid",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				signer := newTestSigner(t)
+				query := signer.Sign("videos/2/video.mp4", signedurl.Options{
+					Expires: time.Now().Add(time.Minute),
+				})
+				store := mock_storage.NewMockStorage(ctrl)
+				SUT := NewDownloadMediaService(store, signer)
+				_, err := SUT.Open(context.Background(), file, query, "")
+				require.ErrorIs(t, err, ErrForbidden)
+			},
+		},
+		{
+			name: "Should return ErrNotFound when file is missing",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				signer := newTestSigner(t)
+				query := signer.Sign(file, signedurl.Options{
+					Expires: time.Now().Add(time.Minute),
+				})
+				store := mock_storage.NewMockStorage(ctrl)
+				store.EXPECT().Stat(file).Times(1).Return(storage.FileInfo{}, storage.ErrNotFound)
+				SUT := NewDownloadMediaService(store, signer)
+				_, err := SUT.Open(context.Background(), file, query, "")
+				require.ErrorIs(t, err, ErrNotFound)
+			},
+		},
+		{
+			name: "Should return storage errors",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				signer := newTestSigner(t)
+				query := signer.Sign(file, signedurl.Options{
+					Expires: time.Now().Add(time.Minute),
+				})
+				store := mock_storage.NewMockStorage(ctrl)
+				store.EXPECT().Stat(file).Times(1).Return(storage.FileInfo{}, errors.New("disk failure"))
+				SUT := NewDownloadMediaService(store, signer)
+				_, err := SUT.Open(context.Background(), file, query, "")
+				require.EqualError(t, err, "disk failure")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tc.testCase(t, ctrl)
+		})
+	}
+}
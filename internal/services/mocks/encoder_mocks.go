@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/encoder_service.go
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ayrtonsato/video-catalog-golang/internal/models"
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMessageProcessor is a mock of MessageProcessor interface.
+type MockMessageProcessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageProcessorMockRecorder
+}
+
+// MockMessageProcessorMockRecorder is the mock recorder for MockMessageProcessor.
+type MockMessageProcessorMockRecorder struct {
+	mock *MockMessageProcessor
+}
+
+// NewMockMessageProcessor creates a new mock instance.
+func NewMockMessageProcessor(ctrl *gomock.Controller) *MockMessageProcessor {
+	mock := &MockMessageProcessor{ctrl: ctrl}
+	mock.recorder = &MockMessageProcessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageProcessor) EXPECT() *MockMessageProcessorMockRecorder {
+	return m.recorder
+}
+
+// Process mocks base method.
+func (m *MockMessageProcessor) Process(ctx context.Context, payload []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Process", ctx, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Process indicates an expected call of Process.
+func (mr *MockMessageProcessorMockRecorder) Process(ctx, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Process", reflect.TypeOf((*MockMessageProcessor)(nil).Process), ctx, payload)
+}
+
+// MockDeadLetters is a mock of DeadLetters interface.
+type MockDeadLetters struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeadLettersMockRecorder
+}
+
+// MockDeadLettersMockRecorder is the mock recorder for MockDeadLetters.
+type MockDeadLettersMockRecorder struct {
+	mock *MockDeadLetters
+}
+
+// NewMockDeadLetters creates a new mock instance.
+func NewMockDeadLetters(ctrl *gomock.Controller) *MockDeadLetters {
+	mock := &MockDeadLetters{ctrl: ctrl}
+	mock.recorder = &MockDeadLettersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeadLetters) EXPECT() *MockDeadLettersMockRecorder {
+	return m.recorder
+}
+
+// GetDeadLetters mocks base method.
+func (m *MockDeadLetters) GetDeadLetters(ctx context.Context, source string) ([]models.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetters", ctx, source)
+	ret0, _ := ret[0].([]models.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetters indicates an expected call of GetDeadLetters.
+func (mr *MockDeadLettersMockRecorder) GetDeadLetters(ctx, source interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetters", reflect.TypeOf((*MockDeadLetters)(nil).GetDeadLetters), ctx, source)
+}
+
+// Replay mocks base method.
+func (m *MockDeadLetters) Replay(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replay", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replay indicates an expected call of Replay.
+func (mr *MockDeadLettersMockRecorder) Replay(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replay", reflect.TypeOf((*MockDeadLetters)(nil).Replay), ctx, id)
+}
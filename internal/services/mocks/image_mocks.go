@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/image_service.go
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	models "github.com/ayrtonsato/video-catalog-golang/internal/models"
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUploadVideoImage is a mock of UploadVideoImage interface.
+type MockUploadVideoImage struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadVideoImageMockRecorder
+}
+
+// MockUploadVideoImageMockRecorder is the mock recorder for MockUploadVideoImage.
+type MockUploadVideoImageMockRecorder struct {
+	mock *MockUploadVideoImage
+}
+
+// NewMockUploadVideoImage creates a new mock instance.
+func NewMockUploadVideoImage(ctrl *gomock.Controller) *MockUploadVideoImage {
+	mock := &MockUploadVideoImage{ctrl: ctrl}
+	mock.recorder = &MockUploadVideoImageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadVideoImage) EXPECT() *MockUploadVideoImageMockRecorder {
+	return m.recorder
+}
+
+// Upload mocks base method.
+func (m *MockUploadVideoImage) Upload(ctx context.Context, id uuid.UUID, kind models.VideoFileKind, image io.Reader) (models.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, id, kind, image)
+	ret0, _ := ret[0].(models.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockUploadVideoImageMockRecorder) Upload(ctx, id, kind, image interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockUploadVideoImage)(nil).Upload), ctx, id, kind, image)
+}
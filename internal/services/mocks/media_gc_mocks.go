@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/media_gc_service.go
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ayrtonsato/video-catalog-golang/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMediaGarbageCollector is a mock of MediaGarbageCollector interface.
+type MockMediaGarbageCollector struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaGarbageCollectorMockRecorder
+}
+
+// MockMediaGarbageCollectorMockRecorder is the mock recorder for MockMediaGarbageCollector.
+type MockMediaGarbageCollectorMockRecorder struct {
+	mock *MockMediaGarbageCollector
+}
+
+// NewMockMediaGarbageCollector creates a new mock instance.
+func NewMockMediaGarbageCollector(ctrl *gomock.Controller) *MockMediaGarbageCollector {
+	mock := &MockMediaGarbageCollector{ctrl: ctrl}
+	mock.recorder = &MockMediaGarbageCollectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaGarbageCollector) EXPECT() *MockMediaGarbageCollectorMockRecorder {
+	return m.recorder
+}
+
+// Collect mocks base method.
+func (m *MockMediaGarbageCollector) Collect(ctx context.Context, dryRun bool) (models.GCReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Collect", ctx, dryRun)
+	ret0, _ := ret[0].(models.GCReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Collect indicates an expected call of Collect.
+func (mr *MockMediaGarbageCollectorMockRecorder) Collect(ctx, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collect", reflect.TypeOf((*MockMediaGarbageCollector)(nil).Collect), ctx, dryRun)
+}
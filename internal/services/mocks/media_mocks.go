@@ -0,0 +1,92 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/media_service.go
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	url "net/url"
+	reflect "reflect"
+
+	models "github.com/ayrtonsato/video-catalog-golang/internal/models"
+	services "github.com/ayrtonsato/video-catalog-golang/internal/services"
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSignMedia is a mock of SignMedia interface.
+type MockSignMedia struct {
+	ctrl     *gomock.Controller
+	recorder *MockSignMediaMockRecorder
+}
+
+// MockSignMediaMockRecorder is the mock recorder for MockSignMedia.
+type MockSignMediaMockRecorder struct {
+	mock *MockSignMedia
+}
+
+// NewMockSignMedia creates a new mock instance.
+func NewMockSignMedia(ctrl *gomock.Controller) *MockSignMedia {
+	mock := &MockSignMedia{ctrl: ctrl}
+	mock.recorder = &MockSignMediaMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSignMedia) EXPECT() *MockSignMediaMockRecorder {
+	return m.recorder
+}
+
+// SignVideoFile mocks base method.
+func (m *MockSignMedia) SignVideoFile(ctx context.Context, id uuid.UUID, kind models.VideoFileKind, clientIP, disposition string) (services.SignedURL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignVideoFile", ctx, id, kind, clientIP, disposition)
+	ret0, _ := ret[0].(services.SignedURL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignVideoFile indicates an expected call of SignVideoFile.
+func (mr *MockSignMediaMockRecorder) SignVideoFile(ctx, id, kind, clientIP, disposition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignVideoFile", reflect.TypeOf((*MockSignMedia)(nil).SignVideoFile), ctx, id, kind, clientIP, disposition)
+}
+
+// MockDownloadMedia is a mock of DownloadMedia interface.
+type MockDownloadMedia struct {
+	ctrl     *gomock.Controller
+	recorder *MockDownloadMediaMockRecorder
+}
+
+// MockDownloadMediaMockRecorder is the mock recorder for MockDownloadMedia.
+type MockDownloadMediaMockRecorder struct {
+	mock *MockDownloadMedia
+}
+
+// NewMockDownloadMedia creates a new mock instance.
+func NewMockDownloadMedia(ctrl *gomock.Controller) *MockDownloadMedia {
+	mock := &MockDownloadMedia{ctrl: ctrl}
+	mock.recorder = &MockDownloadMediaMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDownloadMedia) EXPECT() *MockDownloadMediaMockRecorder {
+	return m.recorder
+}
+
+// Open mocks base method.
+func (m *MockDownloadMedia) Open(ctx context.Context, file string, query url.Values, clientIP string) (services.MediaFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, file, query, clientIP)
+	ret0, _ := ret[0].(services.MediaFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockDownloadMediaMockRecorder) Open(ctx, file, query, clientIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockDownloadMedia)(nil).Open), ctx, file, query, clientIP)
+}
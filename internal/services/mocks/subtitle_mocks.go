@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/subtitle_service.go
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	models "github.com/ayrtonsato/video-catalog-golang/internal/models"
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSubtitles is a mock of Subtitles interface.
+type MockSubtitles struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubtitlesMockRecorder
+}
+
+// MockSubtitlesMockRecorder is the mock recorder for MockSubtitles.
+type MockSubtitlesMockRecorder struct {
+	mock *MockSubtitles
+}
+
+// NewMockSubtitles creates a new mock instance.
+func NewMockSubtitles(ctrl *gomock.Controller) *MockSubtitles {
+	mock := &MockSubtitles{ctrl: ctrl}
+	mock.recorder = &MockSubtitlesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubtitles) EXPECT() *MockSubtitlesMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSubtitles) Delete(ctx context.Context, videoID uuid.UUID, language string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, videoID, language)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSubtitlesMockRecorder) Delete(ctx, videoID, language interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSubtitles)(nil).Delete), ctx, videoID, language)
+}
+
+// GetSubtitles mocks base method.
+func (m *MockSubtitles) GetSubtitles(ctx context.Context, videoID uuid.UUID) ([]models.Subtitle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtitles", ctx, videoID)
+	ret0, _ := ret[0].([]models.Subtitle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtitles indicates an expected call of GetSubtitles.
+func (mr *MockSubtitlesMockRecorder) GetSubtitles(ctx, videoID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtitles", reflect.TypeOf((*MockSubtitles)(nil).GetSubtitles), ctx, videoID)
+}
+
+// Upload mocks base method.
+func (m *MockSubtitles) Upload(ctx context.Context, videoID uuid.UUID, language, label string, content io.Reader) (models.Subtitle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, videoID, language, label, content)
+	ret0, _ := ret[0].(models.Subtitle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockSubtitlesMockRecorder) Upload(ctx, videoID, language, label, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockSubtitles)(nil).Upload), ctx, videoID, language, label, content)
+}
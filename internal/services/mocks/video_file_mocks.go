@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/video_file_service.go
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	models "github.com/ayrtonsato/video-catalog-golang/internal/models"
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUploadVideoFile is a mock of UploadVideoFile interface.
+type MockUploadVideoFile struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadVideoFileMockRecorder
+}
+
+// MockUploadVideoFileMockRecorder is the mock recorder for MockUploadVideoFile.
+type MockUploadVideoFileMockRecorder struct {
+	mock *MockUploadVideoFile
+}
+
+// NewMockUploadVideoFile creates a new mock instance.
+func NewMockUploadVideoFile(ctrl *gomock.Controller) *MockUploadVideoFile {
+	mock := &MockUploadVideoFile{ctrl: ctrl}
+	mock.recorder = &MockUploadVideoFileMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadVideoFile) EXPECT() *MockUploadVideoFileMockRecorder {
+	return m.recorder
+}
+
+// Upload mocks base method.
+func (m *MockUploadVideoFile) Upload(ctx context.Context, id uuid.UUID, kind models.VideoFileKind, content io.ReaderAt, size int64) (models.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, id, kind, content, size)
+	ret0, _ := ret[0].(models.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockUploadVideoFileMockRecorder) Upload(ctx, id, kind, content, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockUploadVideoFile)(nil).Upload), ctx, id, kind, content, size)
+}
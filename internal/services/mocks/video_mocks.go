@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/video_service.go
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ayrtonsato/video-catalog-golang/internal/models"
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReaderVideo is a mock of ReaderVideo interface.
+type MockReaderVideo struct {
+	ctrl     *gomock.Controller
+	recorder *MockReaderVideoMockRecorder
+}
+
+// MockReaderVideoMockRecorder is the mock recorder for MockReaderVideo.
+type MockReaderVideoMockRecorder struct {
+	mock *MockReaderVideo
+}
+
+// NewMockReaderVideo creates a new mock instance.
+func NewMockReaderVideo(ctrl *gomock.Controller) *MockReaderVideo {
+	mock := &MockReaderVideo{ctrl: ctrl}
+	mock.recorder = &MockReaderVideoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReaderVideo) EXPECT() *MockReaderVideoMockRecorder {
+	return m.recorder
+}
+
+// GetVideo mocks base method.
+func (m *MockReaderVideo) GetVideo(ctx context.Context, id uuid.UUID) (models.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVideo", ctx, id)
+	ret0, _ := ret[0].(models.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVideo indicates an expected call of GetVideo.
+func (mr *MockReaderVideoMockRecorder) GetVideo(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideo", reflect.TypeOf((*MockReaderVideo)(nil).GetVideo), ctx, id)
+}
+
+// GetVideos mocks base method.
+func (m *MockReaderVideo) GetVideos(ctx context.Context, status models.VideoStatus) ([]models.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVideos", ctx, status)
+	ret0, _ := ret[0].([]models.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVideos indicates an expected call of GetVideos.
+func (mr *MockReaderVideoMockRecorder) GetVideos(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideos", reflect.TypeOf((*MockReaderVideo)(nil).GetVideos), ctx, status)
+}
+
+// MockUpdateVideoStatus is a mock of UpdateVideoStatus interface.
+type MockUpdateVideoStatus struct {
+	ctrl     *gomock.Controller
+	recorder *MockUpdateVideoStatusMockRecorder
+}
+
+// MockUpdateVideoStatusMockRecorder is the mock recorder for MockUpdateVideoStatus.
+type MockUpdateVideoStatusMockRecorder struct {
+	mock *MockUpdateVideoStatus
+}
+
+// NewMockUpdateVideoStatus creates a new mock instance.
+func NewMockUpdateVideoStatus(ctrl *gomock.Controller) *MockUpdateVideoStatus {
+	mock := &MockUpdateVideoStatus{ctrl: ctrl}
+	mock.recorder = &MockUpdateVideoStatusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUpdateVideoStatus) EXPECT() *MockUpdateVideoStatusMockRecorder {
+	return m.recorder
+}
+
+// UpdateStatus mocks base method.
+func (m *MockUpdateVideoStatus) UpdateStatus(ctx context.Context, id uuid.UUID, status models.VideoStatus, reason string) (models.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status, reason)
+	ret0, _ := ret[0].(models.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockUpdateVideoStatusMockRecorder) UpdateStatus(ctx, id, status, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockUpdateVideoStatus)(nil).UpdateStatus), ctx, id, status, reason)
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/internal/tracing"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/storage"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/subtitle"
+	"github.com/gofrs/uuid"
+)
+
+type Subtitles interface {
+	GetSubtitles(ctx context.Context, videoID uuid.UUID) ([]models.Subtitle, error)
+	Upload(ctx context.Context, videoID uuid.UUID, language string, label string, content io.Reader) (models.Subtitle, error)
+	Delete(ctx context.Context, videoID uuid.UUID, language string) error
+}
+
+type SubtitlesDBService struct {
+	videoRepository    repositories.VideoDB
+	subtitleRepository repositories.SubtitleDB
+	storage            storage.Storage
+	urls               MediaURLs
+}
+
+func NewSubtitlesDBService(videoRepository repositories.VideoDB,
+	subtitleRepository repositories.SubtitleDB,
+	storage storage.Storage,
+	urls MediaURLs) SubtitlesDBService {
+	return SubtitlesDBService{
+		videoRepository:    videoRepository,
+		subtitleRepository: subtitleRepository,
+		storage:            storage,
+		urls:               urls,
+	}
+}
+
+func (s *SubtitlesDBService) GetSubtitles(ctx context.Context, videoID uuid.UUID) ([]models.Subtitle, error) {
+	ctx, span := tracing.Start(ctx, "SubtitlesDBService.GetSubtitles")
+	defer span.End()
+	if _, err := s.getVideo(ctx, videoID); err != nil {
+		return []models.Subtitle{}, err
+	}
+	subtitles, err := s.subtitleRepository.GetByVideoID(ctx, videoID)
+	if err != nil {
+		return []models.Subtitle{}, err
+	}
+	for i := range subtitles {
+		subtitles[i].URL = s.urls.Sign(subtitles[i].Path, "", "").URL
+	}
+	return subtitles, nil
+}
+
+// Upload parses a SRT or WebVTT file, checks its cues fit the declared
+// duration of the video and stores it as WebVTT, replacing the track of
+// the same language
+func (s *SubtitlesDBService) Upload(ctx context.Context, videoID uuid.UUID,
+	language string,
+	label string,
+	content io.Reader) (models.Subtitle, error) {
+	ctx, span := tracing.Start(ctx, "SubtitlesDBService.Upload")
+	defer span.End()
+	video, err := s.getVideo(ctx, videoID)
+	if err != nil {
+		return models.Subtitle{}, err
+	}
+	cues, _, err := subtitle.Parse(content)
+	if err != nil {
+		return models.Subtitle{}, fmt.Errorf("%w: %v", ErrInvalidSubtitle, err)
+	}
+	maxDuration := time.Duration(video.Duration) * time.Minute
+	if err = subtitle.Validate(cues, maxDuration); err != nil {
+		return models.Subtitle{}, fmt.Errorf("%w: %v", ErrInvalidSubtitle, err)
+	}
+
+	var vtt bytes.Buffer
+	if err = subtitle.WriteWebVTT(&vtt, cues); err != nil {
+		return models.Subtitle{}, err
+	}
+	path := fmt.Sprintf("videos/%s/subtitles/%s.vtt", videoID, language)
+	if err = s.storage.Put(path, &vtt); err != nil {
+		return models.Subtitle{}, err
+	}
+	saved, err := s.subtitleRepository.Save(ctx, models.Subtitle{
+		VideoID:  videoID,
+		Language: language,
+		Label:    label,
+		Path:     path,
+		CueCount: len(cues),
+	})
+	if err != nil {
+		return models.Subtitle{}, ErrSaveFailed
+	}
+	saved.URL = s.urls.Sign(saved.Path, "", "").URL
+	return saved, nil
+}
+
+func (s *SubtitlesDBService) Delete(ctx context.Context, videoID uuid.UUID, language string) error {
+	ctx, span := tracing.Start(ctx, "SubtitlesDBService.Delete")
+	defer span.End()
+	current, err := s.subtitleRepository.GetByLanguage(ctx, videoID, language)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNoResult) {
+			return ErrNotFound
+		}
+		return err
+	}
+	if err = s.subtitleRepository.Delete(ctx, videoID, language); err != nil {
+		if errors.Is(err, repositories.ErrNoResult) {
+			return ErrNotFound
+		}
+		return err
+	}
+	if err = s.storage.Delete(current.Path); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (s *SubtitlesDBService) getVideo(ctx context.Context, videoID uuid.UUID) (models.Video, error) {
+	video, err := s.videoRepository.GetByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNoResult) {
+			return models.Video{}, ErrNotFound
+		}
+		return models.Video{}, err
+	}
+	return video, nil
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	mock_repositories "github.com/ayrtonsato/video-catalog-golang/internal/repositories/mocks"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/storage"
+	mock_storage "github.com/ayrtonsato/video-catalog-golang/pkg/storage/mocks"
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubtitlesDBService_Upload(t *testing.T) {
+	uid := uuid.Must(uuid.NewV4())
+	fakeVideo := models.Video{Id: uid, Title: "fake_title", Duration: 1}
+	path := "videos/" + uid.String() + "/subtitles/pt-BR.vtt"
+	srt := "1\n00:00:01,000 --> 00:00:02,000\nOlá\n"
+	urls := NewMediaURLs(newTestSigner(t), "http://localhost", time.Minute)
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Should store the track converted to WebVTT",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				subtitleRepo := mock_repositories.NewMockSubtitleDB(ctrl)
+				store := mock_storage.NewMockStorage(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(fakeVideo, nil)
+				store.EXPECT().
+					Put(path, gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ string, r io.Reader) error {
+						data, err := ioutil.ReadAll(r)
+						require.NoError(t, err)
+						require.Equal(t, "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nOlá\n", string(data))
+						return nil
+					})
+				subtitleRepo.EXPECT().
+					Save(gomock.Any(), models.Subtitle{VideoID: uid, Language: "pt-BR", Label: "Português", Path: path, CueCount: 1}).
+					Times(1).
+					DoAndReturn(func(_ context.Context, s models.Subtitle) (models.Subtitle, error) {
+						s.ID = uuid.Must(uuid.NewV4())
+						return s, nil
+					})
+				SUT := NewSubtitlesDBService(videoRepo, subtitleRepo, store, urls)
+				saved, err := SUT.Upload(context.Background(), uid, "pt-BR", "Português", strings.NewReader(srt))
+				require.NoError(t, err)
+				require.True(t, strings.HasPrefix(saved.URL, "http://localhost/media/"+path+"?"))
+			},
+		},
+		{
+			name: "Should return ErrInvalidSubtitle when a cue is beyond the video duration",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(fakeVideo, nil)
+				SUT := NewSubtitlesDBService(videoRepo, mock_repositories.NewMockSubtitleDB(ctrl),
+					mock_storage.NewMockStorage(ctrl), urls)
+				late := "1\n00:01:01,000 --> 00:01:02,000\nlate\n"
+				_, err := SUT.Upload(context.Background(), uid, "en", "English", strings.NewReader(late))
+				require.ErrorIs(t, err, ErrInvalidSubtitle)
+				require.EqualError(t, err, "service: invalid subtitle: cue 1: subtitle: cue ends after the video")
+			},
+		},
+		{
+			name: "Should return ErrInvalidSubtitle when file cannot be parsed",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(fakeVideo, nil)
+				SUT := NewSubtitlesDBService(videoRepo, mock_repositories.NewMockSubtitleDB(ctrl),
+					mock_storage.NewMockStorage(ctrl), urls)
+				_, err := SUT.Upload(context.Background(), uid, "en", "English", strings.NewReader("not a subtitle"))
+				require.ErrorIs(t, err, ErrInvalidSubtitle)
+			},
+		},
+		{
+			name: "Should return ErrNotFound when video does not exist",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(models.Video{}, repositories.ErrNoResult)
+				SUT := NewSubtitlesDBService(videoRepo, mock_repositories.NewMockSubtitleDB(ctrl),
+					mock_storage.NewMockStorage(ctrl), urls)
+				_, err := SUT.Upload(context.Background(), uid, "en", "English", strings.NewReader(srt))
+				require.ErrorIs(t, err, ErrNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tc.testCase(t, ctrl)
+		})
+	}
+}
+
+func TestSubtitlesDBService_Delete(t *testing.T) {
+	uid := uuid.Must(uuid.NewV4())
+	track := models.Subtitle{VideoID: uid, Language: "en", Path: "videos/1/subtitles/en.vtt"}
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Should delete the track and its file",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				subtitleRepo := mock_repositories.NewMockSubtitleDB(ctrl)
+				store := mock_storage.NewMockStorage(ctrl)
+				subtitleRepo.EXPECT().GetByLanguage(gomock.Any(), uid, "en").Times(1).Return(track, nil)
+				subtitleRepo.EXPECT().Delete(gomock.Any(), uid, "en").Times(1).Return(nil)
+				store.EXPECT().Delete(track.Path).Times(1).Return(storage.ErrNotFound)
+				SUT := NewSubtitlesDBService(mock_repositories.NewMockVideoDB(ctrl), subtitleRepo, store, MediaURLs{})
+				require.NoError(t, SUT.Delete(context.Background(), uid, "en"))
+			},
+		},
+		{
+			name: "Should return ErrNotFound when track does not exist",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				subtitleRepo := mock_repositories.NewMockSubtitleDB(ctrl)
+				subtitleRepo.EXPECT().GetByLanguage(gomock.Any(), uid, "en").Times(1).Return(models.Subtitle{}, repositories.ErrNoResult)
+				SUT := NewSubtitlesDBService(mock_repositories.NewMockVideoDB(ctrl), subtitleRepo,
+					mock_storage.NewMockStorage(ctrl), MediaURLs{})
+				require.ErrorIs(t, SUT.Delete(context.Background(), uid, "en"), ErrNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tc.testCase(t, ctrl)
+		})
+	}
+}
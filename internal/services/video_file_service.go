@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/internal/tracing"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/mp4meta"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/storage"
+	"github.com/gofrs/uuid"
+)
+
+type UploadVideoFile interface {
+	Upload(ctx context.Context, id uuid.UUID, kind models.VideoFileKind, content io.ReaderAt, size int64) (models.Video, error)
+}
+
+type UploadVideoFileDBService struct {
+	videoRepository repositories.VideoDB
+	blobRepository  repositories.MediaBlobDB
+	storage         storage.Storage
+}
+
+func NewUploadVideoFileDBService(videoRepository repositories.VideoDB,
+	blobRepository repositories.MediaBlobDB,
+	storage storage.Storage) UploadVideoFileDBService {
+	return UploadVideoFileDBService{
+		videoRepository: videoRepository,
+		blobRepository:  blobRepository,
+		storage:         storage,
+	}
+}
+
+// Upload checks content is an mp4 or mov file and stores it as a content
+// addressed blob, a file already uploaded for any video is reused and the
+// new copy dropped. The metadata of the main video file is kept on the
+// video so its declared duration can be checked
+func (u *UploadVideoFileDBService) Upload(ctx context.Context, id uuid.UUID,
+	kind models.VideoFileKind,
+	content io.ReaderAt,
+	size int64) (models.Video, error) {
+	ctx, span := tracing.Start(ctx, "UploadVideoFileDBService.Upload")
+	defer span.End()
+	video, err := u.videoRepository.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNoResult) {
+			return models.Video{}, ErrNotFound
+		}
+		return models.Video{}, err
+	}
+	meta, err := mp4meta.Parse(content, size)
+	if err != nil {
+		return models.Video{}, ErrInvalidVideo
+	}
+
+	extension := "mp4"
+	if meta.Brand == "qt  " {
+		extension = "mov"
+	}
+	blob, err := u.storeBlob(ctx, content, size, extension)
+	if err != nil {
+		return models.Video{}, err
+	}
+	from := video
+	previous := video.File(kind)
+	path := blob.Path
+	switch kind {
+	case models.VideoFileKindVideo:
+		video.VideoFile = &path
+		video.SetMetadata(models.VideoMetadata{
+			DurationSeconds: meta.Duration.Seconds(),
+			Width:           meta.Width,
+			Height:          meta.Height,
+			Codec:           meta.Codec,
+		})
+	case models.VideoFileKindTrailer:
+		video.TrailerFile = &path
+	}
+
+	err = u.videoRepository.UpdateMediaFiles(ctx, video, from)
+	if err != nil {
+		u.releaseBlob(ctx, blob.Path)
+		if errors.Is(err, repositories.ErrStaleObject) {
+			return models.Video{}, ErrConflict
+		}
+		return models.Video{}, ErrUpdateFailed
+	}
+	// re-uploading the current file acquired its blob twice, releasing the
+	// previous file keeps the count right in that case too
+	if previous != "" {
+		u.releaseBlob(ctx, previous)
+	}
+	return video, nil
+}
+
+// storeBlob writes content to blobs/incoming/ while hashing it, then takes a
+// reference to the blob of that hash. The file is moved under blobs/sha256/
+// when the reference created the blob, or its file went missing, and dropped
+// when the blob was already stored
+func (u *UploadVideoFileDBService) storeBlob(ctx context.Context, content io.ReaderAt, size int64, extension string) (models.MediaBlob, error) {
+	incoming := fmt.Sprintf("blobs/incoming/%s.%s", uuid.Must(uuid.NewV4()), extension)
+	hasher := sha256.New()
+	if err := u.storage.Put(incoming, io.TeeReader(io.NewSectionReader(content, 0, size), hasher)); err != nil {
+		return models.MediaBlob{}, err
+	}
+	hash := hex.EncodeToString(hasher.Sum(nil))
+
+	blob, err := u.blobRepository.Acquire(ctx, models.MediaBlob{
+		Hash: hash,
+		Path: fmt.Sprintf("blobs/sha256/%s/%s/%s.%s", hash[0:2], hash[2:4], hash, extension),
+		Size: size,
+	})
+	if err != nil {
+		_ = u.storage.Delete(incoming)
+		return models.MediaBlob{}, ErrSaveFailed
+	}
+	if blob.RefCount > 1 {
+		if _, err = u.storage.Stat(blob.Path); err == nil {
+			_ = u.storage.Delete(incoming)
+			return blob, nil
+		}
+	}
+	if err = u.storage.Move(incoming, blob.Path); err != nil {
+		u.releaseBlob(ctx, blob.Path)
+		_ = u.storage.Delete(incoming)
+		return models.MediaBlob{}, err
+	}
+	return blob, nil
+}
+
+// releaseBlob drops a reference to a replaced file, files uploaded before
+// deduplication are not blobs and are left to the media garbage collector
+func (u *UploadVideoFileDBService) releaseBlob(ctx context.Context, path string) {
+	if !strings.HasPrefix(path, "blobs/") {
+		return
+	}
+	_ = u.blobRepository.Release(ctx, path)
+}
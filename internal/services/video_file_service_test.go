@@ -0,0 +1,279 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	mock_repositories "github.com/ayrtonsato/video-catalog-golang/internal/repositories/mocks"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/storage"
+	mock_storage "github.com/ayrtonsato/video-catalog-golang/pkg/storage/mocks"
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func mp4Box(kind string, payload ...[]byte) []byte {
+	body := bytes.Join(payload, nil)
+	out := make([]byte, 8, 8+len(body))
+	binary.BigEndian.PutUint32(out[0:4], uint32(8+len(body)))
+	copy(out[4:8], kind)
+	return append(out, body...)
+}
+
+// newTestMP4 builds a movie of seconds with a single 1280x720 avc1 track
+func newTestMP4(brand string, seconds uint32) []byte {
+	mvhd := make([]byte, 100)
+	binary.BigEndian.PutUint32(mvhd[12:16], 1)
+	binary.BigEndian.PutUint32(mvhd[16:20], seconds)
+	tkhd := make([]byte, 84)
+	binary.BigEndian.PutUint32(tkhd[76:80], 1280<<16)
+	binary.BigEndian.PutUint32(tkhd[80:84], 720<<16)
+	hdlr := make([]byte, 24)
+	copy(hdlr[8:12], "vide")
+	stsd := make([]byte, 16)
+	binary.BigEndian.PutUint32(stsd[4:8], 1)
+	copy(stsd[12:16], "avc1")
+	return bytes.Join([][]byte{
+		mp4Box("ftyp", []byte(brand)),
+		mp4Box("moov",
+			mp4Box("mvhd", mvhd),
+			mp4Box("trak",
+				mp4Box("tkhd", tkhd),
+				mp4Box("mdia", mp4Box("hdlr", hdlr), mp4Box("minf", mp4Box("stbl", mp4Box("stsd", stsd)))))),
+	}, nil)
+}
+
+func TestUploadVideoFileDBService_Upload(t *testing.T) {
+	uid := uuid.Must(uuid.NewV4())
+	fakeVideo := models.Video{Id: uid, Title: "fake_title", Duration: 90}
+	file := newTestMP4("isom", 90*60)
+	sum := sha256.Sum256(file)
+	hash := hex.EncodeToString(sum[:])
+	blobPath := "blobs/sha256/" + hash[0:2] + "/" + hash[2:4] + "/" + hash + ".mp4"
+	incoming := func(path string) bool {
+		return strings.HasPrefix(path, "blobs/incoming/") && strings.HasSuffix(path, ".mp4")
+	}
+	put := func(path string, r io.Reader) error {
+		_, err := io.Copy(ioutil.Discard, r)
+		return err
+	}
+	created := func(_ context.Context, blob models.MediaBlob) (models.MediaBlob, error) {
+		blob.RefCount = 1
+		return blob, nil
+	}
+	shared := func(_ context.Context, blob models.MediaBlob) (models.MediaBlob, error) {
+		blob.RefCount = 2
+		return blob, nil
+	}
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Should store a new blob with the video metadata",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				blobRepo := mock_repositories.NewMockMediaBlobDB(ctrl)
+				store := mock_storage.NewMockStorage(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(fakeVideo, nil)
+				var written string
+				store.EXPECT().Put(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(path string, r io.Reader) error {
+						require.True(t, incoming(path), path)
+						written = path
+						return put(path, r)
+					})
+				blobRepo.EXPECT().
+					Acquire(gomock.Any(), models.MediaBlob{Hash: hash, Path: blobPath, Size: int64(len(file))}).
+					Times(1).
+					DoAndReturn(created)
+				store.EXPECT().Move(gomock.Any(), blobPath).Times(1).
+					DoAndReturn(func(from string, to string) error {
+						require.Equal(t, written, from)
+						return nil
+					})
+				videoRepo.EXPECT().UpdateMediaFiles(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				SUT := NewUploadVideoFileDBService(videoRepo, blobRepo, store)
+				video, err := SUT.Upload(context.Background(), uid, models.VideoFileKindVideo, bytes.NewReader(file), int64(len(file)))
+				require.NoError(t, err)
+				require.Equal(t, blobPath, *video.VideoFile)
+				require.Equal(t, &models.VideoMetadata{
+					DurationSeconds: 5400,
+					Width:           1280,
+					Height:          720,
+					Codec:           "avc1",
+				}, video.Metadata)
+				require.False(t, video.DurationMismatch)
+			},
+		},
+		{
+			name: "Should reuse an existing blob and release the replaced one",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				previous := "blobs/sha256/00/00/previous.mp4"
+				trailerVideo := fakeVideo
+				trailerVideo.TrailerFile = &previous
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				blobRepo := mock_repositories.NewMockMediaBlobDB(ctrl)
+				store := mock_storage.NewMockStorage(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(trailerVideo, nil)
+				store.EXPECT().Put(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(put)
+				blobRepo.EXPECT().Acquire(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(shared)
+				store.EXPECT().Stat(blobPath).Times(1).Return(storage.FileInfo{Path: blobPath}, nil)
+				store.EXPECT().Delete(gomock.Any()).Times(1).
+					DoAndReturn(func(path string) error {
+						require.True(t, incoming(path), path)
+						return nil
+					})
+				videoRepo.EXPECT().UpdateMediaFiles(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				blobRepo.EXPECT().Release(gomock.Any(), previous).Times(1).Return(nil)
+				SUT := NewUploadVideoFileDBService(videoRepo, blobRepo, store)
+				video, err := SUT.Upload(context.Background(), uid, models.VideoFileKindTrailer, bytes.NewReader(file), int64(len(file)))
+				require.NoError(t, err)
+				require.Equal(t, blobPath, *video.TrailerFile)
+				require.Nil(t, video.Metadata)
+			},
+		},
+		{
+			name: "Should flag a declared duration that does not match the file",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				short := newTestMP4("qt  ", 30*60)
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				blobRepo := mock_repositories.NewMockMediaBlobDB(ctrl)
+				store := mock_storage.NewMockStorage(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(fakeVideo, nil)
+				store.EXPECT().Put(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(put)
+				blobRepo.EXPECT().
+					Acquire(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, blob models.MediaBlob) (models.MediaBlob, error) {
+						require.True(t, strings.HasSuffix(blob.Path, ".mov"))
+						return created(nil, blob)
+					})
+				store.EXPECT().Move(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				videoRepo.EXPECT().
+					UpdateMediaFiles(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, video models.Video, from models.Video) error {
+						require.True(t, video.DurationMismatch)
+						return nil
+					})
+				SUT := NewUploadVideoFileDBService(videoRepo, blobRepo, store)
+				_, err := SUT.Upload(context.Background(), uid, models.VideoFileKindVideo, bytes.NewReader(short), int64(len(short)))
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Should release the new blob when the video cannot be updated",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				blobRepo := mock_repositories.NewMockMediaBlobDB(ctrl)
+				store := mock_storage.NewMockStorage(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(fakeVideo, nil)
+				store.EXPECT().Put(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(put)
+				blobRepo.EXPECT().Acquire(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(created)
+				store.EXPECT().Move(gomock.Any(), blobPath).Times(1).Return(nil)
+				videoRepo.EXPECT().UpdateMediaFiles(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(repositories.ErrOnUpdate)
+				blobRepo.EXPECT().Release(gomock.Any(), blobPath).Times(1).Return(nil)
+				SUT := NewUploadVideoFileDBService(videoRepo, blobRepo, store)
+				_, err := SUT.Upload(context.Background(), uid, models.VideoFileKindVideo, bytes.NewReader(file), int64(len(file)))
+				require.ErrorIs(t, err, ErrUpdateFailed)
+			},
+		},
+		{
+			name: "Should keep the replaced file when another upload replaced it first",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				previous := "blobs/sha256/00/00/previous.mp4"
+				loaded := fakeVideo
+				loaded.VideoFile = &previous
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				blobRepo := mock_repositories.NewMockMediaBlobDB(ctrl)
+				store := mock_storage.NewMockStorage(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(loaded, nil)
+				store.EXPECT().Put(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(put)
+				blobRepo.EXPECT().Acquire(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(created)
+				store.EXPECT().Move(gomock.Any(), blobPath).Times(1).Return(nil)
+				videoRepo.EXPECT().
+					UpdateMediaFiles(gomock.Any(), gomock.Any(), loaded).
+					Times(1).
+					Return(repositories.ErrStaleObject)
+				// only the new blob is released, never the replaced one
+				blobRepo.EXPECT().Release(gomock.Any(), blobPath).Times(1).Return(nil)
+				SUT := NewUploadVideoFileDBService(videoRepo, blobRepo, store)
+				_, err := SUT.Upload(context.Background(), uid, models.VideoFileKindVideo, bytes.NewReader(file), int64(len(file)))
+				require.ErrorIs(t, err, ErrConflict)
+			},
+		},
+		{
+			name: "Should write the file again when a shared blob lost it",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				blobRepo := mock_repositories.NewMockMediaBlobDB(ctrl)
+				store := mock_storage.NewMockStorage(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(fakeVideo, nil)
+				store.EXPECT().Put(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(put)
+				blobRepo.EXPECT().Acquire(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(shared)
+				store.EXPECT().Stat(blobPath).Times(1).Return(storage.FileInfo{}, storage.ErrNotFound)
+				store.EXPECT().Move(gomock.Any(), blobPath).Times(1).Return(nil)
+				videoRepo.EXPECT().UpdateMediaFiles(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				SUT := NewUploadVideoFileDBService(videoRepo, blobRepo, store)
+				_, err := SUT.Upload(context.Background(), uid, models.VideoFileKindVideo, bytes.NewReader(file), int64(len(file)))
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Should drop the upload when the blob cannot be acquired",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				blobRepo := mock_repositories.NewMockMediaBlobDB(ctrl)
+				store := mock_storage.NewMockStorage(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(fakeVideo, nil)
+				store.EXPECT().Put(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(put)
+				blobRepo.EXPECT().Acquire(gomock.Any(), gomock.Any()).Times(1).Return(models.MediaBlob{}, repositories.ErrOnSave)
+				store.EXPECT().Delete(gomock.Any()).Times(1).Return(nil)
+				SUT := NewUploadVideoFileDBService(videoRepo, blobRepo, store)
+				_, err := SUT.Upload(context.Background(), uid, models.VideoFileKindVideo, bytes.NewReader(file), int64(len(file)))
+				require.ErrorIs(t, err, ErrSaveFailed)
+			},
+		},
+		{
+			name: "Should return ErrInvalidVideo when file is not an mp4",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				content := "not a video file"
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(fakeVideo, nil)
+				SUT := NewUploadVideoFileDBService(videoRepo, mock_repositories.NewMockMediaBlobDB(ctrl),
+					mock_storage.NewMockStorage(ctrl))
+				_, err := SUT.Upload(context.Background(), uid, models.VideoFileKindVideo, strings.NewReader(content), int64(len(content)))
+				require.ErrorIs(t, err, ErrInvalidVideo)
+			},
+		},
+		{
+			name: "Should return ErrNotFound when video does not exist",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(models.Video{}, repositories.ErrNoResult)
+				SUT := NewUploadVideoFileDBService(videoRepo, mock_repositories.NewMockMediaBlobDB(ctrl),
+					mock_storage.NewMockStorage(ctrl))
+				_, err := SUT.Upload(context.Background(), uid, models.VideoFileKindVideo, strings.NewReader(""), 0)
+				require.ErrorIs(t, err, ErrNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tc.testCase(t, ctrl)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/internal/tracing"
+	"github.com/gofrs/uuid"
+)
+
+type ReaderVideo interface {
+	GetVideos(ctx context.Context, status models.VideoStatus) ([]models.Video, error)
+	GetVideo(ctx context.Context, id uuid.UUID) (models.Video, error)
+}
+
+type GetVideosDBService struct {
+	videoRepository repositories.VideoDB
+	imageRepository repositories.VideoImageDB
+	urls            MediaURLs
+}
+
+func NewGetVideosDBService(videoRepository repositories.VideoDB,
+	imageRepository repositories.VideoImageDB,
+	urls MediaURLs) GetVideosDBService {
+	return GetVideosDBService{
+		videoRepository: videoRepository,
+		imageRepository: imageRepository,
+		urls:            urls,
+	}
+}
+
+func (g *GetVideosDBService) GetVideos(ctx context.Context, status models.VideoStatus) ([]models.Video, error) {
+	ctx, span := tracing.Start(ctx, "GetVideosDBService.GetVideos")
+	defer span.End()
+	videos, err := g.videoRepository.GetVideos(ctx, status)
+	if err != nil {
+		return videos, err
+	}
+	if err = g.attachImages(ctx, videos); err != nil {
+		return []models.Video{}, err
+	}
+	return videos, nil
+}
+
+func (g *GetVideosDBService) GetVideo(ctx context.Context, id uuid.UUID) (models.Video, error) {
+	ctx, span := tracing.Start(ctx, "GetVideosDBService.GetVideo")
+	defer span.End()
+	video, err := g.videoRepository.GetByID(ctx, id)
+	if err == repositories.ErrNoResult {
+		return video, ErrNotFound
+	}
+	if err != nil {
+		return video, err
+	}
+	videos := []models.Video{video}
+	if err = g.attachImages(ctx, videos); err != nil {
+		return models.Video{}, err
+	}
+	return videos[0], nil
+}
+
+// attachImages loads the image variants of videos with signed urls
+func (g *GetVideosDBService) attachImages(ctx context.Context, videos []models.Video) error {
+	if len(videos) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(videos))
+	for _, video := range videos {
+		ids = append(ids, video.Id)
+	}
+	images, err := g.imageRepository.GetByVideoIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i := range videos {
+		variants := images[videos[i].Id]
+		g.urls.SignImages(variants)
+		videos[i].SetImages(variants)
+	}
+	return nil
+}
+
+type UpdateVideoStatus interface {
+	UpdateStatus(ctx context.Context, id uuid.UUID, status models.VideoStatus, reason string) (models.Video, error)
+}
+
+type UpdateVideoStatusDBService struct {
+	videoRepository repositories.VideoDB
+	now             func() time.Time
+}
+
+func NewUpdateVideoStatusDBService(videoRepository repositories.VideoDB) UpdateVideoStatusDBService {
+	return UpdateVideoStatusDBService{
+		videoRepository: videoRepository,
+		now:             time.Now,
+	}
+}
+
+func (u *UpdateVideoStatusDBService) UpdateStatus(ctx context.Context, id uuid.UUID,
+	status models.VideoStatus,
+	reason string) (models.Video, error) {
+	ctx, span := tracing.Start(ctx, "UpdateVideoStatusDBService.UpdateStatus")
+	defer span.End()
+	video, err := u.videoRepository.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNoResult) {
+			return models.Video{}, ErrNotFound
+		}
+		return models.Video{}, err
+	}
+	from := video.Status
+	if err = video.TransitionTo(status, reason, u.now().UTC()); err != nil {
+		return models.Video{}, ErrConflict
+	}
+	err = u.videoRepository.UpdateStatus(ctx, video, from)
+	if err != nil {
+		if errors.Is(err, repositories.ErrStaleObject) {
+			return models.Video{}, ErrConflict
+		}
+		return models.Video{}, ErrUpdateFailed
+	}
+	return video, nil
+}
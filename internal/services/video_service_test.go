@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/models"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	mock_repositories "github.com/ayrtonsato/video-catalog-golang/internal/repositories/mocks"
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVideosDBService_GetVideos(t *testing.T) {
+	uid := uuid.Must(uuid.NewV4())
+	fakeVideos := []models.Video{
+		{Id: uid, Title: "fake_title", Status: models.VideoStatusFailed},
+	}
+	urls := NewMediaURLs(newTestSigner(t), "http://localhost", time.Minute)
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Should return videos filtered by status",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				imageRepo := mock_repositories.NewMockVideoImageDB(ctrl)
+				videoRepo.EXPECT().
+					GetVideos(gomock.Any(), models.VideoStatusFailed).
+					Times(1).
+					Return(fakeVideos, nil)
+				imageRepo.EXPECT().
+					GetByVideoIDs(gomock.Any(), []uuid.UUID{uid}).
+					Times(1).
+					Return(map[uuid.UUID][]models.ImageVariant{}, nil)
+				SUT := NewGetVideosDBService(videoRepo, imageRepo, urls)
+				videos, err := SUT.GetVideos(context.Background(), models.VideoStatusFailed)
+				require.NoError(t, err)
+				require.Len(t, videos, 1)
+				require.Equal(t, "fake_title", videos[0].Title)
+				require.Empty(t, videos[0].Thumbnails)
+			},
+		},
+		{
+			name: "Should attach the image variants with signed urls",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				imageRepo := mock_repositories.NewMockVideoImageDB(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(fakeVideos[0], nil)
+				imageRepo.EXPECT().
+					GetByVideoIDs(gomock.Any(), []uuid.UUID{uid}).
+					Times(1).
+					Return(map[uuid.UUID][]models.ImageVariant{uid: {
+						{Kind: models.VideoFileKindThumb, Width: 320, Path: "videos/thumb/320w.jpg"},
+						{Kind: models.VideoFileKindBanner, Width: 1280, Path: "videos/banner/1280w.jpg"},
+					}}, nil)
+				SUT := NewGetVideosDBService(videoRepo, imageRepo, urls)
+				video, err := SUT.GetVideo(context.Background(), uid)
+				require.NoError(t, err)
+				require.Len(t, video.Thumbnails, 1)
+				require.Len(t, video.Banners, 1)
+				require.True(t, strings.HasPrefix(video.Thumbnails[0].URL, "http://localhost/media/videos/thumb/320w.jpg?"))
+			},
+		},
+		{
+			name: "Should return ErrNotFound when video does not exist",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().
+					GetByID(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(models.Video{}, repositories.ErrNoResult)
+				SUT := NewGetVideosDBService(videoRepo, mock_repositories.NewMockVideoImageDB(ctrl), urls)
+				_, err := SUT.GetVideo(context.Background(), uuid.Must(uuid.NewV4()))
+				require.ErrorIs(t, err, ErrNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tc.testCase(t, ctrl)
+		})
+	}
+}
+
+func TestUpdateVideoStatusDBService_UpdateStatus(t *testing.T) {
+	uid := uuid.Must(uuid.NewV4())
+	pendingVideo := models.Video{Id: uid, Status: models.VideoStatusPending}
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, ctrl *gomock.Controller)
+	}{
+		{
+			name: "Should move the video to the new status",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(pendingVideo, nil)
+				videoRepo.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), models.VideoStatusPending).
+					Times(1).
+					DoAndReturn(func(_ context.Context, video models.Video, from models.VideoStatus) error {
+						require.Equal(t, models.VideoStatusProcessing, video.Status)
+						require.NotNil(t, video.ProcessingAt)
+						return nil
+					})
+				SUT := NewUpdateVideoStatusDBService(videoRepo)
+				video, err := SUT.UpdateStatus(context.Background(), uid, models.VideoStatusProcessing, "")
+				require.NoError(t, err)
+				require.Equal(t, models.VideoStatusProcessing, video.Status)
+			},
+		},
+		{
+			name: "Should return ErrConflict on illegal transition",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(pendingVideo, nil)
+				SUT := NewUpdateVideoStatusDBService(videoRepo)
+				_, err := SUT.UpdateStatus(context.Background(), uid, models.VideoStatusCompleted, "")
+				require.ErrorIs(t, err, ErrConflict)
+			},
+		},
+		{
+			name: "Should return ErrConflict when status changed concurrently",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(pendingVideo, nil)
+				videoRepo.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), models.VideoStatusPending).
+					Times(1).
+					Return(repositories.ErrStaleObject)
+				SUT := NewUpdateVideoStatusDBService(videoRepo)
+				_, err := SUT.UpdateStatus(context.Background(), uid, models.VideoStatusProcessing, "")
+				require.ErrorIs(t, err, ErrConflict)
+			},
+		},
+		{
+			name: "Should return ErrUpdateFailed when repository fails",
+			testCase: func(t *testing.T, ctrl *gomock.Controller) {
+				videoRepo := mock_repositories.NewMockVideoDB(ctrl)
+				videoRepo.EXPECT().GetByID(gomock.Any(), uid).Times(1).Return(pendingVideo, nil)
+				videoRepo.EXPECT().
+					UpdateStatus(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(errors.New("fake_error"))
+				SUT := NewUpdateVideoStatusDBService(videoRepo)
+				_, err := SUT.UpdateStatus(context.Background(), uid, models.VideoStatusFailed, "broken file")
+				require.ErrorIs(t, err, ErrUpdateFailed)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			tc.testCase(t, ctrl)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package setup
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/auth"
+	"github.com/ayrtonsato/video-catalog-golang/internal/middlewares"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/jwtauth"
+)
+
+const (
+	defaultAuthJWKSRefresh = 10 * time.Minute
+	defaultAuthLeeway      = 30 * time.Second
+)
+
+type Auth struct {
+	// Verifier is nil when no JWKS source is configured, only API keys are
+	// then accepted
+	Verifier *jwtauth.Verifier
+	Policy   auth.Policy
+	config   *Config
+}
+
+func NewAuth(config *Config) Auth {
+	return Auth{
+		config: config,
+	}
+}
+
+func (a *Auth) Start() error {
+	spec := a.config.AuthPolicy
+	if spec == "" {
+		spec = auth.DefaultPolicy
+	}
+	policy, err := auth.ParsePolicy(spec)
+	if err != nil {
+		return err
+	}
+	a.Policy = policy
+
+	var keys jwtauth.KeyProvider
+	switch {
+	case a.config.AuthJWKSURL != "" && a.config.AuthJWKSFile != "":
+		return errors.New("auth: set either AUTH_JWKS_URL or AUTH_JWKS_FILE")
+	case a.config.AuthJWKSFile != "":
+		set, err := jwtauth.LoadJWKSFile(a.config.AuthJWKSFile)
+		if err != nil {
+			return err
+		}
+		keys = set
+	case a.config.AuthJWKSURL != "":
+		refresh := a.config.AuthJWKSRefresh
+		if refresh <= 0 {
+			refresh = defaultAuthJWKSRefresh
+		}
+		remote := jwtauth.NewRemoteJWKS(a.config.AuthJWKSURL, refresh)
+		if err := remote.Fetch(); err != nil {
+			return err
+		}
+		keys = remote
+	default:
+		return nil
+	}
+	leeway := a.config.AuthLeeway
+	if leeway <= 0 {
+		leeway = defaultAuthLeeway
+	}
+	a.Verifier = jwtauth.NewVerifier(keys, jwtauth.Options{
+		Issuer:     a.config.AuthIssuer,
+		Audience:   a.config.AuthAudience,
+		RolesClaim: a.config.AuthRolesClaim,
+		Leeway:     leeway,
+	})
+	return nil
+}
+
+// TokenVerifier is nil rather than a nil *jwtauth.Verifier when no JWKS is
+// configured, so middlewares.Authenticate can tell bearer tokens apart
+func (a *Auth) TokenVerifier() middlewares.TokenVerifier {
+	if a.Verifier == nil {
+		return nil
+	}
+	return a.Verifier
+}
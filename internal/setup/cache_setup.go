@@ -0,0 +1,89 @@
+package setup
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/pkg/cache"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	defaultCacheTTL     = 5 * time.Minute
+	defaultCacheLRUSize = 10000
+)
+
+type Cache struct {
+	// ReadThrough is nil when CACHE_DRIVER is not set
+	ReadThrough *cache.ReadThrough
+	pool        *redis.Pool
+	config      *Config
+	log         logger.Logger
+}
+
+func NewCache(config *Config, log logger.Logger) Cache {
+	return Cache{
+		config: config,
+		log:    log,
+	}
+}
+
+// Start builds the cache of CACHE_DRIVER, the lru driver broadcasts
+// invalidations through Redis when REDIS_URL is set so other instances drop
+// their copies too
+func (c *Cache) Start() error {
+	ttl := c.config.CacheTTL
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+	var store cache.Cache
+	var bus cache.Bus
+	switch c.config.CacheDriver {
+	case "":
+		return nil
+	case "lru":
+		size := c.config.CacheLRUSize
+		if size <= 0 {
+			size = defaultCacheLRUSize
+		}
+		store = cache.NewLRU(size)
+		if c.config.RedisURL == "" {
+			c.log.Warnf("cache: REDIS_URL not set, invalidations are not broadcast to other instances")
+			break
+		}
+		pool, err := newRedisPool(c.config.RedisURL)
+		if err != nil {
+			return err
+		}
+		c.pool = pool
+		bus = cache.NewRedisBus(pool, "cache:invalidate")
+	case "redis":
+		if c.config.RedisURL == "" {
+			return fmt.Errorf("cache: redis driver needs REDIS_URL")
+		}
+		pool, err := newRedisPool(c.config.RedisURL)
+		if err != nil {
+			return err
+		}
+		c.pool = pool
+		store = cache.NewRedis(pool, "cache:")
+	default:
+		return fmt.Errorf("cache: unknown driver %q", c.config.CacheDriver)
+	}
+	readThrough, err := cache.NewReadThrough(store, bus, ttl, c.config.CacheLoadTimeout)
+	if err != nil {
+		return err
+	}
+	c.ReadThrough = readThrough
+	return nil
+}
+
+func (c *Cache) Close() {
+	if c.ReadThrough != nil {
+		_ = c.ReadThrough.Close()
+	}
+	if c.pool != nil {
+		_ = c.pool.Close()
+	}
+}
@@ -3,6 +3,7 @@ package setup
 import (
 	"flag"
 	"github.com/spf13/viper"
+	"time"
 )
 
 type Config struct {
@@ -15,6 +16,120 @@ type Config struct {
 	DBDatabase    string `mapstructure:"DB_DATABASE"`
 	DBUsername    string `mapstructure:"DB_USERNAME"`
 	DBPassword    string `mapstructure:"DB_PASSWORD"`
+	// DBMaxOpenConns caps the pool, readiness fails while every connection is
+	// in use. Zero leaves the pool unbounded
+	DBMaxOpenConns int `mapstructure:"DB_MAX_OPEN_CONNS"`
+	DBMaxIdleConns int `mapstructure:"DB_MAX_IDLE_CONNS"`
+	// MigrateOnStart applies pending migrations before serving, instances
+	// started together wait on each other through an advisory lock
+	MigrateOnStart bool `mapstructure:"MIGRATE_ON_START"`
+
+	// ServerReadTimeout and ServerWriteTimeout bound a whole request and
+	// response, they are unbounded when zero since video uploads can take
+	// long. ServerShutdownTimeout is how long in-flight requests are drained
+	// on SIGINT or SIGTERM
+	ServerReadTimeout       time.Duration `mapstructure:"SERVER_READ_TIMEOUT"`
+	ServerReadHeaderTimeout time.Duration `mapstructure:"SERVER_READ_HEADER_TIMEOUT"`
+	ServerWriteTimeout      time.Duration `mapstructure:"SERVER_WRITE_TIMEOUT"`
+	ServerIdleTimeout       time.Duration `mapstructure:"SERVER_IDLE_TIMEOUT"`
+	ServerShutdownTimeout   time.Duration `mapstructure:"SERVER_SHUTDOWN_TIMEOUT"`
+
+	StoragePath string `mapstructure:"STORAGE_PATH"`
+	// MediaSigningKeys holds every accepted key as "kid:secret,kid:secret",
+	// new urls are signed with MediaSigningKeyID so a key can be rotated by
+	// adding a new one, switching the id and dropping the old one once the
+	// urls it signed are expired
+	MediaSigningKeys  string        `mapstructure:"MEDIA_SIGNING_KEYS"`
+	MediaSigningKeyID string        `mapstructure:"MEDIA_SIGNING_KEY_ID"`
+	MediaBaseURL      string        `mapstructure:"MEDIA_BASE_URL"`
+	MediaURLTTL       time.Duration `mapstructure:"MEDIA_URL_TTL"`
+	// ImageVariantWidths lists the widths thumbnails and banners are resized
+	// to on upload, as "320,640,1280"
+	ImageVariantWidths  string `mapstructure:"IMAGE_VARIANT_WIDTHS"`
+	ImageJPEGQuality    int    `mapstructure:"IMAGE_JPEG_QUALITY"`
+	UploadMaxBytes      int64  `mapstructure:"UPLOAD_MAX_BYTES"`
+	VideoUploadMaxBytes int64  `mapstructure:"VIDEO_UPLOAD_MAX_BYTES"`
+
+	// MediaGCInterval schedules the media garbage collector when set, files
+	// younger than MediaGCGracePeriod are never collected
+	MediaGCInterval    time.Duration `mapstructure:"MEDIA_GC_INTERVAL"`
+	MediaGCGracePeriod time.Duration `mapstructure:"MEDIA_GC_GRACE_PERIOD"`
+	MediaGCDryRun      bool          `mapstructure:"MEDIA_GC_DRY_RUN"`
+
+	// AuthJWKSURL or AuthJWKSFile enables bearer token authentication, the
+	// file is meant for offline use. Tokens must be signed with RS256 or
+	// ES256 and match AuthIssuer and AuthAudience when they are set
+	AuthJWKSURL     string        `mapstructure:"AUTH_JWKS_URL"`
+	AuthJWKSFile    string        `mapstructure:"AUTH_JWKS_FILE"`
+	AuthJWKSRefresh time.Duration `mapstructure:"AUTH_JWKS_REFRESH"`
+	AuthIssuer      string        `mapstructure:"AUTH_ISSUER"`
+	AuthAudience    string        `mapstructure:"AUTH_AUDIENCE"`
+	AuthRolesClaim  string        `mapstructure:"AUTH_ROLES_CLAIM"`
+	AuthLeeway      time.Duration `mapstructure:"AUTH_LEEWAY"`
+	// AuthPolicy grants permissions to roles as
+	// "admin=*;editor=category:create,category:update", auth.DefaultPolicy
+	// is used when empty
+	AuthPolicy string `mapstructure:"AUTH_POLICY"`
+	// AuthAnonymous lets requests without credentials through with the
+	// auth.RoleAnonymous role, which the default policy only lets read the
+	// catalog
+	AuthAnonymous bool `mapstructure:"AUTH_ANONYMOUS"`
+
+	// RateLimitDefault limits every route of a client as "100/m", routes in
+	// RateLimitRoutes get their own bucket as "POST /category=30/m;...".
+	// RateLimitIP limits all requests of an address before authentication.
+	// Buckets are kept in Redis when RedisURL is set
+	RedisURL         string `mapstructure:"REDIS_URL"`
+	RateLimitDefault string `mapstructure:"RATE_LIMIT_DEFAULT"`
+	RateLimitRoutes  string `mapstructure:"RATE_LIMIT_ROUTES"`
+	RateLimitIP      string `mapstructure:"RATE_LIMIT_IP"`
+	// TrustedProxies lists the proxies, as "10.0.0.0/8,192.168.1.10", whose
+	// X-Forwarded-For is followed to find the client address. The header is
+	// ignored when it is empty
+	TrustedProxies string `mapstructure:"TRUSTED_PROXIES"`
+
+	// HealthCheckTimeout bounds each readiness check. The database pool is
+	// not ready once queries waited HealthPoolWaitThreshold on average for a
+	// connection in HealthPoolWaitChecks checks in a row
+	HealthCheckTimeout      time.Duration `mapstructure:"HEALTH_CHECK_TIMEOUT"`
+	HealthPoolWaitThreshold time.Duration `mapstructure:"HEALTH_POOL_WAIT_THRESHOLD"`
+	HealthPoolWaitChecks    int           `mapstructure:"HEALTH_POOL_WAIT_CHECKS"`
+
+	// TracingExporter exports spans with otlp or stdout, tracing is disabled
+	// when it is empty. TracingOTLPEndpoint is the host:port of the
+	// collector's OTLP/HTTP receiver, usually 4318
+	TracingExporter     string  `mapstructure:"TRACING_EXPORTER"`
+	TracingOTLPEndpoint string  `mapstructure:"TRACING_OTLP_ENDPOINT"`
+	TracingOTLPInsecure bool    `mapstructure:"TRACING_OTLP_INSECURE"`
+	TracingServiceName  string  `mapstructure:"TRACING_SERVICE_NAME"`
+	TracingSampleRate   float64 `mapstructure:"TRACING_SAMPLE_RATE"`
+
+	// ErrorReportURL receives recovered panics as JSON, ErrorReportTimeout
+	// bounds each delivery
+	ErrorReportURL     string        `mapstructure:"ERROR_REPORT_URL"`
+	ErrorReportTimeout time.Duration `mapstructure:"ERROR_REPORT_TIMEOUT"`
+
+	// QueryTimeout bounds the database queries of a request, routes in
+	// QueryTimeoutRoutes get their own timeout as "GET /category=2s;..."
+	QueryTimeout       time.Duration `mapstructure:"QUERY_TIMEOUT"`
+	QueryTimeoutRoutes string        `mapstructure:"QUERY_TIMEOUT_ROUTES"`
+
+	// CacheDriver caches category and genre reads in process with lru or in
+	// Redis with redis, reads are not cached when it is empty. A miss is
+	// loaded once for every request waiting on it, within CacheLoadTimeout
+	CacheDriver      string        `mapstructure:"CACHE_DRIVER"`
+	CacheTTL         time.Duration `mapstructure:"CACHE_TTL"`
+	CacheLRUSize     int           `mapstructure:"CACHE_LRU_SIZE"`
+	CacheLoadTimeout time.Duration `mapstructure:"CACHE_LOAD_TIMEOUT"`
+
+	// AMQPURL enables the encoder results consumer when set. A message that
+	// fails is retried after EncoderRetryDelay and dead lettered after
+	// EncoderMaxAttempts failures
+	AMQPURL             string        `mapstructure:"AMQP_URL"`
+	EncoderResultsQueue string        `mapstructure:"ENCODER_RESULTS_QUEUE"`
+	EncoderPrefetch     int           `mapstructure:"ENCODER_PREFETCH"`
+	EncoderRetryDelay   time.Duration `mapstructure:"ENCODER_RETRY_DELAY"`
+	EncoderMaxAttempts  int           `mapstructure:"ENCODER_MAX_ATTEMPTS"`
 }
 
 func (c *Config) Load(path string) error {
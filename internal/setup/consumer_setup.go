@@ -0,0 +1,81 @@
+package setup
+
+import (
+	"database/sql"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/consumers"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+)
+
+const (
+	defaultEncoderResultsQueue = "videos.encoded"
+	defaultEncoderPrefetch     = 10
+)
+
+type Consumers struct {
+	db          *sql.DB
+	config      *Config
+	log         logger.Logger
+	subscribers []*consumers.AMQPSubscriber
+}
+
+func NewConsumers(db *sql.DB, config *Config, log logger.Logger) Consumers {
+	return Consumers{
+		db:     db,
+		config: config,
+		log:    log,
+	}
+}
+
+func (c *Consumers) Start() error {
+	if c.config.AMQPURL == "" {
+		c.log.Info("consumers: AMQP_URL is not set, encoder results consumer disabled")
+		return nil
+	}
+	queue := c.config.EncoderResultsQueue
+	if queue == "" {
+		queue = defaultEncoderResultsQueue
+	}
+	prefetch := c.config.EncoderPrefetch
+	if prefetch <= 0 {
+		prefetch = defaultEncoderPrefetch
+	}
+
+	videoRepo := repositories.NewVideoRepository(c.db, c.log)
+	deadLetterRepo := repositories.NewDeadLetterRepository(c.db, c.log)
+	encoder := services.NewEncoderResultDBService(&videoRepo)
+	consumer := consumers.NewEncoderConsumer(&encoder, &deadLetterRepo, c.log)
+
+	subscriber := consumers.NewAMQPSubscriber(c.config.AMQPURL, queue, prefetch,
+		c.config.EncoderRetryDelay, c.config.EncoderMaxAttempts, &consumer, c.log)
+	if err := subscriber.Start(); err != nil {
+		return err
+	}
+	c.subscribers = append(c.subscribers, subscriber)
+	return nil
+}
+
+func (c *Consumers) Enabled() bool {
+	return len(c.subscribers) > 0
+}
+
+// Connected reports whether every subscriber holds a broker connection
+func (c *Consumers) Connected() bool {
+	for _, subscriber := range c.subscribers {
+		if !subscriber.Connected() {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Consumers) Close() {
+	for _, subscriber := range c.subscribers {
+		if err := subscriber.Close(); err != nil {
+			c.log.Errorf("consumers: failed to close subscriber: %v", err)
+		}
+	}
+	c.subscribers = nil
+}
@@ -1,11 +1,18 @@
 package setup
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	_ "github.com/jackc/pgx/v4/stdlib"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/tracing"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/sqltrace"
+	"github.com/jackc/pgx/v4/stdlib"
 )
 
+const dbPingTimeout = 5 * time.Second
+
 type DB struct {
 	DB       *sql.DB
 	config   *Config
@@ -27,10 +34,22 @@ func NewDB(config *Config) DB {
 }
 
 func (d *DB) StartConn() error {
-	db, err := sql.Open("pgx", d.dbSource)
-	if err != nil {
-		return err
+	db := sql.OpenDB(sqltrace.NewConnector(stdlib.GetDefaultDriver(), d.dbSource, tracing.Tracer()))
+	if d.config.DBMaxOpenConns > 0 {
+		db.SetMaxOpenConns(d.config.DBMaxOpenConns)
+	}
+	if d.config.DBMaxIdleConns > 0 {
+		db.SetMaxIdleConns(d.config.DBMaxIdleConns)
 	}
 	d.DB = db
-	return nil
+	ctx, cancel := context.WithTimeout(context.Background(), dbPingTimeout)
+	defer cancel()
+	return db.PingContext(ctx)
+}
+
+func (d *DB) Close() error {
+	if d.DB == nil {
+		return nil
+	}
+	return d.DB.Close()
 }
@@ -0,0 +1,15 @@
+package setup
+
+import (
+	"github.com/ayrtonsato/video-catalog-golang/pkg/errreport"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+)
+
+// NewErrorReporter posts recovered panics to ERROR_REPORT_URL, they are only
+// logged when it is empty
+func NewErrorReporter(config *Config, log logger.Logger) errreport.Reporter {
+	if config.ErrorReportURL == "" {
+		return errreport.Nop{}
+	}
+	return errreport.NewWebhook(config.ErrorReportURL, config.ErrorReportTimeout, log)
+}
@@ -1,24 +1,56 @@
 package setup
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
 
+	"github.com/ayrtonsato/video-catalog-golang/internal/auth"
+	"github.com/ayrtonsato/video-catalog-golang/internal/metrics"
+	"github.com/ayrtonsato/video-catalog-golang/internal/middlewares"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
 	"github.com/ayrtonsato/video-catalog-golang/internal/routes"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	"github.com/ayrtonsato/video-catalog-golang/internal/tracing"
 	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	defaultReadHeaderTimeout = 10 * time.Second
+	defaultIdleTimeout       = 2 * time.Minute
+	defaultShutdownTimeout   = 30 * time.Second
 )
 
 type Server struct {
 	store  *sql.DB
+	media  *Media
+	auth   *Auth
+	limits *RateLimit
+	cache  *Cache
+	health *Health
 	router *gin.Engine
+	http   *http.Server
 	config *Config
 	logger logger.Logger
 }
 
-func NewServer(store *sql.DB, config *Config, logger logger.Logger) Server {
+func NewServer(store *sql.DB,
+	media *Media,
+	auth *Auth,
+	limits *RateLimit,
+	cache *Cache,
+	health *Health,
+	config *Config,
+	logger logger.Logger) Server {
 	server := Server{
-		store: store, config: config, logger: logger,
+		store: store, media: media, auth: auth, limits: limits, cache: cache, health: health, config: config, logger: logger,
 	}
 	server.setupRouter()
 	server.initRoutes()
@@ -26,15 +58,159 @@ func NewServer(store *sql.DB, config *Config, logger logger.Logger) Server {
 }
 
 func (s *Server) setupRouter() {
-	router := gin.Default()
+	router := gin.New()
+	// X-Forwarded-For is set by the caller, middlewares.ClientIP only follows
+	// it through the configured proxies
+	router.ForwardedByClientIP = false
+	router.HandleMethodNotAllowed = true
+	router.NoRoute(middlewares.NotFound())
+	router.NoMethod(middlewares.MethodNotAllowed())
 	s.router = router
+	timeouts, err := parseRouteTimeouts(s.config.QueryTimeoutRoutes)
+	if err != nil {
+		s.logger.Fatalf("gin-server: invalid QUERY_TIMEOUT_ROUTES: %v", err)
+	}
+	proxies, err := parseTrustedProxies(s.config.TrustedProxies)
+	if err != nil {
+		s.logger.Fatalf("gin-server: invalid TRUSTED_PROXIES: %v", err)
+	}
+	s.router.Use(middlewares.ClientIP(proxies))
+	s.router.Use(middlewares.Tracing(tracing.Tracer(), otel.GetTextMapPropagator(), serviceName(s.config)))
+	s.router.Use(middlewares.RequestID(s.logger))
+	s.router.Use(middlewares.AccessLog(s.logger))
+	s.router.Use(middlewares.Metrics(metrics.HTTPRequestDuration))
+	s.router.Use(middlewares.Recovery(s.logger, NewErrorReporter(s.config, s.logger)))
+	s.router.Use(middlewares.Timeout(s.config.QueryTimeout, timeouts))
+	if s.auth.Verifier == nil {
+		s.logger.Warnf("auth: no JWKS configured, only api keys are accepted")
+	}
+	if s.config.AuthAnonymous {
+		s.logger.Warnf("auth: requests without credentials are let through as %s", auth.RoleAnonymous)
+	}
+	// the address limit comes first so failed credentials are limited too
+	if s.limits.Enabled() && s.limits.IP.Burst > 0 {
+		s.router.Use(middlewares.RateLimitIP(s.limits.Limiter, s.limits.IP, s.logger))
+	}
+	apiKeyRepository := repositories.NewAPIKeyRepository(s.store, s.logger)
+	apiKeys := services.NewAPIKeysDBService(&apiKeyRepository)
+	s.router.Use(middlewares.Authenticate(s.auth.TokenVerifier(), &apiKeys, s.config.AuthAnonymous,
+		s.logger, routes.PublicRoutes...))
+	// route limits apply after authentication so clients are told apart by
+	// their credentials rather than their address
+	if s.limits.Enabled() && !s.limits.Rules.Empty() {
+		s.router.Use(middlewares.RateLimit(s.limits.Limiter, s.limits.Rules, s.logger))
+	}
+	s.router.Use(middlewares.Authorize(s.auth.Policy, routes.Permissions, s.logger, routes.PublicRoutes...))
+	s.router.Use(middlewares.UUIDParams("id"))
 }
 
 func (s *Server) initRoutes() {
-	routes.NewCategoryRoutes(s.router, s.store, s.logger).Routes()
+	routes.NewCategoryRoutes(s.router, s.store, s.logger, s.cache.ReadThrough).Routes()
+	routes.NewVideoRoutes(s.router, s.store, s.logger, s.media.Options()).Routes()
+	routes.NewAdminRoutes(s.router, s.store, s.logger).Routes()
+	routes.NewAPIKeyRoutes(s.router, s.store, s.logger).Routes()
+	routes.NewMediaRoutes(s.router, s.store, s.logger, s.media.Options()).Routes()
+	routes.NewSubtitleRoutes(s.router, s.store, s.logger, s.media.Options()).Routes()
+	routes.NewHealthRoutes(s.router, s.health.Checker).Routes()
+	routes.NewMetricsRoutes(s.router).Routes()
 }
 
+// Start serves requests until Shutdown is called, it returns nil once the
+// server was shut down
 func (s *Server) Start() error {
-	addr := fmt.Sprintf("%v:%v", s.config.ServerAddress, s.config.Port)
-	return s.router.Run(addr)
+	readHeaderTimeout := s.config.ServerReadHeaderTimeout
+	if readHeaderTimeout <= 0 {
+		readHeaderTimeout = defaultReadHeaderTimeout
+	}
+	idleTimeout := s.config.ServerIdleTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = defaultIdleTimeout
+	}
+	s.http = &http.Server{
+		Addr:              fmt.Sprintf("%v:%v", s.config.ServerAddress, s.config.Port),
+		Handler:           s.router,
+		ReadTimeout:       s.config.ServerReadTimeout,
+		ReadHeaderTimeout: readHeaderTimeout,
+		WriteTimeout:      s.config.ServerWriteTimeout,
+		IdleTimeout:       idleTimeout,
+	}
+	s.logger.Infof("gin-server: listening on %v", s.http.Addr)
+	err := s.http.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// ShutdownTimeout is how long Shutdown should wait for in-flight requests
+func (s *Server) ShutdownTimeout() time.Duration {
+	if s.config.ServerShutdownTimeout <= 0 {
+		return defaultShutdownTimeout
+	}
+	return s.config.ServerShutdownTimeout
+}
+
+// Shutdown stops accepting connections and waits for in-flight requests to
+// finish, connections still open when ctx is done are closed
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.http == nil {
+		return nil
+	}
+	err := s.http.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		s.logger.Warnf("gin-server: drain deadline exceeded, closing remaining connections")
+		return s.http.Close()
+	}
+	return err
+}
+
+// parseTrustedProxies reads addresses and networks written as
+// "10.0.0.0/8,192.168.1.10"
+func parseTrustedProxies(spec string) ([]*net.IPNet, error) {
+	proxies := make([]*net.IPNet, 0)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid address %q", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+// parseRouteTimeouts reads timeouts written as "METHOD path=duration;..."
+func parseRouteTimeouts(spec string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		i := strings.LastIndex(entry, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("missing timeout in %q", entry)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(entry[i+1:]))
+		if err != nil {
+			return nil, err
+		}
+		timeouts[strings.Join(strings.Fields(entry[:i]), " ")] = timeout
+	}
+	return timeouts, nil
 }
@@ -0,0 +1,113 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/db/migrations"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/health"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/gomodule/redigo/redis"
+)
+
+const defaultHealthTimeout = 2 * time.Second
+
+type Health struct {
+	Checker   *health.Checker
+	db        *sql.DB
+	limits    *RateLimit
+	cache     *Cache
+	consumers *Consumers
+	config    *Config
+	log       logger.Logger
+}
+
+func NewHealth(db *sql.DB, limits *RateLimit, cache *Cache, consumers *Consumers, config *Config, log logger.Logger) Health {
+	return Health{
+		db:        db,
+		limits:    limits,
+		cache:     cache,
+		consumers: consumers,
+		config:    config,
+		log:       log,
+	}
+}
+
+// Start registers the readiness checks, Redis and the broker are only
+// checked when they are configured
+func (h *Health) Start() error {
+	expected, err := migrations.ExpectedVersion()
+	if err != nil {
+		return err
+	}
+	timeout := h.config.HealthCheckTimeout
+	if timeout <= 0 {
+		timeout = defaultHealthTimeout
+	}
+	h.Checker = health.NewChecker(timeout)
+	h.Checker.Add("database", h.checkDatabase)
+	h.Checker.Add("database_pool", health.NewPoolWaits(h.db.Stats,
+		h.config.HealthPoolWaitThreshold, h.config.HealthPoolWaitChecks).Check)
+	h.Checker.Add("migrations", h.checkMigrations(expected))
+	if h.limits != nil && h.limits.pool != nil {
+		h.Checker.Add("redis_ratelimit", checkRedis(h.limits.pool))
+	}
+	if h.cache != nil && h.cache.pool != nil {
+		h.Checker.Add("redis_cache", checkRedis(h.cache.pool))
+	}
+	if h.consumers != nil && h.consumers.Enabled() {
+		h.Checker.Add("broker", h.checkBroker)
+	}
+	return nil
+}
+
+func (h *Health) checkDatabase(ctx context.Context) (string, error) {
+	return "", h.db.PingContext(ctx)
+}
+
+// checkMigrations fails when the database is behind the embedded migrations
+// or a migration failed halfway, a newer schema is accepted so instances of
+// the previous release stay ready during a rollout
+func (h *Health) checkMigrations(expected uint) health.Check {
+	repository := repositories.NewMigrationRepository(h.db, h.log)
+	return func(ctx context.Context) (string, error) {
+		version, dirty, err := repository.Version(ctx)
+		if errors.Is(err, repositories.ErrNoResult) {
+			return fmt.Sprintf("expected %d", expected), errors.New("no migration applied")
+		}
+		if err != nil {
+			return "", err
+		}
+		detail := fmt.Sprintf("applied %d, expected %d", version, expected)
+		if dirty {
+			return detail, errors.New("migration is dirty")
+		}
+		if version < expected {
+			return detail, errors.New("migrations are pending")
+		}
+		return detail, nil
+	}
+}
+
+func (h *Health) checkBroker(ctx context.Context) (string, error) {
+	if !h.consumers.Connected() {
+		return "", errors.New("broker connection is down")
+	}
+	return "", nil
+}
+
+func checkRedis(pool *redis.Pool) health.Check {
+	return func(ctx context.Context) (string, error) {
+		conn, err := pool.GetContext(ctx)
+		if err != nil {
+			return "", err
+		}
+		defer conn.Close()
+		_, err = conn.Do("PING")
+		return "", err
+	}
+}
@@ -0,0 +1,58 @@
+package setup
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/jobs"
+	"github.com/ayrtonsato/video-catalog-golang/internal/repositories"
+	"github.com/ayrtonsato/video-catalog-golang/internal/services"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+)
+
+const defaultMediaGCGracePeriod = 24 * time.Hour
+
+type Jobs struct {
+	db      *sql.DB
+	media   *Media
+	config  *Config
+	log     logger.Logger
+	mediaGC *jobs.MediaGCJob
+}
+
+func NewJobs(db *sql.DB, media *Media, config *Config, log logger.Logger) Jobs {
+	return Jobs{
+		db:     db,
+		media:  media,
+		config: config,
+		log:    log,
+	}
+}
+
+// NewMediaGC builds the media garbage collector used by the scheduled job
+// and the gc command
+func NewMediaGC(db *sql.DB, media *Media, config *Config, log logger.Logger) services.MediaGCService {
+	grace := config.MediaGCGracePeriod
+	if grace <= 0 {
+		grace = defaultMediaGCGracePeriod
+	}
+	references := repositories.NewMediaReferenceRepository(db, log)
+	return services.NewMediaGCService(&references, media.Storage, grace)
+}
+
+func (j *Jobs) Start() {
+	if j.config.MediaGCInterval <= 0 {
+		j.log.Info("jobs: MEDIA_GC_INTERVAL is not set, media gc disabled")
+		return
+	}
+	collector := NewMediaGC(j.db, j.media, j.config, j.log)
+	j.mediaGC = jobs.NewMediaGCJob(&collector, j.config.MediaGCInterval, j.config.MediaGCDryRun, j.log)
+	j.mediaGC.Start()
+}
+
+func (j *Jobs) Close() {
+	if j.mediaGC != nil {
+		j.mediaGC.Close()
+		j.mediaGC = nil
+	}
+}
@@ -0,0 +1,92 @@
+package setup
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/internal/routes"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/signedurl"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/storage"
+)
+
+const (
+	defaultMediaURLTTL         = 15 * time.Minute
+	defaultImageVariantWidths  = "320,640,1280"
+	defaultImageJPEGQuality    = 85
+	defaultUploadMaxBytes      = 10 << 20
+	defaultVideoUploadMaxBytes = 4 << 30
+)
+
+type Media struct {
+	Storage     storage.Storage
+	Signer      signedurl.Signer
+	ImageWidths []int
+	config      *Config
+}
+
+func NewMedia(config *Config) Media {
+	return Media{
+		config: config,
+	}
+}
+
+func (m *Media) Start() error {
+	keys, err := signedurl.ParseKeys(m.config.MediaSigningKeys)
+	if err != nil {
+		return err
+	}
+	signer, err := signedurl.NewSigner(m.config.MediaSigningKeyID, keys)
+	if err != nil {
+		return err
+	}
+	if m.config.MediaURLTTL <= 0 {
+		m.config.MediaURLTTL = defaultMediaURLTTL
+	}
+	if m.config.ImageVariantWidths == "" {
+		m.config.ImageVariantWidths = defaultImageVariantWidths
+	}
+	widths, err := parseWidths(m.config.ImageVariantWidths)
+	if err != nil {
+		return err
+	}
+	if m.config.ImageJPEGQuality <= 0 || m.config.ImageJPEGQuality > 100 {
+		m.config.ImageJPEGQuality = defaultImageJPEGQuality
+	}
+	if m.config.UploadMaxBytes <= 0 {
+		m.config.UploadMaxBytes = defaultUploadMaxBytes
+	}
+	if m.config.VideoUploadMaxBytes <= 0 {
+		m.config.VideoUploadMaxBytes = defaultVideoUploadMaxBytes
+	}
+	m.Signer = signer
+	m.ImageWidths = widths
+	m.Storage = storage.NewLocalStorage(m.config.StoragePath)
+	return nil
+}
+
+func (m *Media) Options() routes.MediaOptions {
+	return routes.MediaOptions{
+		Storage:        m.Storage,
+		Signer:         m.Signer,
+		BaseURL:        m.config.MediaBaseURL,
+		URLTTL:         m.config.MediaURLTTL,
+		ImageWidths:    m.ImageWidths,
+		JPEGQuality:    m.config.ImageJPEGQuality,
+		MaxUploadBytes: m.config.UploadMaxBytes,
+		MaxVideoBytes:  m.config.VideoUploadMaxBytes,
+	}
+}
+
+func parseWidths(value string) ([]int, error) {
+	var widths []int
+	for _, part := range strings.Split(value, ",") {
+		width, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || width <= 0 {
+			return nil, fmt.Errorf("media: invalid image variant width %q", part)
+		}
+		widths = append(widths, width)
+	}
+	return widths, nil
+}
@@ -0,0 +1,14 @@
+package setup
+
+import (
+	"database/sql"
+
+	"github.com/ayrtonsato/video-catalog-golang/db/migrations"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/migrate"
+)
+
+// NewMigrator builds the runner of the migrations embedded in the binary
+func NewMigrator(db *sql.DB, log logger.Logger) (*migrate.Migrator, error) {
+	return migrate.New(db, migrations.FS, log)
+}
@@ -0,0 +1,68 @@
+package setup
+
+import (
+	"strings"
+
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"github.com/ayrtonsato/video-catalog-golang/pkg/ratelimit"
+	"github.com/gomodule/redigo/redis"
+)
+
+type RateLimit struct {
+	// Limiter is nil when no limit is configured
+	Limiter ratelimit.Limiter
+	Rules   ratelimit.Rules
+	// IP limits every request of an address before authentication, it is
+	// zero when RATE_LIMIT_IP is empty
+	IP     ratelimit.Limit
+	pool   *redis.Pool
+	config *Config
+	log    logger.Logger
+}
+
+func NewRateLimit(config *Config, log logger.Logger) RateLimit {
+	return RateLimit{
+		config: config,
+		log:    log,
+	}
+}
+
+// Start keeps buckets in Redis when REDIS_URL is set and in memory otherwise,
+// in memory limits only hold for a single instance
+func (r *RateLimit) Start() error {
+	rules, err := ratelimit.ParseRules(r.config.RateLimitDefault, r.config.RateLimitRoutes)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(r.config.RateLimitIP) != "" {
+		if r.IP, err = ratelimit.ParseLimit(r.config.RateLimitIP); err != nil {
+			return err
+		}
+	}
+	if rules.Empty() && r.IP.Burst == 0 {
+		return nil
+	}
+	r.Rules = rules
+	if r.config.RedisURL == "" {
+		r.log.Warnf("ratelimit: REDIS_URL not set, limits are kept per instance")
+		r.Limiter = ratelimit.NewMemoryLimiter()
+		return nil
+	}
+	pool, err := newRedisPool(r.config.RedisURL)
+	if err != nil {
+		return err
+	}
+	r.pool = pool
+	r.Limiter = ratelimit.NewRedisLimiter(pool, "ratelimit:")
+	return nil
+}
+
+func (r *RateLimit) Enabled() bool {
+	return r.Limiter != nil
+}
+
+func (r *RateLimit) Close() {
+	if r.pool != nil {
+		_ = r.pool.Close()
+	}
+}
@@ -0,0 +1,28 @@
+package setup
+
+import (
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// newRedisPool connects to url and checks the server answers
+func newRedisPool(url string) (*redis.Pool, error) {
+	pool := &redis.Pool{
+		MaxIdle:     10,
+		IdleTimeout: 4 * time.Minute,
+		Dial: func() (redis.Conn, error) {
+			return redis.DialURL(url,
+				redis.DialConnectTimeout(2*time.Second),
+				redis.DialReadTimeout(time.Second),
+				redis.DialWriteTimeout(time.Second))
+		},
+	}
+	conn := pool.Get()
+	defer conn.Close()
+	if _, err := conn.Do("PING"); err != nil {
+		_ = pool.Close()
+		return nil, err
+	}
+	return pool, nil
+}
@@ -2,6 +2,7 @@ package setup
 
 import (
 	"database/sql"
+	"github.com/ayrtonsato/video-catalog-golang/internal/auth"
 	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
@@ -13,6 +14,11 @@ import (
 type TestSetup struct {
 	Config *Config
 	DB     *sql.DB
+	Media  *Media
+	Auth   *Auth
+	Limits *RateLimit
+	Cache  *Cache
+	Health *Health
 	Log    logger.Logger
 	Server *gin.Engine
 }
@@ -46,10 +52,76 @@ func (ts *TestSetup) BuildDB(t *testing.T, c *Config) *TestSetup {
 	return ts
 }
 
+func (ts *TestSetup) BuildMedia(t *testing.T) *TestSetup {
+	media := NewMedia(ts.Config)
+	err := media.Start()
+	ts.Media = &media
+	require.NoError(t, err)
+
+	return ts
+}
+
+// BuildAuth lets requests without credentials through with every permission
+// so route tests don't need an identity provider
+func (ts *TestSetup) BuildAuth(t *testing.T) *TestSetup {
+	ts.Config.AuthAnonymous = true
+	if ts.Config.AuthPolicy == "" {
+		ts.Config.AuthPolicy = auth.DefaultPolicy + ";" + auth.RoleAnonymous + "=*"
+	}
+	auth := NewAuth(ts.Config)
+	err := auth.Start()
+	ts.Auth = &auth
+	require.NoError(t, err)
+
+	return ts
+}
+
+func (ts *TestSetup) BuildRateLimit(t *testing.T) *TestSetup {
+	limits := NewRateLimit(ts.Config, ts.Log)
+	err := limits.Start()
+	ts.Limits = &limits
+	require.NoError(t, err)
+
+	return ts
+}
+
+func (ts *TestSetup) BuildCache(t *testing.T) *TestSetup {
+	cache := NewCache(ts.Config, ts.Log)
+	err := cache.Start()
+	ts.Cache = &cache
+	require.NoError(t, err)
+
+	return ts
+}
+
+func (ts *TestSetup) BuildHealth(t *testing.T) *TestSetup {
+	health := NewHealth(ts.DB, ts.Limits, ts.Cache, nil, ts.Config, ts.Log)
+	err := health.Start()
+	ts.Health = &health
+	require.NoError(t, err)
+
+	return ts
+}
+
 func (ts *TestSetup) BuildServer(t *testing.T) *TestSetup {
 	gin.SetMode(gin.TestMode)
 
-	server := NewServer(ts.DB, ts.Config, ts.Log)
+	if ts.Media == nil {
+		ts.BuildMedia(t)
+	}
+	if ts.Auth == nil {
+		ts.BuildAuth(t)
+	}
+	if ts.Limits == nil {
+		ts.BuildRateLimit(t)
+	}
+	if ts.Cache == nil {
+		ts.BuildCache(t)
+	}
+	if ts.Health == nil {
+		ts.BuildHealth(t)
+	}
+	server := NewServer(ts.DB, ts.Media, ts.Auth, ts.Limits, ts.Cache, ts.Health, ts.Config, ts.Log)
 	ts.Server = server.router
 
 	return ts
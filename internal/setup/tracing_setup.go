@@ -0,0 +1,97 @@
+package setup
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+const (
+	defaultServiceName       = "video-catalog"
+	defaultTracingSampleRate = 1.0
+)
+
+type Tracing struct {
+	provider *sdktrace.TracerProvider
+	config   *Config
+	log      logger.Logger
+}
+
+func NewTracing(config *Config, log logger.Logger) Tracing {
+	return Tracing{
+		config: config,
+		log:    log,
+	}
+}
+
+// Start installs the global tracer provider of TRACING_EXPORTER, otlp sends
+// spans over HTTP to TRACING_OTLP_ENDPOINT and stdout prints them for local
+// use. W3C trace context is propagated even when tracing is disabled so
+// callers' traces are not broken
+func (t *Tracing) Start() error {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch t.config.TracingExporter {
+	case "":
+		t.log.Info("tracing: TRACING_EXPORTER is not set, spans are not exported")
+		return nil
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	case "otlp":
+		options := []otlptracehttp.Option{}
+		if t.config.TracingOTLPEndpoint != "" {
+			options = append(options, otlptracehttp.WithEndpoint(t.config.TracingOTLPEndpoint))
+		}
+		if t.config.TracingOTLPInsecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(context.Background(), options...)
+	default:
+		return fmt.Errorf("tracing: unknown exporter %q", t.config.TracingExporter)
+	}
+	if err != nil {
+		return err
+	}
+
+	rate := t.config.TracingSampleRate
+	if rate <= 0 {
+		rate = defaultTracingSampleRate
+	}
+	t.provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(rate))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceNameKey.String(serviceName(t.config)))),
+	)
+	otel.SetTracerProvider(t.provider)
+	return nil
+}
+
+func serviceName(config *Config) string {
+	if config.TracingServiceName == "" {
+		return defaultServiceName
+	}
+	return config.TracingServiceName
+}
+
+// Close flushes the spans still buffered
+func (t *Tracing) Close(ctx context.Context) {
+	if t.provider == nil {
+		return
+	}
+	if err := t.provider.Shutdown(ctx); err != nil {
+		t.log.Errorf("tracing: failed to flush spans: %v", err)
+	}
+}
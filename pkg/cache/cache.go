@@ -0,0 +1,203 @@
+// Package cache caches JSON encoded values in process or in Redis and loads
+// missing ones once however many requests ask for them at the same time
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Cache stores JSON encoded values, a key ending with * passed to Delete
+// removes every key starting with the rest of it
+type Cache interface {
+	Get(key string) ([]byte, bool, error)
+	Set(key string, value []byte, ttl time.Duration) error
+	Delete(keys ...string) error
+}
+
+// Bus carries invalidated keys to the other instances so caches kept in
+// process do not serve stale values
+type Bus interface {
+	Publish(keys []string) error
+	Subscribe(handler func(keys []string)) error
+	Close() error
+}
+
+// DefaultLoadTimeout bounds a shared load when NewReadThrough is given none
+const DefaultLoadTimeout = 10 * time.Second
+
+// ReadThrough loads values missing from the cache, concurrent loads of the
+// same key share a single call. A failing cache falls back to the loader
+type ReadThrough struct {
+	cache       Cache
+	bus         Bus
+	ttl         time.Duration
+	loadTimeout time.Duration
+	group       singleflight.Group
+
+	// mu orders storing a loaded value with invalidations, a load that was
+	// in flight when its key was invalidated does not store its value
+	mu      sync.Mutex
+	flights map[*flight]struct{}
+}
+
+type flight struct {
+	key   string
+	stale bool
+}
+
+// NewReadThrough caches values for ttl and gives each load loadTimeout to
+// finish, bus may be nil when the cache is shared by every instance
+func NewReadThrough(cache Cache, bus Bus, ttl, loadTimeout time.Duration) (*ReadThrough, error) {
+	if loadTimeout <= 0 {
+		loadTimeout = DefaultLoadTimeout
+	}
+	r := &ReadThrough{
+		cache:       cache,
+		bus:         bus,
+		ttl:         ttl,
+		loadTimeout: loadTimeout,
+		flights:     make(map[*flight]struct{}),
+	}
+	if bus != nil {
+		err := bus.Subscribe(func(keys []string) {
+			r.markStale(keys)
+			_ = cache.Delete(keys...)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Load decodes the cached value of key into dest, calling load to fill it on
+// a miss. Errors of load are returned and not cached. The load is shared by
+// every caller so it runs on a context detached from ctx, keeping its values
+// but bounded by the load timeout instead. A caller whose ctx is done stops
+// waiting without failing the others
+func (r *ReadThrough) Load(ctx context.Context,
+	key string,
+	dest interface{},
+	load func(ctx context.Context) (interface{}, error)) error {
+	if data, ok, err := r.cache.Get(key); err == nil && ok {
+		if json.Unmarshal(data, dest) == nil {
+			return nil
+		}
+	}
+	results := r.group.DoChan(key, func() (interface{}, error) {
+		f := r.start(key)
+		defer r.finish(f)
+		loadCtx, cancel := context.WithTimeout(detached{ctx}, r.loadTimeout)
+		defer cancel()
+		value, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+		if data, err := json.Marshal(value); err == nil {
+			r.store(f, data)
+		}
+		return value, nil
+	})
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case result := <-results:
+		if result.Err != nil {
+			return result.Err
+		}
+		reflect.ValueOf(dest).Elem().Set(reflect.ValueOf(result.Val))
+		return nil
+	}
+}
+
+func (r *ReadThrough) start(key string) *flight {
+	f := &flight{key: key}
+	r.mu.Lock()
+	r.flights[f] = struct{}{}
+	r.mu.Unlock()
+	return f
+}
+
+func (r *ReadThrough) finish(f *flight) {
+	r.mu.Lock()
+	delete(r.flights, f)
+	r.mu.Unlock()
+}
+
+// store caches a loaded value unless its key was invalidated during the load,
+// an invalidation coming after it deletes the value again
+func (r *ReadThrough) store(f *flight, data []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !f.stale {
+		_ = r.cache.Set(f.key, data, r.ttl)
+	}
+}
+
+// markStale keeps the loads in flight for keys from storing their value
+func (r *ReadThrough) markStale(keys []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for f := range r.flights {
+		for _, key := range keys {
+			if f.key == key || strings.HasSuffix(key, "*") && strings.HasPrefix(f.key, strings.TrimSuffix(key, "*")) {
+				f.stale = true
+			}
+		}
+	}
+}
+
+// Invalidate deletes keys here and on the other instances
+func (r *ReadThrough) Invalidate(keys ...string) error {
+	r.markStale(keys)
+	// callers arriving from now on start a new load instead of sharing the
+	// result of a stale one
+	for _, key := range keys {
+		if !strings.HasSuffix(key, "*") {
+			r.group.Forget(key)
+		}
+	}
+	if err := r.cache.Delete(keys...); err != nil {
+		return err
+	}
+	if r.bus != nil {
+		return r.bus.Publish(keys)
+	}
+	return nil
+}
+
+func (r *ReadThrough) Close() error {
+	if r.bus != nil {
+		return r.bus.Close()
+	}
+	return nil
+}
+
+// detached keeps the values of a context, as its span and logger, but not
+// its deadline or cancellation
+type detached struct {
+	parent context.Context
+}
+
+func (detached) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detached) Done() <-chan struct{} {
+	return nil
+}
+
+func (detached) Err() error {
+	return nil
+}
+
+func (d detached) Value(key interface{}) interface{} {
+	return d.parent.Value(key)
+}
@@ -0,0 +1,187 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRU(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	lru := NewLRU(2)
+	lru.now = func() time.Time { return now }
+
+	require.NoError(t, lru.Set("a", []byte("1"), time.Minute))
+	require.NoError(t, lru.Set("b", []byte("2"), time.Minute))
+	_, ok, _ := lru.Get("a")
+	require.True(t, ok)
+	require.NoError(t, lru.Set("c", []byte("3"), time.Minute))
+	_, ok, _ = lru.Get("b")
+	require.False(t, ok, "least recently used is evicted")
+
+	now = now.Add(time.Minute)
+	_, ok, _ = lru.Get("a")
+	require.False(t, ok, "expired")
+
+	require.NoError(t, lru.Set("genre:1", []byte("1"), time.Minute))
+	require.NoError(t, lru.Set("category:1", []byte("2"), time.Minute))
+	require.NoError(t, lru.Delete("genre:*"))
+	_, ok, _ = lru.Get("genre:1")
+	require.False(t, ok)
+	value, ok, _ := lru.Get("category:1")
+	require.True(t, ok)
+	require.Equal(t, []byte("2"), value)
+}
+
+type fakeBus struct {
+	handler   func(keys []string)
+	published [][]string
+}
+
+func (f *fakeBus) Publish(keys []string) error {
+	f.published = append(f.published, keys)
+	return nil
+}
+
+func (f *fakeBus) Subscribe(handler func(keys []string)) error {
+	f.handler = handler
+	return nil
+}
+
+func (f *fakeBus) Close() error {
+	return nil
+}
+
+func TestReadThrough_Load(t *testing.T) {
+	cache, err := NewReadThrough(NewLRU(10), nil, time.Minute, time.Second)
+	require.NoError(t, err)
+
+	var calls int32
+	release := make(chan struct{})
+	load := func(context.Context) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return []string{"a", "b"}, nil
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var got []string
+			require.NoError(t, cache.Load(context.Background(), "key", &got, load))
+			require.Equal(t, []string{"a", "b"}, got)
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls), "concurrent misses share a load")
+
+	var got []string
+	require.NoError(t, cache.Load(context.Background(), "key", &got, func(context.Context) (interface{}, error) {
+		t.Fatal("cached value is not used")
+		return nil, nil
+	}))
+	require.Equal(t, []string{"a", "b"}, got)
+
+	failure := errors.New("db down")
+	err = cache.Load(context.Background(), "other", &got, func(context.Context) (interface{}, error) { return nil, failure })
+	require.ErrorIs(t, err, failure)
+}
+
+type ctxKey struct{}
+
+func TestReadThrough_LoadDetachedFromCaller(t *testing.T) {
+	cache, err := NewReadThrough(NewLRU(10), nil, time.Minute, time.Second)
+	require.NoError(t, err)
+
+	caller, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "request"))
+	release := make(chan struct{})
+	loaded := make(chan error, 1)
+	load := func(ctx context.Context) (interface{}, error) {
+		require.Equal(t, "request", ctx.Value(ctxKey{}))
+		_, hasDeadline := ctx.Deadline()
+		require.True(t, hasDeadline)
+		<-release
+		loaded <- ctx.Err()
+		return "value", nil
+	}
+
+	var got string
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	err = cache.Load(caller, "key", &got, load)
+	require.ErrorIs(t, err, context.Canceled)
+
+	close(release)
+	require.NoError(t, <-loaded, "the shared load outlives its first caller")
+	require.Eventually(t, func() bool {
+		return cache.Load(context.Background(), "key", &got, func(context.Context) (interface{}, error) {
+			return nil, errors.New("not cached")
+		}) == nil
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, "value", got)
+}
+
+func TestReadThrough_Invalidate(t *testing.T) {
+	bus := &fakeBus{}
+	lru := NewLRU(10)
+	cache, err := NewReadThrough(lru, bus, time.Minute, time.Second)
+	require.NoError(t, err)
+
+	require.NoError(t, lru.Set("local", []byte(`1`), time.Minute))
+	require.NoError(t, cache.Invalidate("local"))
+	_, ok, _ := lru.Get("local")
+	require.False(t, ok)
+	require.Equal(t, [][]string{{"local"}}, bus.published)
+
+	require.NoError(t, lru.Set("remote", []byte(`1`), time.Minute))
+	bus.handler([]string{"remote"})
+	_, ok, _ = lru.Get("remote")
+	require.False(t, ok, "invalidations of other instances are applied")
+}
+
+func TestReadThrough_InvalidateDuringLoad(t *testing.T) {
+	for _, invalidated := range []string{"category:1", "category:*"} {
+		t.Run(invalidated, func(t *testing.T) {
+			lru := NewLRU(10)
+			cache, err := NewReadThrough(lru, nil, time.Minute, time.Second)
+			require.NoError(t, err)
+
+			started := make(chan struct{})
+			release := make(chan struct{})
+			done := make(chan error, 1)
+			go func() {
+				var got string
+				done <- cache.Load(context.Background(), "category:1", &got, func(context.Context) (interface{}, error) {
+					close(started)
+					<-release
+					return "old", nil
+				})
+			}()
+			<-started
+			require.NoError(t, cache.Invalidate(invalidated))
+			close(release)
+			require.NoError(t, <-done)
+
+			_, ok, _ := lru.Get("category:1")
+			require.False(t, ok, "a load started before the invalidation must not be cached")
+
+			var got string
+			require.NoError(t, cache.Load(context.Background(), "category:1", &got, func(context.Context) (interface{}, error) {
+				return "new", nil
+			}))
+			require.Equal(t, "new", got)
+			_, ok, _ = lru.Get("category:1")
+			require.True(t, ok)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package cache
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// Redis keeps values in Redis, shared by every instance
+type Redis struct {
+	pool   *redis.Pool
+	prefix string
+}
+
+func NewRedis(pool *redis.Pool, prefix string) *Redis {
+	return &Redis{
+		pool:   pool,
+		prefix: prefix,
+	}
+}
+
+func (r *Redis) Get(key string) ([]byte, bool, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+	value, err := redis.Bytes(conn.Do("GET", r.prefix+key))
+	if err == redis.ErrNil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (r *Redis) Set(key string, value []byte, ttl time.Duration) error {
+	conn := r.pool.Get()
+	defer conn.Close()
+	_, err := conn.Do("SET", r.prefix+key, value, "PX", ttl.Milliseconds())
+	return err
+}
+
+// Delete removes prefixed keys with SCAN, which walks the whole keyspace and
+// is meant for rare invalidations only
+func (r *Redis) Delete(keys ...string) error {
+	conn := r.pool.Get()
+	defer conn.Close()
+	for _, key := range keys {
+		if !strings.HasSuffix(key, "*") {
+			if _, err := conn.Do("DEL", r.prefix+key); err != nil {
+				return err
+			}
+			continue
+		}
+		cursor := 0
+		for {
+			reply, err := redis.Values(conn.Do("SCAN", cursor, "MATCH", r.prefix+key, "COUNT", 500))
+			if err != nil {
+				return err
+			}
+			var found []string
+			if _, err = redis.Scan(reply, &cursor, &found); err != nil {
+				return err
+			}
+			if len(found) > 0 {
+				if _, err = conn.Do("DEL", redis.Args{}.AddFlat(found)...); err != nil {
+					return err
+				}
+			}
+			if cursor == 0 {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// RedisBus broadcasts invalidations on a Redis channel, the subscription is
+// reopened when the connection drops
+type RedisBus struct {
+	pool    *redis.Pool
+	channel string
+
+	mu     sync.Mutex
+	conn   redis.PubSubConn
+	closed bool
+}
+
+func NewRedisBus(pool *redis.Pool, channel string) *RedisBus {
+	return &RedisBus{
+		pool:    pool,
+		channel: channel,
+	}
+}
+
+func (b *RedisBus) Publish(keys []string) error {
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+	conn := b.pool.Get()
+	defer conn.Close()
+	_, err = conn.Do("PUBLISH", b.channel, data)
+	return err
+}
+
+// Subscribe delivers keys published by every instance, this one included, to
+// handler until Close
+func (b *RedisBus) Subscribe(handler func(keys []string)) error {
+	if err := b.subscribe(); err != nil {
+		return err
+	}
+	go func() {
+		for {
+			b.mu.Lock()
+			conn := b.conn
+			b.mu.Unlock()
+			b.receive(conn, handler)
+
+			b.mu.Lock()
+			closed := b.closed
+			b.mu.Unlock()
+			if closed {
+				return
+			}
+			for b.subscribe() != nil {
+				time.Sleep(time.Second)
+			}
+		}
+	}()
+	return nil
+}
+
+func (b *RedisBus) subscribe() error {
+	conn := redis.PubSubConn{Conn: b.pool.Get()}
+	if err := conn.Subscribe(b.channel); err != nil {
+		conn.Close()
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		conn.Close()
+		return nil
+	}
+	b.conn = conn
+	return nil
+}
+
+func (b *RedisBus) receive(conn redis.PubSubConn, handler func(keys []string)) {
+	for {
+		switch message := conn.ReceiveWithTimeout(0).(type) {
+		case redis.Message:
+			var keys []string
+			if json.Unmarshal(message.Data, &keys) == nil {
+				handler(keys)
+			}
+		case error:
+			conn.Close()
+			return
+		}
+	}
+}
+
+func (b *RedisBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	if b.conn.Conn != nil {
+		return b.conn.Close()
+	}
+	return nil
+}
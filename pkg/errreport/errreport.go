@@ -0,0 +1,100 @@
+// Package errreport sends unexpected failures like recovered panics to an
+// error tracker. Webhook posts them as JSON so any tracker with an HTTP
+// intake can be plugged in
+package errreport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+)
+
+const defaultTimeout = 5 * time.Second
+
+type Event struct {
+	Err       error
+	Stack     []byte
+	RequestID string
+	Method    string
+	Route     string
+	Path      string
+	Time      time.Time
+}
+
+type Reporter interface {
+	Report(ctx context.Context, event Event)
+}
+
+// Nop drops every event, it is used when no tracker is configured
+type Nop struct{}
+
+func (Nop) Report(context.Context, Event) {}
+
+type payload struct {
+	Error     string    `json:"error"`
+	Stack     string    `json:"stack"`
+	RequestID string    `json:"request_id,omitempty"`
+	Method    string    `json:"method,omitempty"`
+	Route     string    `json:"route,omitempty"`
+	Path      string    `json:"path,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+type Webhook struct {
+	url    string
+	client *http.Client
+	log    logger.Logger
+}
+
+// NewWebhook posts events to url, a zero timeout waits up to 5s for the
+// tracker
+func NewWebhook(url string, timeout time.Duration, log logger.Logger) *Webhook {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &Webhook{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+		log:    log,
+	}
+}
+
+// Report posts event in the background so the failing request is not held
+// by the tracker, delivery failures are only logged
+func (w *Webhook) Report(_ context.Context, event Event) {
+	body, err := json.Marshal(payload{
+		Error:     event.Err.Error(),
+		Stack:     string(event.Stack),
+		RequestID: event.RequestID,
+		Method:    event.Method,
+		Route:     event.Route,
+		Path:      event.Path,
+		Time:      event.Time,
+	})
+	if err != nil {
+		w.log.Errorf("errreport: failed to encode event: %v", err)
+		return
+	}
+	go func() {
+		if err := w.send(body); err != nil {
+			w.log.Errorf("errreport: failed to report event: %v", err)
+		}
+	}()
+}
+
+func (w *Webhook) send(body []byte) error {
+	resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("tracker answered %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package errreport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mock_logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_Report(t *testing.T) {
+	received := make(chan payload, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body payload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		received <- body
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	log := mock_logger.NewMockLogger(ctrl)
+
+	webhook := NewWebhook(server.URL, time.Second, log)
+	webhook.Report(context.Background(), Event{
+		Err:       errors.New("assignment to entry in nil map"),
+		Stack:     []byte("goroutine 1 [running]"),
+		RequestID: "abc-123",
+		Method:    http.MethodPut,
+		Route:     "/category/:id",
+		Path:      "/category/1",
+	})
+
+	select {
+	case body := <-received:
+		require.Equal(t, "assignment to entry in nil map", body.Error)
+		require.Equal(t, "goroutine 1 [running]", body.Stack)
+		require.Equal(t, "abc-123", body.RequestID)
+		require.Equal(t, "/category/:id", body.Route)
+	case <-time.After(2 * time.Second):
+		t.Fatal("the event was not posted")
+	}
+}
@@ -0,0 +1,56 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker_Run(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Add("database", func(ctx context.Context) (string, error) {
+		return "", nil
+	})
+	checker.Add("pool", func(ctx context.Context) (string, error) {
+		return "20 of 20 connections in use", errors.New("pool saturated")
+	})
+
+	report := checker.Run(context.Background())
+	require.False(t, report.OK())
+	require.Equal(t, StatusOK, report.Checks["database"].Status)
+	require.Equal(t, StatusFail, report.Checks["pool"].Status)
+	require.Equal(t, "20 of 20 connections in use", report.Checks["pool"].Detail)
+	require.Equal(t, "pool saturated", report.Checks["pool"].Error)
+}
+
+func TestChecker_RunAllOK(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Add("database", func(ctx context.Context) (string, error) {
+		return "", nil
+	})
+
+	report := checker.Run(context.Background())
+	require.True(t, report.OK())
+	require.Len(t, report.Checks, 1)
+
+	report = NewChecker(time.Second).Run(context.Background())
+	require.True(t, report.OK())
+	require.Empty(t, report.Checks)
+}
+
+func TestChecker_RunTimeout(t *testing.T) {
+	checker := NewChecker(10 * time.Millisecond)
+	release := make(chan struct{})
+	defer close(release)
+	checker.Add("broker", func(ctx context.Context) (string, error) {
+		<-release
+		return "", nil
+	})
+
+	report := checker.Run(context.Background())
+	require.False(t, report.OK())
+	require.Equal(t, context.DeadlineExceeded.Error(), report.Checks["broker"].Error)
+}
@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPoolWaitThreshold is the average wait for a connection above
+	// which a check counts as waiting
+	DefaultPoolWaitThreshold = 100 * time.Millisecond
+	// DefaultPoolWaitChecks is how many checks in a row must be waiting for
+	// the pool to be reported as failing
+	DefaultPoolWaitChecks = 3
+)
+
+var ErrPoolWaiting = errors.New("queries keep waiting for a database connection")
+
+// PoolWaits checks a database pool through the growth of its wait counters
+// between two runs. Using every connection is normal under load and is only
+// reported in the detail, the check fails once callers waited threshold or
+// longer on average in the last checks runs
+type PoolWaits struct {
+	stats     func() sql.DBStats
+	threshold time.Duration
+	checks    int
+
+	mu       sync.Mutex
+	count    int64
+	duration time.Duration
+	streak   int
+}
+
+// NewPoolWaits reads the pool counters from stats, usually db.Stats, and
+// starts from their current values
+func NewPoolWaits(stats func() sql.DBStats, threshold time.Duration, checks int) *PoolWaits {
+	if threshold <= 0 {
+		threshold = DefaultPoolWaitThreshold
+	}
+	if checks <= 0 {
+		checks = DefaultPoolWaitChecks
+	}
+	current := stats()
+	return &PoolWaits{
+		stats:     stats,
+		threshold: threshold,
+		checks:    checks,
+		count:     current.WaitCount,
+		duration:  current.WaitDuration,
+	}
+}
+
+func (p *PoolWaits) Check(ctx context.Context) (string, error) {
+	stats := p.stats()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	waits := stats.WaitCount - p.count
+	waited := stats.WaitDuration - p.duration
+	p.count, p.duration = stats.WaitCount, stats.WaitDuration
+	if waits > 0 && waited/time.Duration(waits) >= p.threshold {
+		p.streak++
+	} else {
+		p.streak = 0
+	}
+
+	var detail string
+	if stats.MaxOpenConnections <= 0 {
+		detail = fmt.Sprintf("%d in use, unbounded", stats.InUse)
+	} else {
+		detail = fmt.Sprintf("%d of %d in use", stats.InUse, stats.MaxOpenConnections)
+		if stats.InUse >= stats.MaxOpenConnections {
+			detail += ", saturated"
+		}
+	}
+	detail += fmt.Sprintf(", %d waits since last check", waits)
+	if waits > 0 {
+		detail += fmt.Sprintf(" for %v on average", waited/time.Duration(waits))
+	}
+	if p.streak >= p.checks {
+		return detail, ErrPoolWaiting
+	}
+	return detail, nil
+}
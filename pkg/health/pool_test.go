@@ -0,0 +1,43 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolWaits_Check(t *testing.T) {
+	stats := sql.DBStats{MaxOpenConnections: 20}
+	check := NewPoolWaits(func() sql.DBStats { return stats }, 100*time.Millisecond, 2)
+
+	// a saturated pool without waits is ready
+	stats.InUse = 20
+	detail, err := check.Check(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "20 of 20 in use, saturated, 0 waits since last check", detail)
+
+	// one check with long waits is not sustained yet
+	stats.WaitCount, stats.WaitDuration = 4, 2*time.Second
+	detail, err = check.Check(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "20 of 20 in use, saturated, 4 waits since last check for 500ms on average", detail)
+
+	stats.WaitCount, stats.WaitDuration = 6, 3*time.Second
+	_, err = check.Check(context.Background())
+	require.ErrorIs(t, err, ErrPoolWaiting)
+
+	// short waits reset the streak
+	stats.WaitCount, stats.WaitDuration = 16, 3*time.Second+10*time.Millisecond
+	_, err = check.Check(context.Background())
+	require.NoError(t, err)
+
+	// waits before the checker started are ignored
+	stats = sql.DBStats{MaxOpenConnections: 20, WaitCount: 100, WaitDuration: time.Minute}
+	check = NewPoolWaits(func() sql.DBStats { return stats }, 0, 0)
+	detail, err = check.Check(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "0 of 20 in use, 0 waits since last check", detail)
+}
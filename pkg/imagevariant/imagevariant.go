@@ -0,0 +1,158 @@
+package imagevariant
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+)
+
+// MaxPixels bounds the size of decoded images, a 8000x5000 image already
+// takes 160MB once decoded
+const MaxPixels = 40_000_000
+
+var (
+	ErrUnsupportedImage = errors.New("imagevariant: unsupported or corrupted image")
+	ErrImageTooLarge    = errors.New("imagevariant: image has too many pixels")
+)
+
+type Variant struct {
+	Width  int
+	Height int
+	Format string
+	Data   []byte
+}
+
+// Decode reads a jpeg, png or gif image and tells the format variants of
+// it should be encoded with, png is kept so transparency is not lost. The
+// header is checked first so images over MaxPixels are never decoded
+func Decode(r io.Reader) (image.Image, string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, "", err
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width <= 0 || config.Height <= 0 {
+		return nil, "", ErrUnsupportedImage
+	}
+	if config.Width > MaxPixels/config.Height {
+		return nil, "", ErrImageTooLarge
+	}
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", ErrUnsupportedImage
+	}
+	if format == "png" {
+		return img, FormatPNG, nil
+	}
+	return img, FormatJPEG, nil
+}
+
+// Resize scales src down to width keeping its aspect ratio, each target
+// pixel is the average of the source pixels it covers
+func Resize(src image.Image, width int) *image.RGBA {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	height := (srcH*width + srcW/2) / srcW
+	if height < 1 {
+		height = 1
+	}
+	rgba := image.NewRGBA(image.Rect(0, 0, srcW, srcH))
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := y * srcH / height
+		y1 := (y + 1) * srcH / height
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < width; x++ {
+			x0 := x * srcW / width
+			x1 := (x + 1) * srcW / width
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				offset := rgba.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint32(rgba.Pix[offset])
+					g += uint32(rgba.Pix[offset+1])
+					b += uint32(rgba.Pix[offset+2])
+					a += uint32(rgba.Pix[offset+3])
+					offset += 4
+					n++
+				}
+			}
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8(r / n)
+			dst.Pix[offset+1] = uint8(g / n)
+			dst.Pix[offset+2] = uint8(b / n)
+			dst.Pix[offset+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+func Encode(w io.Writer, img image.Image, format string, quality int) error {
+	if format == FormatPNG {
+		return png.Encode(w, img)
+	}
+	return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+}
+
+// Original returns the bytes to keep as the original upload. data is kept
+// as is when it already is in format, other sources such as gif are
+// encoded from img so the stored bytes match the format they are served as
+func Original(data []byte, img image.Image, format string, quality int) ([]byte, error) {
+	if _, source, err := image.DecodeConfig(bytes.NewReader(data)); err == nil && source == format {
+		return data, nil
+	}
+	var buf bytes.Buffer
+	if err := Encode(&buf, img, format, quality); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Generate builds one variant per width. Widths larger than the source are
+// skipped as upscaling only produces bigger files of the same image, when
+// the source is smaller than every width a single variant of its own size
+// is built.
+func Generate(src image.Image, format string, widths []int, quality int) ([]Variant, error) {
+	srcW := src.Bounds().Dx()
+	targets := make([]int, 0, len(widths))
+	for _, width := range widths {
+		if width > 0 && width <= srcW {
+			targets = append(targets, width)
+		}
+	}
+	if len(targets) == 0 {
+		targets = append(targets, srcW)
+	}
+	variants := make([]Variant, 0, len(targets))
+	for _, width := range targets {
+		resized := Resize(src, width)
+		var buf bytes.Buffer
+		if err := Encode(&buf, resized, format, quality); err != nil {
+			return nil, err
+		}
+		variants = append(variants, Variant{
+			Width:  width,
+			Height: resized.Bounds().Dy(),
+			Format: format,
+			Data:   buf.Bytes(),
+		})
+	}
+	return variants, nil
+}
@@ -0,0 +1,103 @@
+package imagevariant
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func TestDecode(t *testing.T) {
+	var pngBuf, jpegBuf bytes.Buffer
+	require.NoError(t, png.Encode(&pngBuf, newTestImage(10, 10)))
+	require.NoError(t, jpeg.Encode(&jpegBuf, newTestImage(10, 10), nil))
+
+	_, format, err := Decode(&pngBuf)
+	require.NoError(t, err)
+	require.Equal(t, FormatPNG, format)
+
+	_, format, err = Decode(&jpegBuf)
+	require.NoError(t, err)
+	require.Equal(t, FormatJPEG, format)
+
+	_, _, err = Decode(strings.NewReader("not an image"))
+	require.ErrorIs(t, err, ErrUnsupportedImage)
+
+	// a gif header declaring a 65535x65535 screen
+	huge := []byte("GIF89a\xff\xff\xff\xff\x00\x00\x00")
+	_, _, err = Decode(bytes.NewReader(huge))
+	require.ErrorIs(t, err, ErrImageTooLarge)
+}
+
+func TestResize(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		value := uint8(0)
+		if x%2 == 1 {
+			value = 200
+		}
+		src.Set(x, 0, color.RGBA{R: value, A: 255})
+		src.Set(x, 1, color.RGBA{R: value, A: 255})
+	}
+	dst := Resize(src, 2)
+	require.Equal(t, image.Rect(0, 0, 2, 1), dst.Bounds())
+	require.Equal(t, color.RGBA{R: 100, A: 255}, dst.RGBAAt(0, 0))
+}
+
+func TestOriginal(t *testing.T) {
+	var pngBuf, gifBuf bytes.Buffer
+	require.NoError(t, png.Encode(&pngBuf, newTestImage(10, 10)))
+	require.NoError(t, gif.Encode(&gifBuf, newTestImage(10, 10), nil))
+
+	img, format, err := Decode(bytes.NewReader(pngBuf.Bytes()))
+	require.NoError(t, err)
+	data, err := Original(pngBuf.Bytes(), img, format, 80)
+	require.NoError(t, err)
+	require.Equal(t, pngBuf.Bytes(), data)
+
+	img, format, err = Decode(bytes.NewReader(gifBuf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, FormatJPEG, format)
+	data, err = Original(gifBuf.Bytes(), img, format, 80)
+	require.NoError(t, err)
+	_, source, err := image.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, "jpeg", source)
+}
+
+func TestGenerate(t *testing.T) {
+	src := newTestImage(800, 400)
+	variants, err := Generate(src, FormatJPEG, []int{320, 640, 1280}, 80)
+	require.NoError(t, err)
+	require.Len(t, variants, 2)
+	require.Equal(t, 320, variants[0].Width)
+	require.Equal(t, 160, variants[0].Height)
+	require.Equal(t, 640, variants[1].Width)
+
+	decoded, format, err := Decode(bytes.NewReader(variants[1].Data))
+	require.NoError(t, err)
+	require.Equal(t, FormatJPEG, format)
+	require.Equal(t, image.Rect(0, 0, 640, 320), decoded.Bounds())
+
+	small, err := Generate(newTestImage(100, 50), FormatPNG, []int{320}, 80)
+	require.NoError(t, err)
+	require.Len(t, small, 1)
+	require.Equal(t, 100, small[0].Width)
+	require.Equal(t, FormatPNG, small[0].Format)
+}
@@ -0,0 +1,254 @@
+package jwtauth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+var ErrInvalidJWKS = errors.New("jwtauth: invalid jwks document")
+
+// KeyProvider finds the public key a token was signed with
+type KeyProvider interface {
+	Key(kid string) (crypto.PublicKey, string, error)
+}
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type publicKey struct {
+	key crypto.PublicKey
+	alg string
+}
+
+// KeySet is a parsed JWKS document, keys which are not RSA or P-256 signing
+// keys are ignored
+type KeySet struct {
+	keys map[string]publicKey
+}
+
+func ParseJWKS(data []byte) (KeySet, error) {
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return KeySet{}, fmt.Errorf("%w: %v", ErrInvalidJWKS, err)
+	}
+	set := KeySet{keys: make(map[string]publicKey)}
+	for _, key := range doc.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		parsed, err := key.publicKey()
+		if err != nil {
+			return KeySet{}, fmt.Errorf("%w: key %q: %v", ErrInvalidJWKS, key.Kid, err)
+		}
+		if parsed.key != nil {
+			set.keys[key.Kid] = parsed
+		}
+	}
+	if len(set.keys) == 0 {
+		return KeySet{}, fmt.Errorf("%w: no signing keys", ErrInvalidJWKS)
+	}
+	return set, nil
+}
+
+// Key returns the key of kid, a token without kid is accepted when the set
+// holds a single key
+func (s KeySet) Key(kid string) (crypto.PublicKey, string, error) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key.key, key.alg, nil
+		}
+	}
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, "", ErrUnknownKey
+	}
+	return key.key, key.alg, nil
+}
+
+func (k jwk) publicKey() (publicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return publicKey{}, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return publicKey{}, err
+		}
+		if !e.IsInt64() || e.Int64() < 3 {
+			return publicKey{}, errors.New("invalid exponent")
+		}
+		return publicKey{key: &rsa.PublicKey{N: n, E: int(e.Int64())}, alg: k.Alg}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return publicKey{}, nil
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return publicKey{}, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return publicKey{}, err
+		}
+		curve := elliptic.P256()
+		if !curve.IsOnCurve(x, y) {
+			return publicKey{}, errors.New("point is not on the curve")
+		}
+		return publicKey{key: &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, alg: k.Alg}, nil
+	}
+	return publicKey{}, nil
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("invalid base64url integer")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// LoadJWKSFile reads a JWKS document from disk, for offline use
+func LoadJWKSFile(path string) (KeySet, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return KeySet{}, err
+	}
+	return ParseJWKS(data)
+}
+
+// RemoteJWKS fetches the JWKS document from url and caches it for refresh,
+// an unknown kid triggers a new fetch at most once per minRefresh so keys
+// rotated by the issuer are picked up without restarting. Concurrent fetches
+// are shared and failed ones are retried with an exponential backoff while
+// the last key set keeps being served
+type RemoteJWKS struct {
+	url        string
+	client     *http.Client
+	refresh    time.Duration
+	minRefresh time.Duration
+	maxBackoff time.Duration
+	now        func() time.Time
+	group      singleflight.Group
+
+	mu          sync.RWMutex
+	set         KeySet
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	retryAt     time.Time
+	failures    int
+}
+
+func NewRemoteJWKS(url string, refresh time.Duration) *RemoteJWKS {
+	return &RemoteJWKS{
+		url:        url,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		refresh:    refresh,
+		minRefresh: time.Minute,
+		maxBackoff: 10 * time.Minute,
+		now:        time.Now,
+	}
+}
+
+// Fetch downloads the document, it is called on startup to fail fast on a
+// wrong url
+func (r *RemoteJWKS) Fetch() error {
+	set, err := r.download()
+	now := r.now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attemptedAt = now
+	if err != nil {
+		r.failures++
+		backoff := r.minRefresh << (r.failures - 1)
+		if backoff > r.maxBackoff || backoff <= 0 {
+			backoff = r.maxBackoff
+		}
+		r.retryAt = now.Add(backoff)
+		return err
+	}
+	r.set = set
+	r.fetchedAt = now
+	r.failures = 0
+	r.retryAt = time.Time{}
+	return nil
+}
+
+func (r *RemoteJWKS) download() (KeySet, error) {
+	resp, err := r.client.Get(r.url)
+	if err != nil {
+		return KeySet{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return KeySet{}, fmt.Errorf("jwtauth: jwks fetch returned %d", resp.StatusCode)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return KeySet{}, err
+	}
+	return ParseJWKS(data)
+}
+
+// fetchShared runs a single Fetch for every caller waiting on it
+func (r *RemoteJWKS) fetchShared() error {
+	_, err, _ := r.group.Do(r.url, func() (interface{}, error) {
+		return nil, r.Fetch()
+	})
+	return err
+}
+
+func (r *RemoteJWKS) Key(kid string) (crypto.PublicKey, string, error) {
+	r.mu.RLock()
+	set, fetchedAt, attemptedAt, retryAt := r.set, r.fetchedAt, r.attemptedAt, r.retryAt
+	r.mu.RUnlock()
+
+	now := r.now()
+	if (set.keys == nil || now.Sub(fetchedAt) > r.refresh) && !now.Before(retryAt) {
+		if err := r.fetchShared(); err != nil && set.keys == nil {
+			return nil, "", err
+		}
+		r.mu.RLock()
+		set, attemptedAt, retryAt = r.set, r.attemptedAt, r.retryAt
+		r.mu.RUnlock()
+	}
+	if set.keys == nil {
+		return nil, "", fmt.Errorf("jwtauth: jwks unavailable until %v", retryAt)
+	}
+	key, alg, err := set.Key(kid)
+	if errors.Is(err, ErrUnknownKey) && now.Sub(attemptedAt) > r.minRefresh && !now.Before(retryAt) {
+		if fetchErr := r.fetchShared(); fetchErr != nil {
+			return nil, "", err
+		}
+		r.mu.RLock()
+		set = r.set
+		r.mu.RUnlock()
+		return set.Key(kid)
+	}
+	return key, alg, err
+}
@@ -0,0 +1,227 @@
+// Package jwtauth verifies RS256 and ES256 bearer tokens against the keys of
+// a JWKS document
+package jwtauth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMalformedToken   = errors.New("jwtauth: malformed token")
+	ErrUnsupportedAlg   = errors.New("jwtauth: unsupported signing algorithm")
+	ErrUnknownKey       = errors.New("jwtauth: unknown signing key")
+	ErrInvalidSignature = errors.New("jwtauth: invalid signature")
+	ErrExpired          = errors.New("jwtauth: token is expired")
+	ErrNotYetValid      = errors.New("jwtauth: token is not valid yet")
+	ErrInvalidIssuer    = errors.New("jwtauth: invalid issuer")
+	ErrInvalidAudience  = errors.New("jwtauth: invalid audience")
+	ErrMissingSubject   = errors.New("jwtauth: missing subject")
+)
+
+// Claims are the registered claims of a verified token plus its roles
+type Claims struct {
+	Subject   string
+	Issuer    string
+	Audience  []string
+	ExpiresAt time.Time
+	Roles     []string
+}
+
+type Options struct {
+	Issuer   string
+	Audience string
+	// RolesClaim is the claim holding the roles, nested claims are separated
+	// by dots like realm_access.roles. Defaults to roles
+	RolesClaim string
+	// Leeway tolerates clock skew with the issuer on exp and nbf
+	Leeway time.Duration
+}
+
+type Verifier struct {
+	keys    KeyProvider
+	options Options
+	now     func() time.Time
+}
+
+func NewVerifier(keys KeyProvider, options Options) *Verifier {
+	if options.RolesClaim == "" {
+		options.RolesClaim = "roles"
+	}
+	return &Verifier{
+		keys:    keys,
+		options: options,
+		now:     time.Now,
+	}
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Verify checks the signature of a compact serialized token and validates
+// its exp, nbf, iss and aud claims, exp is required
+func (v *Verifier) Verify(token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, ErrMalformedToken
+	}
+	var head header
+	if err := decodeSegment(parts[0], &head); err != nil {
+		return Claims{}, ErrMalformedToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Claims{}, ErrMalformedToken
+	}
+	if head.Alg != "RS256" && head.Alg != "ES256" {
+		return Claims{}, ErrUnsupportedAlg
+	}
+	key, keyAlg, err := v.keys.Key(head.Kid)
+	if err != nil {
+		return Claims{}, err
+	}
+	if keyAlg != "" && keyAlg != head.Alg {
+		return Claims{}, ErrUnsupportedAlg
+	}
+	if err = verifySignature(head.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return Claims{}, err
+	}
+
+	var payload map[string]interface{}
+	if err = decodeSegment(parts[1], &payload); err != nil {
+		return Claims{}, ErrMalformedToken
+	}
+	return v.validate(payload)
+}
+
+func (v *Verifier) validate(payload map[string]interface{}) (Claims, error) {
+	now := v.now()
+	exp, ok := numericDate(payload["exp"])
+	if !ok {
+		return Claims{}, ErrMalformedToken
+	}
+	if now.After(exp.Add(v.options.Leeway)) {
+		return Claims{}, ErrExpired
+	}
+	if nbf, ok := numericDate(payload["nbf"]); ok && now.Add(v.options.Leeway).Before(nbf) {
+		return Claims{}, ErrNotYetValid
+	}
+
+	claims := Claims{
+		ExpiresAt: exp,
+		Audience:  stringList(payload["aud"]),
+		Roles:     stringList(lookup(payload, v.options.RolesClaim)),
+	}
+	claims.Subject, _ = payload["sub"].(string)
+	claims.Issuer, _ = payload["iss"].(string)
+	if v.options.Issuer != "" && claims.Issuer != v.options.Issuer {
+		return Claims{}, ErrInvalidIssuer
+	}
+	if v.options.Audience != "" && !contains(claims.Audience, v.options.Audience) {
+		return Claims{}, ErrInvalidAudience
+	}
+	if claims.Subject == "" {
+		return Claims{}, ErrMissingSubject
+	}
+	return claims, nil
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signingInput string, signature []byte) error {
+	digest := sha256.Sum256([]byte(signingInput))
+	switch alg {
+	case "RS256":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return ErrUnsupportedAlg
+		}
+		if rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], signature) != nil {
+			return ErrInvalidSignature
+		}
+	case "ES256":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return ErrUnsupportedAlg
+		}
+		if len(signature) != 64 {
+			return ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(pub, digest[:], r, s) {
+			return ErrInvalidSignature
+		}
+	}
+	return nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func numericDate(value interface{}) (time.Time, bool) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	seconds, err := number.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(seconds), 0), true
+}
+
+// lookup follows a dotted claim path through nested objects
+func lookup(payload map[string]interface{}, path string) interface{} {
+	var current interface{} = payload
+	for _, name := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = object[name]
+	}
+	return current
+}
+
+// stringList reads a claim that may be a single string, a space separated
+// list like scope or an array of strings
+func stringList(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return strings.Fields(v)
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return []string{}
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,259 @@
+package jwtauth
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func encode(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	input := encode(map[string]string{"alg": "RS256", "kid": kid}) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(input))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func signES256(t *testing.T, key *ecdsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	input := encode(map[string]string{"alg": "ES256", "kid": kid}) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(input))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func jwksDocument(rsaKey *rsa.PrivateKey, ecKey *ecdsa.PrivateKey) []byte {
+	b64 := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	doc := fmt.Sprintf(`{"keys":[
+		{"kid":"rsa1","kty":"RSA","alg":"RS256","use":"sig","n":%q,"e":%q},
+		{"kid":"ec1","kty":"EC","crv":"P-256","x":%q,"y":%q},
+		{"kid":"enc","kty":"RSA","use":"enc","n":"AQAB","e":"AQAB"}
+	]}`,
+		b64(rsaKey.N.Bytes()), b64(big.NewInt(int64(rsaKey.E)).Bytes()),
+		b64(ecKey.X.FillBytes(make([]byte, 32))), b64(ecKey.Y.FillBytes(make([]byte, 32))))
+	return []byte(doc)
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keys, err := ParseJWKS(jwksDocument(rsaKey, ecKey))
+	require.NoError(t, err)
+
+	now := time.Unix(1600000000, 0)
+	verifier := NewVerifier(keys, Options{
+		Issuer:     "https://issuer",
+		Audience:   "catalog",
+		RolesClaim: "realm_access.roles",
+		Leeway:     30 * time.Second,
+	})
+	verifier.now = func() time.Time { return now }
+
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"sub":          "user-1",
+			"iss":          "https://issuer",
+			"aud":          []string{"catalog", "other"},
+			"exp":          now.Add(time.Minute).Unix(),
+			"realm_access": map[string]interface{}{"roles": []string{"admin", "editor"}},
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+				continue
+			}
+			c[k] = v
+		}
+		return c
+	}
+
+	testCases := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{name: "RS256", token: signRS256(t, rsaKey, "rsa1", claims(nil))},
+		{name: "ES256", token: signES256(t, ecKey, "ec1", claims(nil))},
+		{name: "expired within leeway", token: signRS256(t, rsaKey, "rsa1", claims(map[string]interface{}{"exp": now.Add(-10 * time.Second).Unix()}))},
+		{name: "expired", token: signRS256(t, rsaKey, "rsa1", claims(map[string]interface{}{"exp": now.Add(-time.Minute).Unix()})), err: ErrExpired},
+		{name: "missing exp", token: signRS256(t, rsaKey, "rsa1", claims(map[string]interface{}{"exp": nil})), err: ErrMalformedToken},
+		{name: "not yet valid", token: signRS256(t, rsaKey, "rsa1", claims(map[string]interface{}{"nbf": now.Add(time.Minute).Unix()})), err: ErrNotYetValid},
+		{name: "wrong issuer", token: signRS256(t, rsaKey, "rsa1", claims(map[string]interface{}{"iss": "https://evil"})), err: ErrInvalidIssuer},
+		{name: "wrong audience", token: signRS256(t, rsaKey, "rsa1", claims(map[string]interface{}{"aud": "other"})), err: ErrInvalidAudience},
+		{name: "missing subject", token: signRS256(t, rsaKey, "rsa1", claims(map[string]interface{}{"sub": nil})), err: ErrMissingSubject},
+		{name: "unknown kid", token: signRS256(t, rsaKey, "rsa2", claims(nil)), err: ErrUnknownKey},
+		{name: "encryption key", token: signRS256(t, rsaKey, "enc", claims(nil)), err: ErrUnknownKey},
+		{name: "forged signature", token: signRS256(t, otherKey, "rsa1", claims(nil)), err: ErrInvalidSignature},
+		{name: "algorithm of another key", token: signES256(t, ecKey, "rsa1", claims(nil)), err: ErrUnsupportedAlg},
+		{name: "none", token: encode(map[string]string{"alg": "none"}) + "." + encode(claims(nil)) + ".", err: ErrUnsupportedAlg},
+		{name: "malformed", token: "abc.def", err: ErrMalformedToken},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := verifier.Verify(tc.token)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "user-1", got.Subject)
+			require.Equal(t, []string{"admin", "editor"}, got.Roles)
+		})
+	}
+}
+
+func TestParseJWKS_Invalid(t *testing.T) {
+	_, err := ParseJWKS([]byte(`{"keys":[]}`))
+	require.ErrorIs(t, err, ErrInvalidJWKS)
+	_, err = ParseJWKS([]byte(`{"keys":[{"kid":"a","kty":"EC","crv":"P-256","x":"AQ","y":"AQ"}]}`))
+	require.ErrorIs(t, err, ErrInvalidJWKS)
+}
+
+func TestRemoteJWKS_RefetchesUnknownKid(t *testing.T) {
+	first, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rotated, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	current := jwksDocument(first, ecKey)
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		_, _ = w.Write(current)
+	}))
+	defer server.Close()
+
+	now := time.Unix(1600000000, 0)
+	remote := NewRemoteJWKS(server.URL, time.Hour)
+	remote.now = func() time.Time { return now }
+	require.NoError(t, remote.Fetch())
+
+	current = bytes.Replace(jwksDocument(rotated, ecKey), []byte(`"rsa1"`), []byte(`"rsa2"`), 1)
+	_, _, err = remote.Key("missing")
+	require.ErrorIs(t, err, ErrUnknownKey)
+	require.Equal(t, 1, fetches, "fetched again before minRefresh")
+
+	now = now.Add(2 * time.Minute)
+	key, _, err := remote.Key("rsa2")
+	require.NoError(t, err)
+	require.Equal(t, &rotated.PublicKey, key)
+	require.Equal(t, 2, fetches)
+}
+
+func TestRemoteJWKS_BacksOffAndServesStaleKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	failing := false
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		if failing {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(jwksDocument(rsaKey, ecKey))
+	}))
+	defer server.Close()
+
+	now := time.Unix(1600000000, 0)
+	remote := NewRemoteJWKS(server.URL, time.Hour)
+	remote.now = func() time.Time { return now }
+	require.NoError(t, remote.Fetch())
+
+	failing = true
+	now = now.Add(2 * time.Hour)
+	key, _, err := remote.Key("rsa1")
+	require.NoError(t, err, "stale keys are served while the issuer is down")
+	require.Equal(t, &rsaKey.PublicKey, key)
+	require.Equal(t, 2, fetches)
+
+	for i := 0; i < 10; i++ {
+		_, _, err = remote.Key("rsa1")
+		require.NoError(t, err)
+		_, _, err = remote.Key("unknown")
+		require.ErrorIs(t, err, ErrUnknownKey)
+	}
+	require.Equal(t, 2, fetches, "fetched again before the backoff elapsed")
+
+	now = now.Add(time.Minute)
+	_, _, err = remote.Key("rsa1")
+	require.NoError(t, err)
+	require.Equal(t, 3, fetches)
+	now = now.Add(time.Minute)
+	_, _, err = remote.Key("rsa1")
+	require.NoError(t, err)
+	require.Equal(t, 3, fetches, "second failure doubles the backoff")
+
+	failing = false
+	now = now.Add(time.Minute)
+	_, _, err = remote.Key("rsa1")
+	require.NoError(t, err)
+	require.Equal(t, 4, fetches)
+	_, _, err = remote.Key("rsa1")
+	require.NoError(t, err)
+	require.Equal(t, 4, fetches)
+}
+
+func TestRemoteJWKS_SharesConcurrentFetches(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var fetches int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		<-release
+		_, _ = w.Write(jwksDocument(rsaKey, ecKey))
+	}))
+	defer server.Close()
+
+	remote := NewRemoteJWKS(server.URL, time.Hour)
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := remote.Key("rsa1")
+			require.NoError(t, err)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	require.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+}
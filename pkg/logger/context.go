@@ -0,0 +1,32 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type contextKey struct{}
+
+// With returns a logger adding keysAndValues to every entry, loggers other
+// than zap's are returned unchanged
+func With(log Logger, keysAndValues ...interface{}) Logger {
+	if sugared, ok := log.(*zap.SugaredLogger); ok {
+		return sugared.With(keysAndValues...)
+	}
+	return log
+}
+
+// WithContext stores the request scoped log in ctx
+func WithContext(ctx context.Context, log Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, log)
+}
+
+// FromContext returns the request scoped logger of ctx, or fallback when ctx
+// has none
+func FromContext(ctx context.Context, fallback Logger) Logger {
+	if log, ok := ctx.Value(contextKey{}).(Logger); ok {
+		return log
+	}
+	return fallback
+}
@@ -14,4 +14,10 @@ type Logger interface {
 	Warnf(format string, v ...interface{})
 	Fatalf(format string, v ...interface{})
 	Panicf(format string, v ...interface{})
+
+	// Infow and friends log msg with structured key value pairs
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
 }
@@ -5,34 +5,35 @@
 package mock_logger
 
 import (
-	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
 )
 
-// MockLogger is a mock of Logger interface
+// MockLogger is a mock of Logger interface.
 type MockLogger struct {
 	ctrl     *gomock.Controller
 	recorder *MockLoggerMockRecorder
 }
 
-// MockLoggerMockRecorder is the mock recorder for MockLogger
+// MockLoggerMockRecorder is the mock recorder for MockLogger.
 type MockLoggerMockRecorder struct {
 	mock *MockLogger
 }
 
-// NewMockLogger creates a new mock instance
+// NewMockLogger creates a new mock instance.
 func NewMockLogger(ctrl *gomock.Controller) *MockLogger {
 	mock := &MockLogger{ctrl: ctrl}
 	mock.recorder = &MockLoggerMockRecorder{mock}
 	return mock
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use
+// EXPECT returns an object that allows the caller to indicate expected use.
 func (m *MockLogger) EXPECT() *MockLoggerMockRecorder {
 	return m.recorder
 }
 
-// Debug mocks base method
+// Debug mocks base method.
 func (m *MockLogger) Debug(args ...interface{}) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{}
@@ -42,45 +43,47 @@ func (m *MockLogger) Debug(args ...interface{}) {
 	m.ctrl.Call(m, "Debug", varargs...)
 }
 
-// Debug indicates an expected call of Debug
+// Debug indicates an expected call of Debug.
 func (mr *MockLoggerMockRecorder) Debug(args ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debug", reflect.TypeOf((*MockLogger)(nil).Debug), args...)
 }
 
-// Info mocks base method
-func (m *MockLogger) Info(args ...interface{}) {
+// Debugf mocks base method.
+func (m *MockLogger) Debugf(format string, v ...interface{}) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{}
-	for _, a := range args {
+	varargs := []interface{}{format}
+	for _, a := range v {
 		varargs = append(varargs, a)
 	}
-	m.ctrl.Call(m, "Info", varargs...)
+	m.ctrl.Call(m, "Debugf", varargs...)
 }
 
-// Info indicates an expected call of Info
-func (mr *MockLoggerMockRecorder) Info(args ...interface{}) *gomock.Call {
+// Debugf indicates an expected call of Debugf.
+func (mr *MockLoggerMockRecorder) Debugf(format interface{}, v ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockLogger)(nil).Info), args...)
+	varargs := append([]interface{}{format}, v...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debugf", reflect.TypeOf((*MockLogger)(nil).Debugf), varargs...)
 }
 
-// Warn mocks base method
-func (m *MockLogger) Warn(args ...interface{}) {
+// Debugw mocks base method.
+func (m *MockLogger) Debugw(msg string, keysAndValues ...interface{}) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{}
-	for _, a := range args {
+	varargs := []interface{}{msg}
+	for _, a := range keysAndValues {
 		varargs = append(varargs, a)
 	}
-	m.ctrl.Call(m, "Warn", varargs...)
+	m.ctrl.Call(m, "Debugw", varargs...)
 }
 
-// Warn indicates an expected call of Warn
-func (mr *MockLoggerMockRecorder) Warn(args ...interface{}) *gomock.Call {
+// Debugw indicates an expected call of Debugw.
+func (mr *MockLoggerMockRecorder) Debugw(msg interface{}, keysAndValues ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warn", reflect.TypeOf((*MockLogger)(nil).Warn), args...)
+	varargs := append([]interface{}{msg}, keysAndValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debugw", reflect.TypeOf((*MockLogger)(nil).Debugw), varargs...)
 }
 
-// Error mocks base method
+// Error mocks base method.
 func (m *MockLogger) Error(args ...interface{}) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{}
@@ -90,29 +93,47 @@ func (m *MockLogger) Error(args ...interface{}) {
 	m.ctrl.Call(m, "Error", varargs...)
 }
 
-// Error indicates an expected call of Error
+// Error indicates an expected call of Error.
 func (mr *MockLoggerMockRecorder) Error(args ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockLogger)(nil).Error), args...)
 }
 
-// Panic mocks base method
-func (m *MockLogger) Panic(args ...interface{}) {
+// Errorf mocks base method.
+func (m *MockLogger) Errorf(format string, v ...interface{}) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{}
-	for _, a := range args {
+	varargs := []interface{}{format}
+	for _, a := range v {
 		varargs = append(varargs, a)
 	}
-	m.ctrl.Call(m, "Panic", varargs...)
+	m.ctrl.Call(m, "Errorf", varargs...)
 }
 
-// Panic indicates an expected call of Panic
-func (mr *MockLoggerMockRecorder) Panic(args ...interface{}) *gomock.Call {
+// Errorf indicates an expected call of Errorf.
+func (mr *MockLoggerMockRecorder) Errorf(format interface{}, v ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Panic", reflect.TypeOf((*MockLogger)(nil).Panic), args...)
+	varargs := append([]interface{}{format}, v...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Errorf", reflect.TypeOf((*MockLogger)(nil).Errorf), varargs...)
 }
 
-// Fatal mocks base method
+// Errorw mocks base method.
+func (m *MockLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range keysAndValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Errorw", varargs...)
+}
+
+// Errorw indicates an expected call of Errorw.
+func (mr *MockLoggerMockRecorder) Errorw(msg interface{}, keysAndValues ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, keysAndValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Errorw", reflect.TypeOf((*MockLogger)(nil).Errorw), varargs...)
+}
+
+// Fatal mocks base method.
 func (m *MockLogger) Fatal(args ...interface{}) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{}
@@ -122,47 +143,46 @@ func (m *MockLogger) Fatal(args ...interface{}) {
 	m.ctrl.Call(m, "Fatal", varargs...)
 }
 
-// Fatal indicates an expected call of Fatal
+// Fatal indicates an expected call of Fatal.
 func (mr *MockLoggerMockRecorder) Fatal(args ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fatal", reflect.TypeOf((*MockLogger)(nil).Fatal), args...)
 }
 
-// Debugf mocks base method
-func (m *MockLogger) Debugf(format string, v ...interface{}) {
+// Fatalf mocks base method.
+func (m *MockLogger) Fatalf(format string, v ...interface{}) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{format}
 	for _, a := range v {
 		varargs = append(varargs, a)
 	}
-	m.ctrl.Call(m, "Debugf", varargs...)
+	m.ctrl.Call(m, "Fatalf", varargs...)
 }
 
-// Debugf indicates an expected call of Debugf
-func (mr *MockLoggerMockRecorder) Debugf(format interface{}, v ...interface{}) *gomock.Call {
+// Fatalf indicates an expected call of Fatalf.
+func (mr *MockLoggerMockRecorder) Fatalf(format interface{}, v ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{format}, v...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debugf", reflect.TypeOf((*MockLogger)(nil).Debugf), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fatalf", reflect.TypeOf((*MockLogger)(nil).Fatalf), varargs...)
 }
 
-// Errorf mocks base method
-func (m *MockLogger) Errorf(format string, v ...interface{}) {
+// Info mocks base method.
+func (m *MockLogger) Info(args ...interface{}) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{format}
-	for _, a := range v {
+	varargs := []interface{}{}
+	for _, a := range args {
 		varargs = append(varargs, a)
 	}
-	m.ctrl.Call(m, "Errorf", varargs...)
+	m.ctrl.Call(m, "Info", varargs...)
 }
 
-// Errorf indicates an expected call of Errorf
-func (mr *MockLoggerMockRecorder) Errorf(format interface{}, v ...interface{}) *gomock.Call {
+// Info indicates an expected call of Info.
+func (mr *MockLoggerMockRecorder) Info(args ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{format}, v...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Errorf", reflect.TypeOf((*MockLogger)(nil).Errorf), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockLogger)(nil).Info), args...)
 }
 
-// Infof mocks base method
+// Infof mocks base method.
 func (m *MockLogger) Infof(format string, v ...interface{}) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{format}
@@ -172,48 +192,47 @@ func (m *MockLogger) Infof(format string, v ...interface{}) {
 	m.ctrl.Call(m, "Infof", varargs...)
 }
 
-// Infof indicates an expected call of Infof
+// Infof indicates an expected call of Infof.
 func (mr *MockLoggerMockRecorder) Infof(format interface{}, v ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{format}, v...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Infof", reflect.TypeOf((*MockLogger)(nil).Infof), varargs...)
 }
 
-// Warnf mocks base method
-func (m *MockLogger) Warnf(format string, v ...interface{}) {
+// Infow mocks base method.
+func (m *MockLogger) Infow(msg string, keysAndValues ...interface{}) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{format}
-	for _, a := range v {
+	varargs := []interface{}{msg}
+	for _, a := range keysAndValues {
 		varargs = append(varargs, a)
 	}
-	m.ctrl.Call(m, "Warnf", varargs...)
+	m.ctrl.Call(m, "Infow", varargs...)
 }
 
-// Warnf indicates an expected call of Warnf
-func (mr *MockLoggerMockRecorder) Warnf(format interface{}, v ...interface{}) *gomock.Call {
+// Infow indicates an expected call of Infow.
+func (mr *MockLoggerMockRecorder) Infow(msg interface{}, keysAndValues ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{format}, v...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warnf", reflect.TypeOf((*MockLogger)(nil).Warnf), varargs...)
+	varargs := append([]interface{}{msg}, keysAndValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Infow", reflect.TypeOf((*MockLogger)(nil).Infow), varargs...)
 }
 
-// Fatalf mocks base method
-func (m *MockLogger) Fatalf(format string, v ...interface{}) {
+// Panic mocks base method.
+func (m *MockLogger) Panic(args ...interface{}) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{format}
-	for _, a := range v {
+	varargs := []interface{}{}
+	for _, a := range args {
 		varargs = append(varargs, a)
 	}
-	m.ctrl.Call(m, "Fatalf", varargs...)
+	m.ctrl.Call(m, "Panic", varargs...)
 }
 
-// Fatalf indicates an expected call of Fatalf
-func (mr *MockLoggerMockRecorder) Fatalf(format interface{}, v ...interface{}) *gomock.Call {
+// Panic indicates an expected call of Panic.
+func (mr *MockLoggerMockRecorder) Panic(args ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{format}, v...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fatalf", reflect.TypeOf((*MockLogger)(nil).Fatalf), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Panic", reflect.TypeOf((*MockLogger)(nil).Panic), args...)
 }
 
-// Panicf mocks base method
+// Panicf mocks base method.
 func (m *MockLogger) Panicf(format string, v ...interface{}) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{format}
@@ -223,9 +242,59 @@ func (m *MockLogger) Panicf(format string, v ...interface{}) {
 	m.ctrl.Call(m, "Panicf", varargs...)
 }
 
-// Panicf indicates an expected call of Panicf
+// Panicf indicates an expected call of Panicf.
 func (mr *MockLoggerMockRecorder) Panicf(format interface{}, v ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{format}, v...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Panicf", reflect.TypeOf((*MockLogger)(nil).Panicf), varargs...)
 }
+
+// Warn mocks base method.
+func (m *MockLogger) Warn(args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Warn", varargs...)
+}
+
+// Warn indicates an expected call of Warn.
+func (mr *MockLoggerMockRecorder) Warn(args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warn", reflect.TypeOf((*MockLogger)(nil).Warn), args...)
+}
+
+// Warnf mocks base method.
+func (m *MockLogger) Warnf(format string, v ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{format}
+	for _, a := range v {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Warnf", varargs...)
+}
+
+// Warnf indicates an expected call of Warnf.
+func (mr *MockLoggerMockRecorder) Warnf(format interface{}, v ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{format}, v...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warnf", reflect.TypeOf((*MockLogger)(nil).Warnf), varargs...)
+}
+
+// Warnw mocks base method.
+func (m *MockLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range keysAndValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Warnw", varargs...)
+}
+
+// Warnw indicates an expected call of Warnw.
+func (mr *MockLoggerMockRecorder) Warnw(msg interface{}, keysAndValues ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, keysAndValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warnw", reflect.TypeOf((*MockLogger)(nil).Warnw), varargs...)
+}
@@ -0,0 +1,306 @@
+// Package migrate applies golang-migrate style SQL files to Postgres. It
+// keeps the schema_migrations table of the migrate CLI so both can be used on
+// the same database
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/ayrtonsato/video-catalog-golang/pkg/logger"
+)
+
+var (
+	ErrDirty          = errors.New("migrate: database is dirty, fix the failed migration and force its version")
+	ErrUnknownVersion = errors.New("migrate: unknown migration version")
+	ErrMissingDown    = errors.New("migrate: migration has no down file")
+)
+
+// lockID is the Postgres advisory lock held while migrating so instances
+// started together do not migrate concurrently
+const lockID int64 = 0x76636174616c6f67
+
+var fileName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Version uint
+	Dirty   bool
+	Latest  uint
+	Pending []Migration
+}
+
+// Load reads the migrations of fsys sorted by version, files which are not
+// named as 000001_name.up.sql or 000001_name.down.sql are ignored
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		migration, ok := byVersion[uint(version)]
+		if !ok {
+			migration = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[uint(version)] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migrate: version %d is used by %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(data)
+		} else {
+			migration.Down = string(data)
+		}
+	}
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	log        logger.Logger
+}
+
+func New(db *sql.DB, fsys fs.FS, log logger.Logger) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		log:        log,
+	}, nil
+}
+
+// Latest is the version of the newest migration
+func (m *Migrator) Latest() uint {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies every pending migration
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		return m.migrate(ctx, conn, m.Latest())
+	})
+}
+
+// Down reverts the last steps migrations
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		current, _, err := m.version(ctx, conn)
+		if err != nil {
+			return err
+		}
+		index := m.index(current)
+		if index < 0 && current != 0 {
+			return fmt.Errorf("%w: %d", ErrUnknownVersion, current)
+		}
+		target := uint(0)
+		if index-steps >= 0 {
+			target = m.migrations[index-steps].Version
+		}
+		return m.migrate(ctx, conn, target)
+	})
+}
+
+// Goto migrates up or down to version, 0 reverts every migration
+func (m *Migrator) Goto(ctx context.Context, version uint) error {
+	if version != 0 && m.index(version) < 0 {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		return m.migrate(ctx, conn, version)
+	})
+}
+
+// Force records version as applied and clean without running anything, it
+// is how a dirty database is recovered once the failed migration is fixed
+func (m *Migrator) Force(ctx context.Context, version uint) error {
+	if version != 0 && m.index(version) < 0 {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if err = setVersion(ctx, tx, version); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	status := Status{Latest: m.Latest()}
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		var err error
+		status.Version, status.Dirty, err = m.version(ctx, conn)
+		return err
+	})
+	if err != nil {
+		return Status{}, err
+	}
+	for _, migration := range m.migrations {
+		if migration.Version > status.Version {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+	return status, nil
+}
+
+func (m *Migrator) index(version uint) int {
+	for i, migration := range m.migrations {
+		if migration.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// withLock runs fn on a single connection holding the advisory lock, the
+// lock belongs to the session so every statement must use conn
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockID); err != nil {
+		return err
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockID); err != nil {
+			m.log.Errorf("migrate: failed to release lock: %v", err)
+		}
+	}()
+	_, err = conn.ExecContext(ctx,
+		"CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)")
+	if err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func (m *Migrator) version(ctx context.Context, conn *sql.Conn) (uint, bool, error) {
+	var version int64
+	var dirty bool
+	err := conn.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return uint(version), dirty, nil
+}
+
+func (m *Migrator) migrate(ctx context.Context, conn *sql.Conn, target uint) error {
+	current, dirty, err := m.version(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return ErrDirty
+	}
+	if current == target {
+		m.log.Infof("migrate: version %d, no change", current)
+		return nil
+	}
+	if target > current {
+		for _, migration := range m.migrations {
+			if migration.Version <= current || migration.Version > target {
+				continue
+			}
+			if err = m.apply(ctx, conn, migration.Up, migration.Version); err != nil {
+				return fmt.Errorf("migrate: %d_%s up: %w", migration.Version, migration.Name, err)
+			}
+			m.log.Infof("migrate: applied %d_%s", migration.Version, migration.Name)
+		}
+		return nil
+	}
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if migration.Version > current || migration.Version <= target {
+			continue
+		}
+		if migration.Down == "" {
+			return fmt.Errorf("%w: %d_%s", ErrMissingDown, migration.Version, migration.Name)
+		}
+		previous := uint(0)
+		if i > 0 {
+			previous = m.migrations[i-1].Version
+		}
+		if err = m.apply(ctx, conn, migration.Down, previous); err != nil {
+			return fmt.Errorf("migrate: %d_%s down: %w", migration.Version, migration.Name, err)
+		}
+		m.log.Infof("migrate: reverted %d_%s", migration.Version, migration.Name)
+	}
+	return nil
+}
+
+// apply runs a migration and records version in one transaction, so a
+// failing migration leaves neither partial changes nor a dirty version
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, statements string, version uint) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, statements); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err = setVersion(ctx, tx, version); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func setVersion(ctx context.Context, tx *sql.Tx, version uint) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations"); err != nil {
+		return err
+	}
+	if version == 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)", int64(version))
+	return err
+}
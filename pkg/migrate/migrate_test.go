@@ -0,0 +1,150 @@
+package migrate
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	mock_logger "github.com/ayrtonsato/video-catalog-golang/pkg/logger/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var testFS = fstest.MapFS{
+	"000001_init.up.sql":     {Data: []byte("CREATE TABLE a (id INT);")},
+	"000001_init.down.sql":   {Data: []byte("DROP TABLE a;")},
+	"000002_second.up.sql":   {Data: []byte("CREATE TABLE b (id INT);")},
+	"000002_second.down.sql": {Data: []byte("DROP TABLE b;")},
+	"README.md":              {Data: []byte("not a migration")},
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(testFS)
+	require.NoError(t, err)
+	require.Equal(t, []Migration{
+		{Version: 1, Name: "init", Up: "CREATE TABLE a (id INT);", Down: "DROP TABLE a;"},
+		{Version: 2, Name: "second", Up: "CREATE TABLE b (id INT);", Down: "DROP TABLE b;"},
+	}, migrations)
+
+	_, err = Load(fstest.MapFS{
+		"000001_a.up.sql": {Data: []byte("")},
+		"000001_b.up.sql": {Data: []byte("")},
+	})
+	require.Error(t, err)
+}
+
+func expectLock(mock sqlmock.Sqlmock, version int64, dirty bool) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).
+		WithArgs(lockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	rows := sqlmock.NewRows([]string{"version", "dirty"})
+	if version > 0 {
+		rows.AddRow(version, dirty)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, dirty FROM schema_migrations")).WillReturnRows(rows)
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).
+		WithArgs(lockID).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectApply(mock sqlmock.Sqlmock, statements string, version int64) {
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(statements)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM schema_migrations").WillReturnResult(sqlmock.NewResult(0, 1))
+	if version > 0 {
+		mock.ExpectExec("INSERT INTO schema_migrations").
+			WithArgs(version).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+}
+
+func TestMigrator(t *testing.T) {
+	testCases := []struct {
+		name     string
+		testCase func(t *testing.T, SUT *Migrator, mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "Up applies pending migrations in order",
+			testCase: func(t *testing.T, SUT *Migrator, mock sqlmock.Sqlmock) {
+				expectLock(mock, 0, false)
+				expectApply(mock, "CREATE TABLE a (id INT);", 1)
+				expectApply(mock, "CREATE TABLE b (id INT);", 2)
+				expectUnlock(mock)
+				require.NoError(t, SUT.Up(context.Background()))
+			},
+		},
+		{
+			name: "Up refuses a dirty database",
+			testCase: func(t *testing.T, SUT *Migrator, mock sqlmock.Sqlmock) {
+				expectLock(mock, 1, true)
+				expectUnlock(mock)
+				require.ErrorIs(t, SUT.Up(context.Background()), ErrDirty)
+			},
+		},
+		{
+			name: "Down reverts the last migration",
+			testCase: func(t *testing.T, SUT *Migrator, mock sqlmock.Sqlmock) {
+				expectLock(mock, 2, false)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT version, dirty FROM schema_migrations")).
+					WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(2, false))
+				expectApply(mock, "DROP TABLE b;", 1)
+				expectUnlock(mock)
+				require.NoError(t, SUT.Down(context.Background(), 1))
+			},
+		},
+		{
+			name: "Goto 0 reverts every migration",
+			testCase: func(t *testing.T, SUT *Migrator, mock sqlmock.Sqlmock) {
+				expectLock(mock, 2, false)
+				expectApply(mock, "DROP TABLE b;", 1)
+				expectApply(mock, "DROP TABLE a;", 0)
+				expectUnlock(mock)
+				require.NoError(t, SUT.Goto(context.Background(), 0))
+			},
+		},
+		{
+			name: "Goto rejects unknown versions",
+			testCase: func(t *testing.T, SUT *Migrator, mock sqlmock.Sqlmock) {
+				require.ErrorIs(t, SUT.Goto(context.Background(), 7), ErrUnknownVersion)
+			},
+		},
+		{
+			name: "Status lists pending migrations",
+			testCase: func(t *testing.T, SUT *Migrator, mock sqlmock.Sqlmock) {
+				expectLock(mock, 1, false)
+				expectUnlock(mock)
+				status, err := SUT.Status(context.Background())
+				require.NoError(t, err)
+				require.Equal(t, uint(1), status.Version)
+				require.Equal(t, uint(2), status.Latest)
+				require.Len(t, status.Pending, 1)
+				require.Equal(t, "second", status.Pending[0].Name)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			log := mock_logger.NewMockLogger(ctrl)
+			log.EXPECT().Infof(gomock.Any(), gomock.Any()).AnyTimes()
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			SUT, err := New(db, testFS, log)
+			require.NoError(t, err)
+			tc.testCase(t, SUT, mock)
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("%s", err)
+			}
+		})
+	}
+}
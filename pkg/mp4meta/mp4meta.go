@@ -0,0 +1,245 @@
+package mp4meta
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	ErrInvalidFile   = errors.New("mp4meta: invalid or truncated mp4 file")
+	ErrNoMovie       = errors.New("mp4meta: moov atom not found")
+	ErrTooDeep       = errors.New("mp4meta: atoms are nested too deeply")
+	ErrTooManyTracks = errors.New("mp4meta: too many tracks")
+)
+
+const (
+	// maxLeafSize bounds how much of a leaf atom is read in memory, the
+	// atoms parsed here are a few hundred bytes long
+	maxLeafSize = 1 << 20
+	// maxDepth bounds container nesting, moov/trak/mdia/minf/stbl is five
+	// levels deep in a valid file
+	maxDepth = 8
+	// maxTracks bounds the trak atoms kept while looking for the video one
+	maxTracks = 64
+)
+
+type Metadata struct {
+	// Brand is the major brand of the ftyp atom, "qt  " for mov files
+	Brand    string
+	Duration time.Duration
+	Width    int
+	Height   int
+	// Codec is the fourcc of the first video track sample entry, as avc1
+	Codec string
+}
+
+type atom struct {
+	kind  string
+	start int64
+	end   int64
+}
+
+type track struct {
+	handler string
+	width   int
+	height  int
+	codec   string
+}
+
+type parser struct {
+	r        io.ReaderAt
+	meta     Metadata
+	hasMovie bool
+	current  *track
+	tracks   []*track
+}
+
+// Parse reads the moov atom of an mp4 or mov file of size bytes, only the
+// atom headers are read from mdat so the media data is never loaded
+func Parse(r io.ReaderAt, size int64) (Metadata, error) {
+	p := &parser{r: r}
+	if err := p.walk(0, size, 0); err != nil {
+		return Metadata{}, err
+	}
+	if !p.hasMovie {
+		return Metadata{}, ErrNoMovie
+	}
+	for _, t := range p.tracks {
+		if t.handler == "vide" {
+			p.meta.Width = t.width
+			p.meta.Height = t.height
+			p.meta.Codec = t.codec
+			break
+		}
+	}
+	return p.meta, nil
+}
+
+func (p *parser) walk(start, end int64, depth int) error {
+	if depth > maxDepth {
+		return ErrTooDeep
+	}
+	offset := start
+	for offset+8 <= end {
+		a, err := p.readHeader(offset, end)
+		if err != nil {
+			return err
+		}
+		if err = p.visit(a, depth); err != nil {
+			return err
+		}
+		offset = a.end
+	}
+	return nil
+}
+
+func (p *parser) readHeader(offset, limit int64) (atom, error) {
+	header := make([]byte, 8)
+	if _, err := p.r.ReadAt(header, offset); err != nil {
+		return atom{}, ErrInvalidFile
+	}
+	size := int64(binary.BigEndian.Uint32(header[0:4]))
+	a := atom{kind: string(header[4:8]), start: offset + 8}
+	switch size {
+	case 0:
+		// the atom extends to the end of the file
+		size = limit - offset
+	case 1:
+		large := make([]byte, 8)
+		if _, err := p.r.ReadAt(large, offset+8); err != nil {
+			return atom{}, ErrInvalidFile
+		}
+		size = int64(binary.BigEndian.Uint64(large))
+		a.start = offset + 16
+	}
+	a.end = offset + size
+	if a.end < a.start || a.end > limit {
+		return atom{}, ErrInvalidFile
+	}
+	return a, nil
+}
+
+func (p *parser) visit(a atom, depth int) error {
+	switch a.kind {
+	case "moov":
+		p.hasMovie = true
+		return p.walk(a.start, a.end, depth+1)
+	case "trak":
+		if len(p.tracks) == maxTracks {
+			return ErrTooManyTracks
+		}
+		p.current = &track{}
+		p.tracks = append(p.tracks, p.current)
+		return p.walk(a.start, a.end, depth+1)
+	case "mdia", "minf", "stbl":
+		return p.walk(a.start, a.end, depth+1)
+	case "ftyp":
+		return p.parseFtyp(a)
+	case "mvhd":
+		return p.parseMvhd(a)
+	case "tkhd":
+		return p.parseTkhd(a)
+	case "hdlr":
+		return p.parseHdlr(a)
+	case "stsd":
+		return p.parseStsd(a)
+	}
+	return nil
+}
+
+func (p *parser) read(a atom, min int) ([]byte, error) {
+	size := a.end - a.start
+	if size < int64(min) || size > maxLeafSize {
+		return nil, ErrInvalidFile
+	}
+	data := make([]byte, size)
+	if _, err := p.r.ReadAt(data, a.start); err != nil {
+		return nil, ErrInvalidFile
+	}
+	return data, nil
+}
+
+func (p *parser) parseFtyp(a atom) error {
+	data, err := p.read(a, 4)
+	if err != nil {
+		return err
+	}
+	p.meta.Brand = string(data[0:4])
+	return nil
+}
+
+func (p *parser) parseMvhd(a atom) error {
+	data, err := p.read(a, 20)
+	if err != nil {
+		return err
+	}
+	var timescale, duration uint64
+	if data[0] == 1 {
+		if len(data) < 32 {
+			return ErrInvalidFile
+		}
+		timescale = uint64(binary.BigEndian.Uint32(data[20:24]))
+		duration = binary.BigEndian.Uint64(data[24:32])
+	} else {
+		timescale = uint64(binary.BigEndian.Uint32(data[12:16]))
+		duration = uint64(binary.BigEndian.Uint32(data[16:20]))
+	}
+	if timescale == 0 {
+		return ErrInvalidFile
+	}
+	seconds := duration / timescale
+	remainder := duration % timescale
+	p.meta.Duration = time.Duration(seconds)*time.Second +
+		time.Duration(remainder)*time.Second/time.Duration(timescale)
+	return nil
+}
+
+func (p *parser) parseTkhd(a atom) error {
+	if p.current == nil {
+		return nil
+	}
+	data, err := p.read(a, 84)
+	if err != nil {
+		return err
+	}
+	// width and height are the last two 16.16 fixed point fields
+	offset := 76
+	if data[0] == 1 {
+		offset = 88
+		if len(data) < 96 {
+			return ErrInvalidFile
+		}
+	}
+	p.current.width = int(binary.BigEndian.Uint32(data[offset:offset+4]) >> 16)
+	p.current.height = int(binary.BigEndian.Uint32(data[offset+4:offset+8]) >> 16)
+	return nil
+}
+
+func (p *parser) parseHdlr(a atom) error {
+	if p.current == nil || p.current.handler != "" {
+		return nil
+	}
+	data, err := p.read(a, 12)
+	if err != nil {
+		return err
+	}
+	p.current.handler = string(data[8:12])
+	return nil
+}
+
+func (p *parser) parseStsd(a atom) error {
+	if p.current == nil {
+		return nil
+	}
+	data, err := p.read(a, 16)
+	if err != nil {
+		return err
+	}
+	if binary.BigEndian.Uint32(data[4:8]) == 0 {
+		return nil
+	}
+	p.current.codec = string(data[12:16])
+	return nil
+}
@@ -0,0 +1,98 @@
+package mp4meta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func box(kind string, payload ...[]byte) []byte {
+	body := bytes.Join(payload, nil)
+	out := make([]byte, 8, 8+len(body))
+	binary.BigEndian.PutUint32(out[0:4], uint32(8+len(body)))
+	copy(out[4:8], kind)
+	return append(out, body...)
+}
+
+func mvhd(timescale, duration uint32) []byte {
+	payload := make([]byte, 100)
+	binary.BigEndian.PutUint32(payload[12:16], timescale)
+	binary.BigEndian.PutUint32(payload[16:20], duration)
+	return box("mvhd", payload)
+}
+
+func tkhd(width, height uint32) []byte {
+	payload := make([]byte, 84)
+	binary.BigEndian.PutUint32(payload[76:80], width<<16)
+	binary.BigEndian.PutUint32(payload[80:84], height<<16)
+	return box("tkhd", payload)
+}
+
+func hdlr(handler string) []byte {
+	payload := make([]byte, 24)
+	copy(payload[8:12], handler)
+	return box("hdlr", payload)
+}
+
+func stsd(codec string) []byte {
+	payload := make([]byte, 16)
+	binary.BigEndian.PutUint32(payload[4:8], 1)
+	binary.BigEndian.PutUint32(payload[8:12], 8)
+	copy(payload[12:16], codec)
+	return box("stsd", payload)
+}
+
+func trak(handler string, width, height uint32, codec string) []byte {
+	return box("trak",
+		tkhd(width, height),
+		box("mdia", hdlr(handler), box("minf", box("stbl", stsd(codec)))))
+}
+
+func TestParse(t *testing.T) {
+	file := bytes.Join([][]byte{
+		box("ftyp", []byte("isom")),
+		box("mdat", make([]byte, 64)),
+		box("moov",
+			mvhd(1000, 95500),
+			trak("soun", 0, 0, "mp4a"),
+			trak("vide", 1920, 1080, "avc1")),
+	}, nil)
+
+	meta, err := Parse(bytes.NewReader(file), int64(len(file)))
+	require.NoError(t, err)
+	require.Equal(t, 95500*time.Millisecond, meta.Duration)
+	require.Equal(t, 1920, meta.Width)
+	require.Equal(t, 1080, meta.Height)
+	require.Equal(t, "avc1", meta.Codec)
+	require.Equal(t, "isom", meta.Brand)
+}
+
+func TestParse_Errors(t *testing.T) {
+	noMovie := box("ftyp", []byte("isom"))
+	_, err := Parse(bytes.NewReader(noMovie), int64(len(noMovie)))
+	require.ErrorIs(t, err, ErrNoMovie)
+
+	truncated := box("moov", mvhd(1000, 1000))
+	truncated = truncated[:len(truncated)-10]
+	_, err = Parse(bytes.NewReader(truncated), int64(len(truncated)))
+	require.ErrorIs(t, err, ErrInvalidFile)
+
+	nested := box("stbl")
+	for i := 0; i < maxDepth; i++ {
+		nested = box("minf", nested)
+	}
+	nested = box("moov", nested)
+	_, err = Parse(bytes.NewReader(nested), int64(len(nested)))
+	require.ErrorIs(t, err, ErrTooDeep)
+
+	tracks := make([][]byte, maxTracks+1)
+	for i := range tracks {
+		tracks[i] = box("trak")
+	}
+	many := box("moov", tracks...)
+	_, err = Parse(bytes.NewReader(many), int64(len(many)))
+	require.ErrorIs(t, err, ErrTooManyTracks)
+}
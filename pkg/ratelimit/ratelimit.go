@@ -0,0 +1,131 @@
+// Package ratelimit implements token bucket rate limiting kept in memory or
+// in Redis so every instance of the api shares the same buckets
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidLimit = errors.New("ratelimit: invalid limit")
+
+// Limit refills a bucket of Burst tokens at Rate tokens per second
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Result of taking a token, Reset is the time until the bucket is full again
+// and RetryAfter the time until the next token when the request was denied
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+type Limiter interface {
+	Allow(key string, limit Limit) (Result, error)
+}
+
+// ParseLimit reads a limit written as count/unit, like 100/m, allowing count
+// requests at once and refilling them over one s, m or h
+func ParseLimit(spec string) (Limit, error) {
+	parts := strings.SplitN(strings.TrimSpace(spec), "/", 2)
+	if len(parts) != 2 {
+		return Limit{}, fmt.Errorf("%w: %q", ErrInvalidLimit, spec)
+	}
+	count, err := strconv.Atoi(parts[0])
+	if err != nil || count <= 0 {
+		return Limit{}, fmt.Errorf("%w: %q", ErrInvalidLimit, spec)
+	}
+	var period time.Duration
+	switch parts[1] {
+	case "s":
+		period = time.Second
+	case "m":
+		period = time.Minute
+	case "h":
+		period = time.Hour
+	default:
+		return Limit{}, fmt.Errorf("%w: %q", ErrInvalidLimit, spec)
+	}
+	return Limit{Rate: float64(count) / period.Seconds(), Burst: count}, nil
+}
+
+// take refills tokens for the time elapsed since the last request and takes
+// one if available, the Redis script follows the same steps
+func take(tokens float64, elapsed time.Duration, limit Limit) (float64, Result) {
+	tokens = math.Min(float64(limit.Burst), tokens+elapsed.Seconds()*limit.Rate)
+	result := Result{Limit: limit.Burst}
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - tokens) / limit.Rate)
+	}
+	result.Remaining = int(math.Floor(tokens))
+	result.Reset = seconds((float64(limit.Burst) - tokens) / limit.Rate)
+	return tokens, result
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// Rules picks the limit of a route, routes without their own limit share the
+// Default bucket of a client. A zero Default leaves them unlimited
+type Rules struct {
+	Default Limit
+	Routes  map[string]Limit
+}
+
+// ParseRules reads the default limit and route limits written as
+// "METHOD path=limit;METHOD path=limit", like "POST /category=30/m"
+func ParseRules(defaultSpec string, routesSpec string) (Rules, error) {
+	rules := Rules{Routes: make(map[string]Limit)}
+	if strings.TrimSpace(defaultSpec) != "" {
+		limit, err := ParseLimit(defaultSpec)
+		if err != nil {
+			return Rules{}, err
+		}
+		rules.Default = limit
+	}
+	for _, entry := range strings.Split(routesSpec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		i := strings.LastIndex(entry, "=")
+		if i < 0 {
+			return Rules{}, fmt.Errorf("%w: %q", ErrInvalidLimit, entry)
+		}
+		limit, err := ParseLimit(entry[i+1:])
+		if err != nil {
+			return Rules{}, err
+		}
+		rules.Routes[strings.Join(strings.Fields(entry[:i]), " ")] = limit
+	}
+	return rules, nil
+}
+
+// Empty tells whether no request is limited
+func (r Rules) Empty() bool {
+	return r.Default.Burst == 0 && len(r.Routes) == 0
+}
+
+// For returns the limit of route and the name of its bucket
+func (r Rules) For(route string) (Limit, string, bool) {
+	if limit, ok := r.Routes[route]; ok {
+		return limit, route, true
+	}
+	if r.Default.Burst > 0 {
+		return r.Default, "default", true
+	}
+	return Limit{}, "", false
+}
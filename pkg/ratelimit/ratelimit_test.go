@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimit(t *testing.T) {
+	limit, err := ParseLimit("120/m")
+	require.NoError(t, err)
+	require.Equal(t, Limit{Rate: 2, Burst: 120}, limit)
+
+	for _, spec := range []string{"", "10", "0/s", "10/d", "x/m"} {
+		_, err = ParseLimit(spec)
+		require.ErrorIs(t, err, ErrInvalidLimit, spec)
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules("100/m", " POST  /category=2/s ; POST /video/:id/video=10/h")
+	require.NoError(t, err)
+
+	limit, bucket, ok := rules.For("POST /category")
+	require.True(t, ok)
+	require.Equal(t, "POST /category", bucket)
+	require.Equal(t, Limit{Rate: 2, Burst: 2}, limit)
+
+	_, bucket, ok = rules.For("GET /category")
+	require.True(t, ok)
+	require.Equal(t, "default", bucket)
+
+	rules, err = ParseRules("", "")
+	require.NoError(t, err)
+	require.True(t, rules.Empty())
+	_, _, ok = rules.For("GET /category")
+	require.False(t, ok)
+}
+
+func TestMemoryLimiter_Allow(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	limiter := NewMemoryLimiter()
+	limiter.now = func() time.Time { return now }
+	limit := Limit{Rate: 1, Burst: 2}
+
+	result, err := limiter.Allow("a", limit)
+	require.NoError(t, err)
+	require.Equal(t, Result{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Second}, result)
+
+	result, _ = limiter.Allow("a", limit)
+	require.True(t, result.Allowed)
+	require.Equal(t, 0, result.Remaining)
+
+	result, _ = limiter.Allow("a", limit)
+	require.False(t, result.Allowed)
+	require.Equal(t, time.Second, result.RetryAfter)
+	require.Equal(t, 2*time.Second, result.Reset)
+
+	result, _ = limiter.Allow("b", limit)
+	require.True(t, result.Allowed, "buckets are per key")
+
+	now = now.Add(1500 * time.Millisecond)
+	result, _ = limiter.Allow("a", limit)
+	require.True(t, result.Allowed)
+	require.Equal(t, 0, result.Remaining)
+
+	now = now.Add(2 * time.Hour)
+	limiter.Allow("c", limit)
+	require.NotContains(t, limiter.buckets, "a", "idle buckets are swept")
+}
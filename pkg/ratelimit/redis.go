@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"strconv"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// tokenBucket mirrors take, the clock of Redis is used so instances with
+// skewed clocks share buckets correctly
+var tokenBucket = redis.NewScript(1, `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000 + math.floor(tonumber(clock[2]) / 1000)
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - ts) / 1000 * rate)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HMSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000))
+return {allowed, tostring(tokens)}
+`)
+
+// RedisLimiter keeps buckets in Redis so limits hold across instances
+type RedisLimiter struct {
+	pool   *redis.Pool
+	prefix string
+}
+
+func NewRedisLimiter(pool *redis.Pool, prefix string) *RedisLimiter {
+	return &RedisLimiter{
+		pool:   pool,
+		prefix: prefix,
+	}
+}
+
+func (r *RedisLimiter) Allow(key string, limit Limit) (Result, error) {
+	conn := r.pool.Get()
+	defer conn.Close()
+
+	reply, err := redis.Values(tokenBucket.Do(conn, r.prefix+key, limit.Rate, limit.Burst))
+	if err != nil {
+		return Result{}, err
+	}
+	var allowed int
+	var remaining string
+	if _, err = redis.Scan(reply, &allowed, &remaining); err != nil {
+		return Result{}, err
+	}
+	tokens, err := strconv.ParseFloat(remaining, 64)
+	if err != nil {
+		return Result{}, err
+	}
+	// take with no elapsed time and one token added back recomputes the
+	// result from the stored tokens without refilling them again
+	_, result := take(tokens+float64(allowed), 0, limit)
+	return result, nil
+}
@@ -0,0 +1,136 @@
+package signedurl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoKeys           = errors.New("signedurl: no signing keys configured")
+	ErrUnknownKey       = errors.New("signedurl: unknown signing key")
+	ErrMalformed        = errors.New("signedurl: malformed signed url")
+	ErrExpired          = errors.New("signedurl: url expired")
+	ErrInvalidSignature = errors.New("signedurl: invalid signature")
+	ErrIPMismatch       = errors.New("signedurl: client ip does not match")
+)
+
+const (
+	ParamExpires     = "expires"
+	ParamKeyID       = "kid"
+	ParamIP          = "ip"
+	ParamDisposition = "disposition"
+	ParamSignature   = "signature"
+)
+
+type Options struct {
+	Expires     time.Time
+	ClientIP    string
+	Disposition string
+}
+
+// Signer signs and verifies paths with HMAC-SHA256. New urls are always
+// signed with the current key, while every configured key is accepted on
+// verification so keys can be rotated without invalidating live urls.
+type Signer struct {
+	current string
+	keys    map[string][]byte
+	now     func() time.Time
+}
+
+func NewSigner(current string, keys map[string][]byte) (Signer, error) {
+	if len(keys) == 0 {
+		return Signer{}, ErrNoKeys
+	}
+	if _, ok := keys[current]; !ok {
+		return Signer{}, fmt.Errorf("%w: %v", ErrUnknownKey, current)
+	}
+	return Signer{
+		current: current,
+		keys:    keys,
+		now:     time.Now,
+	}, nil
+}
+
+// ParseKeys reads keys in the "kid:secret,kid:secret" format
+func ParseKeys(raw string) (map[string][]byte, error) {
+	keys := make(map[string][]byte)
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("signedurl: invalid key %q", parts[0])
+		}
+		keys[parts[0]] = []byte(parts[1])
+	}
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+	return keys, nil
+}
+
+func (s Signer) signature(secret []byte, path string, expires string, ip string, disposition string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strings.Join([]string{path, expires, ip, disposition}, "\n")))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Sign returns the query values that authorize a download of path
+func (s Signer) Sign(path string, opts Options) url.Values {
+	expires := strconv.FormatInt(opts.Expires.Unix(), 10)
+	query := url.Values{}
+	query.Set(ParamExpires, expires)
+	query.Set(ParamKeyID, s.current)
+	if opts.ClientIP != "" {
+		query.Set(ParamIP, opts.ClientIP)
+	}
+	if opts.Disposition != "" {
+		query.Set(ParamDisposition, opts.Disposition)
+	}
+	query.Set(ParamSignature,
+		s.signature(s.keys[s.current], path, expires, opts.ClientIP, opts.Disposition))
+	return query
+}
+
+// Verify checks the query values produced by Sign for path and returns the
+// signed options
+func (s Signer) Verify(path string, query url.Values, clientIP string) (Options, error) {
+	expires := query.Get(ParamExpires)
+	sig := query.Get(ParamSignature)
+	if expires == "" || sig == "" {
+		return Options{}, ErrMalformed
+	}
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return Options{}, ErrMalformed
+	}
+	secret, ok := s.keys[query.Get(ParamKeyID)]
+	if !ok {
+		return Options{}, ErrUnknownKey
+	}
+	opts := Options{
+		Expires:     time.Unix(unix, 0),
+		ClientIP:    query.Get(ParamIP),
+		Disposition: query.Get(ParamDisposition),
+	}
+	expected := s.signature(secret, path, expires, opts.ClientIP, opts.Disposition)
+	if !hmac.Equal([]byte(expected), []byte(sig)) {
+		return Options{}, ErrInvalidSignature
+	}
+	if !s.now().Before(opts.Expires) {
+		return Options{}, ErrExpired
+	}
+	if opts.ClientIP != "" && opts.ClientIP != clientIP {
+		return Options{}, ErrIPMismatch
+	}
+	return opts, nil
+}
@@ -0,0 +1,115 @@
+package signedurl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeys(t *testing.T) {
+	keys, err := ParseKeys("k1:secret_one, k2:secret:two")
+	require.NoError(t, err)
+	require.Equal(t, []byte("secret_one"), keys["k1"])
+	require.Equal(t, []byte("secret:two"), keys["k2"])
+
+	_, err = ParseKeys("")
+	require.ErrorIs(t, err, ErrNoKeys)
+
+	_, err = ParseKeys("k1")
+	require.Error(t, err)
+}
+
+func TestSigner_Verify(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	keys := map[string][]byte{
+		"old": []byte("old_secret"),
+		"new": []byte("new_secret"),
+	}
+	testCases := []struct {
+		name string
+		tc   func(t *testing.T, signer Signer)
+	}{
+		{
+			name: "Should accept a valid url",
+			tc: func(t *testing.T, signer Signer) {
+				query := signer.Sign("videos/1/video.mp4", Options{
+					Expires:     now.Add(time.Minute),
+					Disposition: "attachment",
+				})
+				opts, err := signer.Verify("videos/1/video.mp4", query, "10.0.0.1")
+				require.NoError(t, err)
+				require.Equal(t, "attachment", opts.Disposition)
+				require.Equal(t, now.Add(time.Minute).Unix(), opts.Expires.Unix())
+			},
+		},
+		{
+			name: "Should reject an expired url",
+			tc: func(t *testing.T, signer Signer) {
+				query := signer.Sign("videos/1/video.mp4", Options{Expires: now})
+				_, err := signer.Verify("videos/1/video.mp4", query, "")
+				require.ErrorIs(t, err, ErrExpired)
+			},
+		},
+		{
+			name: "Should reject a url signed for another path",
+			tc: func(t *testing.T, signer Signer) {
+				query := signer.Sign("videos/1/video.mp4", Options{Expires: now.Add(time.Minute)})
+				_, err := signer.Verify("videos/2/video.mp4", query, "")
+				require.ErrorIs(t, err, ErrInvalidSignature)
+			},
+		},
+		{
+			name: "Should reject a tampered disposition",
+			tc: func(t *testing.T, signer Signer) {
+				query := signer.Sign("videos/1/video.mp4", Options{Expires: now.Add(time.Minute)})
+				query.Set(ParamDisposition, "attachment")
+				_, err := signer.Verify("videos/1/video.mp4", query, "")
+				require.ErrorIs(t, err, ErrInvalidSignature)
+			},
+		},
+		{
+			name: "Should reject a different client ip when bound",
+			tc: func(t *testing.T, signer Signer) {
+				query := signer.Sign("videos/1/video.mp4", Options{
+					Expires:  now.Add(time.Minute),
+					ClientIP: "10.0.0.1",
+				})
+				_, err := signer.Verify("videos/1/video.mp4", query, "10.0.0.2")
+				require.ErrorIs(t, err, ErrIPMismatch)
+				_, err = signer.Verify("videos/1/video.mp4", query, "10.0.0.1")
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Should accept urls signed with a rotated key",
+			tc: func(t *testing.T, signer Signer) {
+				old, err := NewSigner("old", keys)
+				require.NoError(t, err)
+				old.now = signer.now
+				query := old.Sign("videos/1/video.mp4", Options{Expires: now.Add(time.Minute)})
+				require.Equal(t, "old", query.Get(ParamKeyID))
+				_, err = signer.Verify("videos/1/video.mp4", query, "")
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Should reject unknown keys",
+			tc: func(t *testing.T, signer Signer) {
+				query := signer.Sign("videos/1/video.mp4", Options{Expires: now.Add(time.Minute)})
+				query.Set(ParamKeyID, "missing")
+				_, err := signer.Verify("videos/1/video.mp4", query, "")
+				require.ErrorIs(t, err, ErrUnknownKey)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			signer, err := NewSigner("new", keys)
+			require.NoError(t, err)
+			signer.now = func() time.Time { return now }
+			tc.tc(t, signer)
+		})
+	}
+}
@@ -0,0 +1,272 @@
+// Package sqltrace wraps a database/sql driver so every statement run with a
+// traced context gets a span holding the statement, the rows it affected or
+// returned and its error
+package sqltrace
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	rowsAffectedKey = attribute.Key("db.rows_affected")
+	rowsReturnedKey = attribute.Key("db.rows_returned")
+)
+
+type connector struct {
+	dsn    string
+	driver driver.Driver
+	tracer trace.Tracer
+}
+
+// NewConnector opens connections to dsn with d, use it with sql.OpenDB.
+// Statements run without a span in their context are not traced so
+// background work does not create root spans
+func NewConnector(d driver.Driver, dsn string, tracer trace.Tracer) driver.Connector {
+	return &connector{dsn: dsn, driver: d, tracer: tracer}
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedConn{Conn: conn, tracer: c.tracer}, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.driver
+}
+
+func start(ctx context.Context, tracer trace.Tracer, query string) (context.Context, trace.Span) {
+	if !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+		return ctx, nil
+	}
+	return tracer.Start(ctx, spanName(query),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBStatementKey.String(query),
+		))
+}
+
+// spanName is the operation of the statement like SELECT, statements can be
+// long and hold values so they are only kept as attribute
+func spanName(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "sql"
+	}
+	return "sql " + strings.ToUpper(fields[0])
+}
+
+func end(span trace.Span, err error, attrs ...attribute.KeyValue) {
+	if span == nil {
+		return
+	}
+	span.SetAttributes(attrs...)
+	if err != nil && err != driver.ErrSkip && err != io.EOF {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+type tracedConn struct {
+	driver.Conn
+	tracer trace.Tracer
+}
+
+func (c *tracedConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *tracedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tracedStmt{Stmt: stmt, query: query, tracer: c.tracer}, nil
+}
+
+func (c *tracedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *tracedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	ctx, span := start(ctx, c.tracer, query)
+	result, err := execer.ExecContext(ctx, query, args)
+	end(span, err, rowsAffected(result)...)
+	return result, err
+}
+
+func (c *tracedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	ctx, span := start(ctx, c.tracer, query)
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != nil {
+		end(span, err)
+		return nil, err
+	}
+	return wrapRows(rows, span), nil
+}
+
+func (c *tracedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *tracedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *tracedConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func (c *tracedConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+type tracedStmt struct {
+	driver.Stmt
+	query  string
+	tracer trace.Tracer
+}
+
+func (s *tracedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	ctx, span := start(ctx, s.tracer, s.query)
+	var result driver.Result
+	var err error
+	if execer, ok := s.Stmt.(driver.StmtExecContext); ok {
+		result, err = execer.ExecContext(ctx, args)
+	} else {
+		values, convertErr := namedToValues(args)
+		if convertErr != nil {
+			end(span, convertErr)
+			return nil, convertErr
+		}
+		result, err = s.Stmt.Exec(values)
+	}
+	end(span, err, rowsAffected(result)...)
+	return result, err
+}
+
+func (s *tracedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	ctx, span := start(ctx, s.tracer, s.query)
+	var rows driver.Rows
+	var err error
+	if queryer, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = queryer.QueryContext(ctx, args)
+	} else {
+		values, convertErr := namedToValues(args)
+		if convertErr != nil {
+			end(span, convertErr)
+			return nil, convertErr
+		}
+		rows, err = s.Stmt.Query(values)
+	}
+	if err != nil {
+		end(span, err)
+		return nil, err
+	}
+	return wrapRows(rows, span), nil
+}
+
+func (s *tracedStmt) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+// tracedRows ends the span of its query once the rows are closed, so the
+// span covers reading them
+type tracedRows struct {
+	driver.Rows
+	span     trace.Span
+	returned int64
+	err      error
+}
+
+func wrapRows(rows driver.Rows, span trace.Span) driver.Rows {
+	if span == nil {
+		return rows
+	}
+	return &tracedRows{Rows: rows, span: span}
+}
+
+func (r *tracedRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	if err == nil {
+		r.returned++
+	} else if err != io.EOF {
+		r.err = err
+	}
+	return err
+}
+
+func (r *tracedRows) Close() error {
+	err := r.Rows.Close()
+	if r.err == nil {
+		r.err = err
+	}
+	end(r.span, r.err, rowsReturnedKey.Int64(r.returned))
+	return err
+}
+
+func rowsAffected(result driver.Result) []attribute.KeyValue {
+	if result == nil {
+		return nil
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil
+	}
+	return []attribute.KeyValue{rowsAffectedKey.Int64(affected)}
+}
+
+func namedToValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, driver.ErrSkip
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
@@ -0,0 +1,96 @@
+package sqltrace
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setup(t *testing.T, dsn string) (*sql.DB, sqlmock.Sqlmock, *tracetest.SpanRecorder, *sdktrace.TracerProvider) {
+	_, mock, err := sqlmock.NewWithDSN(dsn)
+	require.NoError(t, err)
+	opened, err := sql.Open("sqlmock", dsn)
+	require.NoError(t, err)
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	db := sql.OpenDB(NewConnector(opened.Driver(), dsn, provider.Tracer("test")))
+	t.Cleanup(func() {
+		db.Close()
+		opened.Close()
+	})
+	return db, mock, recorder, provider
+}
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, attr := range span.Attributes() {
+		attrs[attr.Key] = attr.Value
+	}
+	return attrs
+}
+
+func TestConnector_Exec(t *testing.T) {
+	db, mock, recorder, provider := setup(t, "exec")
+	mock.ExpectExec("UPDATE categories").WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec("DELETE FROM genres").WillReturnError(errors.New("boom"))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	_, err := db.ExecContext(ctx, "UPDATE categories SET is_active=$1", false)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "DELETE FROM genres")
+	require.Error(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	require.Equal(t, "sql UPDATE", spans[0].Name())
+	require.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	attrs := attributes(spans[0])
+	require.Equal(t, "UPDATE categories SET is_active=$1", attrs["db.statement"].AsString())
+	require.Equal(t, int64(3), attrs["db.rows_affected"].AsInt64())
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+}
+
+func TestConnector_Query(t *testing.T) {
+	db, mock, recorder, provider := setup(t, "query")
+	mock.ExpectQuery("SELECT id FROM categories").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectPrepare("SELECT id FROM genres").
+		ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	rows, err := db.QueryContext(ctx, "SELECT id FROM categories")
+	require.NoError(t, err)
+	for rows.Next() {
+	}
+	require.NoError(t, rows.Close())
+	stmt, err := db.PrepareContext(ctx, "SELECT id FROM genres WHERE id=$1")
+	require.NoError(t, err)
+	var id int
+	require.NoError(t, stmt.QueryRowContext(ctx, 1).Scan(&id))
+	require.NoError(t, stmt.Close())
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	require.Equal(t, "sql SELECT", spans[0].Name())
+	require.Equal(t, int64(2), attributes(spans[0])["db.rows_returned"].AsInt64())
+	require.Equal(t, int64(1), attributes(spans[1])["db.rows_returned"].AsInt64())
+}
+
+func TestConnector_Untraced(t *testing.T) {
+	db, mock, recorder, _ := setup(t, "untraced")
+	mock.ExpectExec("UPDATE categories").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	_, err := db.ExecContext(context.Background(), "UPDATE categories SET is_active=false")
+	require.NoError(t, err)
+	require.Empty(t, recorder.Ended())
+}
@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+type LocalStorage struct {
+	root string
+}
+
+func NewLocalStorage(root string) LocalStorage {
+	return LocalStorage{
+		root: root,
+	}
+}
+
+// resolve maps a storage path into the local filesystem, refusing any
+// path that would escape the root directory
+func (l LocalStorage) resolve(p string) (string, error) {
+	if p == "" || strings.Contains(p, "\\") {
+		return "", ErrInvalidPath
+	}
+	clean := path.Clean("/" + p)
+	if clean == "/" || clean != "/"+strings.TrimPrefix(p, "/") {
+		return "", ErrInvalidPath
+	}
+	return filepath.Join(l.root, filepath.FromSlash(clean)), nil
+}
+
+func (l LocalStorage) Put(p string, r io.Reader) error {
+	full, err := l.resolve(p)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return err
+	}
+	// write into a temporary file first so readers never see partial files
+	tmp, err := ioutil.TempFile(filepath.Dir(full), ".upload-*")
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), full)
+}
+
+func (l LocalStorage) Open(p string) (io.ReadCloser, error) {
+	full, err := l.resolve(p)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(full)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return file, nil
+}
+
+func (l LocalStorage) Stat(p string) (FileInfo, error) {
+	full, err := l.resolve(p)
+	if err != nil {
+		return FileInfo{}, err
+	}
+	info, err := os.Stat(full)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return FileInfo{}, ErrNotFound
+		}
+		return FileInfo{}, err
+	}
+	if info.IsDir() {
+		return FileInfo{}, ErrNotFound
+	}
+	return FileInfo{
+		Path:    strings.TrimPrefix(path.Clean("/"+p), "/"),
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}, nil
+}
+
+func (l LocalStorage) Delete(p string) error {
+	full, err := l.resolve(p)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(full)
+	if err != nil && errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func (l LocalStorage) Move(from string, to string) error {
+	fullFrom, err := l.resolve(from)
+	if err != nil {
+		return err
+	}
+	fullTo, err := l.resolve(to)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(fullTo), 0755); err != nil {
+		return err
+	}
+	err = os.Rename(fullFrom, fullTo)
+	if err != nil && errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func (l LocalStorage) List(prefix string) ([]FileInfo, error) {
+	files := make([]FileInfo, 0)
+	err := filepath.Walk(l.root, func(full string, info os.FileInfo, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(l.root, full)
+		if err != nil {
+			return err
+		}
+		p := filepath.ToSlash(rel)
+		if !strings.HasPrefix(p, prefix) {
+			return nil
+		}
+		files = append(files, FileInfo{
+			Path:    p,
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
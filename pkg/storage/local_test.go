@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage_List(t *testing.T) {
+	root, err := ioutil.TempDir("", "storage")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	SUT := NewLocalStorage(root)
+	require.NoError(t, SUT.Put("videos/1/video/source.mp4", strings.NewReader("video")))
+	require.NoError(t, SUT.Put("videos/2/thumb/320w.jpg", strings.NewReader("thumb")))
+	require.NoError(t, SUT.Put("blobs/ab/cd", strings.NewReader("blob")))
+
+	files, err := SUT.List("videos/")
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	require.Equal(t, "videos/1/video/source.mp4", files[0].Path)
+	require.Equal(t, int64(5), files[0].Size)
+
+	all, err := NewLocalStorage(root + "/missing").List("")
+	require.NoError(t, err)
+	require.Empty(t, all)
+}
+
+func TestLocalStorage_Move(t *testing.T) {
+	root, err := ioutil.TempDir("", "storage")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	SUT := NewLocalStorage(root)
+	require.NoError(t, SUT.Put("blobs/incoming/1.mp4", strings.NewReader("video")))
+	require.NoError(t, SUT.Move("blobs/incoming/1.mp4", "blobs/sha256/ab/cd/abcd.mp4"))
+
+	_, err = SUT.Stat("blobs/incoming/1.mp4")
+	require.ErrorIs(t, err, ErrNotFound)
+	info, err := SUT.Stat("blobs/sha256/ab/cd/abcd.mp4")
+	require.NoError(t, err)
+	require.Equal(t, int64(5), info.Size)
+	require.ErrorIs(t, SUT.Move("blobs/incoming/1.mp4", "blobs/other.mp4"), ErrNotFound)
+	require.ErrorIs(t, SUT.Move("../escape", "blobs/other.mp4"), ErrInvalidPath)
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	ErrNotFound    = errors.New("storage: file not found")
+	ErrInvalidPath = errors.New("storage: invalid path")
+)
+
+type FileInfo struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// Storage is the driver used to persist media files, paths are always
+// relative to the driver root and use forward slashes
+type Storage interface {
+	Put(path string, r io.Reader) error
+	Open(path string) (io.ReadCloser, error)
+	Stat(path string) (FileInfo, error)
+	Delete(path string) error
+	// Move renames from to to, replacing any file already stored at to
+	Move(from string, to string) error
+	// List returns every file whose path starts with prefix
+	List(prefix string) ([]FileInfo, error)
+}
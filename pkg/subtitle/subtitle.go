@@ -0,0 +1,219 @@
+package subtitle
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FormatSRT    = "srt"
+	FormatWebVTT = "vtt"
+)
+
+var (
+	ErrEmpty          = errors.New("subtitle: no cues found")
+	ErrMalformed      = errors.New("subtitle: malformed cue")
+	ErrInvalidTiming  = errors.New("subtitle: cue ends before it starts")
+	ErrOverlap        = errors.New("subtitle: cue overlaps the previous one")
+	ErrBeyondDuration = errors.New("subtitle: cue ends after the video")
+)
+
+// CueError tells which cue, counted from 1, is invalid
+type CueError struct {
+	Cue int
+	Err error
+}
+
+func (e *CueError) Error() string {
+	return fmt.Sprintf("cue %d: %v", e.Cue, e.Err)
+}
+
+func (e *CueError) Unwrap() error {
+	return e.Err
+}
+
+type Cue struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+// Parse reads a SRT or WebVTT file, the format is detected from the
+// WEBVTT header
+func Parse(r io.Reader) ([]Cue, string, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, "", err
+	}
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	format := FormatSRT
+	blocks := splitBlocks(text)
+	if len(blocks) > 0 && isWebVTTHeader(blocks[0][0]) {
+		format = FormatWebVTT
+		blocks = blocks[1:]
+	}
+	cues := make([]Cue, 0, len(blocks))
+	for _, block := range blocks {
+		if format == FormatWebVTT && isWebVTTMetadata(block[0]) {
+			continue
+		}
+		cue, err := parseCue(block, format)
+		if err != nil {
+			return nil, "", &CueError{Cue: len(cues) + 1, Err: err}
+		}
+		cues = append(cues, cue)
+	}
+	if len(cues) == 0 {
+		return nil, "", ErrEmpty
+	}
+	return cues, format, nil
+}
+
+// Validate checks the timing of cues, they must be sorted, must not overlap
+// and must end before maxDuration unless it is zero
+func Validate(cues []Cue, maxDuration time.Duration) error {
+	for i, cue := range cues {
+		if cue.End <= cue.Start {
+			return &CueError{Cue: i + 1, Err: ErrInvalidTiming}
+		}
+		if i > 0 && cue.Start < cues[i-1].End {
+			return &CueError{Cue: i + 1, Err: ErrOverlap}
+		}
+		if maxDuration > 0 && cue.End > maxDuration {
+			return &CueError{Cue: i + 1, Err: ErrBeyondDuration}
+		}
+	}
+	return nil
+}
+
+// WriteWebVTT writes cues as a WebVTT file
+func WriteWebVTT(w io.Writer, cues []Cue) error {
+	buf := bufio.NewWriter(w)
+	buf.WriteString("WEBVTT\n")
+	for _, cue := range cues {
+		fmt.Fprintf(buf, "\n%s --> %s\n%s\n", formatTimestamp(cue.Start), formatTimestamp(cue.End), cue.Text)
+	}
+	return buf.Flush()
+}
+
+func splitBlocks(text string) [][]string {
+	var blocks [][]string
+	var current []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				blocks = append(blocks, current)
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		blocks = append(blocks, current)
+	}
+	return blocks
+}
+
+func isWebVTTHeader(line string) bool {
+	return line == "WEBVTT" || strings.HasPrefix(line, "WEBVTT ") || strings.HasPrefix(line, "WEBVTT\t")
+}
+
+func isWebVTTMetadata(line string) bool {
+	for _, prefix := range []string{"NOTE", "STYLE", "REGION"} {
+		if line == prefix || strings.HasPrefix(line, prefix+" ") || strings.HasPrefix(line, prefix+"\t") {
+			return true
+		}
+	}
+	return false
+}
+
+func parseCue(block []string, format string) (Cue, error) {
+	// the timing line may be preceded by a cue identifier, numeric in srt
+	timing := 0
+	if !strings.Contains(block[0], "-->") {
+		timing = 1
+	}
+	if timing >= len(block) || !strings.Contains(block[timing], "-->") {
+		return Cue{}, ErrMalformed
+	}
+	parts := strings.SplitN(block[timing], "-->", 2)
+	start, err := parseTimestamp(strings.TrimSpace(parts[0]), format)
+	if err != nil {
+		return Cue{}, err
+	}
+	// webvtt cue settings follow the end timestamp
+	endFields := strings.Fields(parts[1])
+	if len(endFields) == 0 {
+		return Cue{}, ErrMalformed
+	}
+	end, err := parseTimestamp(endFields[0], format)
+	if err != nil {
+		return Cue{}, err
+	}
+	text := strings.Join(block[timing+1:], "\n")
+	if strings.TrimSpace(text) == "" {
+		return Cue{}, ErrMalformed
+	}
+	return Cue{Start: start, End: end, Text: text}, nil
+}
+
+// parseTimestamp reads hh:mm:ss,mmm in srt and [hh:]mm:ss.mmm in webvtt
+func parseTimestamp(value string, format string) (time.Duration, error) {
+	separator := "."
+	if format == FormatSRT {
+		separator = ","
+	}
+	parts := strings.Split(value, separator)
+	if len(parts) != 2 || len(parts[1]) != 3 {
+		return 0, ErrMalformed
+	}
+	millis, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, ErrMalformed
+	}
+	clock := strings.Split(parts[0], ":")
+	if len(clock) == 2 && format == FormatWebVTT {
+		clock = append([]string{"0"}, clock...)
+	}
+	if len(clock) != 3 {
+		return 0, ErrMalformed
+	}
+	var values [3]int
+	for i, part := range clock {
+		if part == "" {
+			return 0, ErrMalformed
+		}
+		values[i], err = strconv.Atoi(part)
+		if err != nil || values[i] < 0 {
+			return 0, ErrMalformed
+		}
+	}
+	if values[1] > 59 || values[2] > 59 {
+		return 0, ErrMalformed
+	}
+	return time.Duration(values[0])*time.Hour +
+		time.Duration(values[1])*time.Minute +
+		time.Duration(values[2])*time.Second +
+		time.Duration(millis)*time.Millisecond, nil
+}
+
+func formatTimestamp(d time.Duration) string {
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d / time.Second
+	d -= seconds * time.Second
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", hours, minutes, seconds, d/time.Millisecond)
+}
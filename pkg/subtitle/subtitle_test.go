@@ -0,0 +1,74 @@
+package subtitle
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const srtFile = "\ufeff1\r\n00:00:01,000 --> 00:00:02,500\r\nHello\r\n\r\n2\r\n00:01:00,000 --> 00:01:03,000\r\nTwo\r\nlines\r\n"
+
+const vttFile = `WEBVTT - sample
+
+NOTE this is ignored
+
+intro
+00:01.000 --> 00:02.500 align:start
+Hello
+
+01:00:00.000 --> 01:00:03.000
+Late
+`
+
+func TestParse(t *testing.T) {
+	cues, format, err := Parse(strings.NewReader(srtFile))
+	require.NoError(t, err)
+	require.Equal(t, FormatSRT, format)
+	require.Equal(t, []Cue{
+		{Start: time.Second, End: 2500 * time.Millisecond, Text: "Hello"},
+		{Start: time.Minute, End: time.Minute + 3*time.Second, Text: "Two\nlines"},
+	}, cues)
+
+	cues, format, err = Parse(strings.NewReader(vttFile))
+	require.NoError(t, err)
+	require.Equal(t, FormatWebVTT, format)
+	require.Len(t, cues, 2)
+	require.Equal(t, time.Second, cues[0].Start)
+	require.Equal(t, time.Hour, cues[1].Start)
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, _, err := Parse(strings.NewReader("WEBVTT\n"))
+	require.ErrorIs(t, err, ErrEmpty)
+
+	_, _, err = Parse(strings.NewReader("1\n00:00:01,000 --> 00:00:02,000\nok\n\n2\n00:00:03.000 --> 00:00:04,000\nbad\n"))
+	require.ErrorIs(t, err, ErrMalformed)
+	require.EqualError(t, err, "cue 2: subtitle: malformed cue")
+}
+
+func TestValidate(t *testing.T) {
+	cues := []Cue{
+		{Start: time.Second, End: 2 * time.Second, Text: "a"},
+		{Start: 2 * time.Second, End: 3 * time.Second, Text: "b"},
+	}
+	require.NoError(t, Validate(cues, time.Minute))
+	require.ErrorIs(t, Validate(cues, 2500*time.Millisecond), ErrBeyondDuration)
+
+	overlapping := []Cue{cues[0], {Start: 1500 * time.Millisecond, End: 3 * time.Second, Text: "b"}}
+	require.EqualError(t, Validate(overlapping, 0), "cue 2: subtitle: cue overlaps the previous one")
+
+	inverted := []Cue{{Start: 2 * time.Second, End: time.Second, Text: "a"}}
+	require.ErrorIs(t, Validate(inverted, 0), ErrInvalidTiming)
+}
+
+func TestWriteWebVTT(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteWebVTT(&buf, []Cue{
+		{Start: time.Second, End: time.Hour + 2500*time.Millisecond, Text: "Hello\nWorld"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "WEBVTT\n\n00:00:01.000 --> 01:00:02.500\nHello\nWorld\n", buf.String())
+}